@@ -0,0 +1,220 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+var listCmdOpts struct {
+	Filter []string
+	Order  []string
+	Start  int32
+	Limit  int32
+}
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the Engine(s) known to the server",
+	Long: `Lists the Engine(s) known to the server.
+
+Each --filter flag adds an expression that must match. Terms within an
+expression are separated by " or ". A term has the form:
+
+  field==value   field equals value (also field=value)
+  field!=value   field does not equal value
+  field|=value   field starts with value
+  field=|value   field ends with value
+  field~=value   field contains value
+  field?         field is set
+
+Prefixing a term with ! negates it. Orders have the form field[:asc|:desc].`,
+	Example: `  mathctl list --filter phase==running --filter "owner==alice or owner==bob"
+  mathctl list --filter annotation.column? --order created:asc --limit 10`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := parseFilter(listCmdOpts.Filter)
+		if err != nil {
+			return err
+		}
+		order, err := parseOrder(listCmdOpts.Order)
+		if err != nil {
+			return err
+		}
+
+		client, closeConn, err := dial()
+		if err != nil {
+			return err
+		}
+		defer closeConn()
+
+		resp, err := client.ListEngines(context.Background(), &v1.ListEnginesRequest{
+			Filter: filter,
+			Order:  order,
+			Start:  listCmdOpts.Start,
+			Limit:  listCmdOpts.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tOWNER\tPHASE\tSUCCESS\tCREATED")
+		for _, s := range resp.Result {
+			var created string
+			if c := s.Metadata.GetCreated(); c != nil {
+				created = c.AsTime().Local().Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\n",
+				s.Name,
+				s.Metadata.GetOwner(),
+				strings.ToLower(strings.TrimPrefix(s.Phase.String(), "PHASE_")),
+				s.Conditions.GetSuccess(),
+				created,
+			)
+		}
+		w.Flush()
+
+		if int(listCmdOpts.Start)+len(resp.Result) < int(resp.Total) {
+			fmt.Printf("\nshowing %d of %d Engine(s)\n", len(resp.Result), resp.Total)
+		}
+
+		return nil
+	},
+}
+
+// filterOps lists the filter operators. Operators that are prefixes of
+// others must come later.
+var filterOps = []struct {
+	Token  string
+	Op     v1.FilterOp
+	Negate bool
+}{
+	{"==", v1.FilterOp_OP_EQUALS, false},
+	{"!=", v1.FilterOp_OP_EQUALS, true},
+	{"|=", v1.FilterOp_OP_STARTS_WITH, false},
+	{"=|", v1.FilterOp_OP_ENDS_WITH, false},
+	{"~=", v1.FilterOp_OP_CONTAINS, false},
+	{"=", v1.FilterOp_OP_EQUALS, false},
+}
+
+// parseFilter parses filter flags into filter expressions.
+func parseFilter(exprs []string) ([]*v1.FilterExpression, error) {
+	res := make([]*v1.FilterExpression, 0, len(exprs))
+	for _, expr := range exprs {
+		var terms []*v1.FilterTerm
+		for _, t := range strings.Split(expr, " or ") {
+			term, err := parseFilterTerm(strings.TrimSpace(t))
+			if err != nil {
+				return nil, err
+			}
+			terms = append(terms, term)
+		}
+		res = append(res, &v1.FilterExpression{Terms: terms})
+	}
+
+	return res, nil
+}
+
+// parseFilterTerm parses a single term of a filter expression, e.g. owner==alice.
+func parseFilterTerm(t string) (*v1.FilterTerm, error) {
+	var negate bool
+	if strings.HasPrefix(t, "!") {
+		negate = true
+		t = t[1:]
+	}
+
+	if strings.HasSuffix(t, "?") && !strings.ContainsAny(t, "=") {
+		field := strings.TrimSuffix(t, "?")
+		if field == "" {
+			return nil, fmt.Errorf("invalid filter term %q: missing field", t)
+		}
+		return &v1.FilterTerm{Field: field, Operation: v1.FilterOp_OP_EXISTS, Negate: negate}, nil
+	}
+
+	var (
+		best   = -1
+		bestOp int
+	)
+	for i, op := range filterOps {
+		idx := strings.Index(t, op.Token)
+		if idx < 0 {
+			continue
+		}
+		if best == -1 || idx < best {
+			best, bestOp = idx, i
+		}
+	}
+	if best <= 0 {
+		return nil, fmt.Errorf("invalid filter term %q: must be field<op>value", t)
+	}
+
+	op := filterOps[bestOp]
+	return &v1.FilterTerm{
+		Field:     t[:best],
+		Value:     t[best+len(op.Token):],
+		Operation: op.Op,
+		Negate:    negate != op.Negate,
+	}, nil
+}
+
+// parseOrder parses order flags of the form field[:asc|:desc].
+func parseOrder(orders []string) ([]*v1.OrderExpression, error) {
+	res := make([]*v1.OrderExpression, 0, len(orders))
+	for _, o := range orders {
+		segs := strings.SplitN(o, ":", 2)
+		if segs[0] == "" {
+			return nil, fmt.Errorf("invalid order %q: missing field", o)
+		}
+
+		expr := &v1.OrderExpression{Field: segs[0]}
+		if len(segs) == 2 {
+			switch segs[1] {
+			case "asc":
+				expr.Ascending = true
+			case "desc":
+			default:
+				return nil, fmt.Errorf("invalid order %q: direction must be asc or desc", o)
+			}
+		}
+		res = append(res, expr)
+	}
+
+	return res, nil
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringArrayVar(&listCmdOpts.Filter, "filter", nil, "filter expression, e.g. phase==running")
+	listCmd.Flags().StringArrayVar(&listCmdOpts.Order, "order", nil, "order expression, e.g. created:desc")
+	listCmd.Flags().Int32Var(&listCmdOpts.Start, "start", 0, "offset of the first Engine to list")
+	listCmd.Flags().Int32Var(&listCmdOpts.Limit, "limit", 50, "maximum number of Engine(s) to list (0 for no limit)")
+}
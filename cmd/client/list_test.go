@@ -0,0 +1,119 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseFilterTerm(t *testing.T) {
+	tests := []struct {
+		term     string
+		expected *v1.FilterTerm
+		err      string
+	}{
+		{"phase==running", &v1.FilterTerm{Field: "phase", Value: "running", Operation: v1.FilterOp_OP_EQUALS}, ""},
+		{"phase=running", &v1.FilterTerm{Field: "phase", Value: "running", Operation: v1.FilterOp_OP_EQUALS}, ""},
+		{"phase!=running", &v1.FilterTerm{Field: "phase", Value: "running", Operation: v1.FilterOp_OP_EQUALS, Negate: true}, ""},
+		{"!phase!=running", &v1.FilterTerm{Field: "phase", Value: "running", Operation: v1.FilterOp_OP_EQUALS}, ""},
+		{"name|=sales", &v1.FilterTerm{Field: "name", Value: "sales", Operation: v1.FilterOp_OP_STARTS_WITH}, ""},
+		{"name=|2021", &v1.FilterTerm{Field: "name", Value: "2021", Operation: v1.FilterOp_OP_ENDS_WITH}, ""},
+		{"owner~=li", &v1.FilterTerm{Field: "owner", Value: "li", Operation: v1.FilterOp_OP_CONTAINS}, ""},
+		{"annotation.x==a==b", &v1.FilterTerm{Field: "annotation.x", Value: "a==b", Operation: v1.FilterOp_OP_EQUALS}, ""},
+		{"owner==", &v1.FilterTerm{Field: "owner", Operation: v1.FilterOp_OP_EQUALS}, ""},
+		{"annotation.column?", &v1.FilterTerm{Field: "annotation.column", Operation: v1.FilterOp_OP_EXISTS}, ""},
+		{"!annotation.column?", &v1.FilterTerm{Field: "annotation.column", Operation: v1.FilterOp_OP_EXISTS, Negate: true}, ""},
+		{"", nil, "must be field<op>value"},
+		{"!", nil, "must be field<op>value"},
+		{"phase", nil, "must be field<op>value"},
+		{"==running", nil, "must be field<op>value"},
+		{"!=running", nil, "must be field<op>value"},
+		{"?", nil, "missing field"},
+		{"!?", nil, "missing field"},
+	}
+
+	for i, tc := range tests {
+		term, err := parseFilterTerm(tc.term)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%d: expected error containing %q, got: %v", i, tc.err, err)
+			}
+			continue
+		}
+		if err != nil || !proto.Equal(term, tc.expected) {
+			t.Errorf("%d: expected %v, got: %v, %v", i, tc.expected, term, err)
+		}
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	filter, err := parseFilter([]string{"owner==alice or owner==bob", "phase==running"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filter) != 2 || len(filter[0].Terms) != 2 || filter[0].Terms[1].Value != "bob" || len(filter[1].Terms) != 1 {
+		t.Errorf("unexpected filter: %v", filter)
+	}
+
+	if _, err := parseFilter([]string{"owner==alice or "}); err == nil {
+		t.Error("expected error for an empty term")
+	}
+}
+
+func TestParseOrder(t *testing.T) {
+	tests := []struct {
+		orders   []string
+		expected []*v1.OrderExpression
+		err      string
+	}{
+		{nil, []*v1.OrderExpression{}, ""},
+		{[]string{"created"}, []*v1.OrderExpression{{Field: "created"}}, ""},
+		{[]string{"created:asc", "name:desc"}, []*v1.OrderExpression{{Field: "created", Ascending: true}, {Field: "name"}}, ""},
+		{[]string{"created:up"}, nil, "direction must be asc or desc"},
+		{[]string{"created:"}, nil, "direction must be asc or desc"},
+		{[]string{"created:asc:desc"}, nil, "direction must be asc or desc"},
+		{[]string{""}, nil, "missing field"},
+		{[]string{"name", ":asc"}, nil, "missing field"},
+	}
+
+	for i, tc := range tests {
+		order, err := parseOrder(tc.orders)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%d: expected error containing %q, got: %v", i, tc.err, err)
+			}
+			continue
+		}
+		if err != nil || len(order) != len(tc.expected) {
+			t.Errorf("%d: expected %v, got: %v, %v", i, tc.expected, order, err)
+			continue
+		}
+		for j := range order {
+			if !proto.Equal(order[j], tc.expected[j]) {
+				t.Errorf("%d: expected %v, got: %v", i, tc.expected, order)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+var listenCmdOpts struct {
+	Logs    string
+	Updates bool
+}
+
+// logModes maps the --logs flag values to the log modes of the API.
+var logModes = map[string]v1.ListenRequestLogs{
+	"disabled": v1.ListenRequestLogs_LOGS_DISABLED,
+	"unsliced": v1.ListenRequestLogs_LOGS_UNSLICED,
+	"raw":      v1.ListenRequestLogs_LOGS_RAW,
+	"html":     v1.ListenRequestLogs_LOGS_HTML,
+}
+
+// listenCmd represents the listen command
+var listenCmd = &cobra.Command{
+	Use:   "listen <name>",
+	Short: "Listens to the log output and updates of an Engine",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mode, exists := logModes[listenCmdOpts.Logs]
+		if !exists {
+			return fmt.Errorf("invalid log mode %q: must be one of disabled, unsliced, raw or html", listenCmdOpts.Logs)
+		}
+
+		client, closeConn, err := dial()
+		if err != nil {
+			return err
+		}
+		defer closeConn()
+
+		return listen(context.Background(), client, args[0], listenCmdOpts.Updates, mode)
+	},
+}
+
+// listen prints the log output and updates of an Engine until it is done.
+func listen(ctx context.Context, client v1.MathematicsServiceClient, name string, updates bool, mode v1.ListenRequestLogs) error {
+	stream, err := client.Listen(ctx, &v1.ListenRequest{
+		Name:    name,
+		Updates: updates,
+		Logs:    mode,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch c := resp.Content.(type) {
+		case *v1.ListenResponse_Update:
+			s := c.Update
+			fmt.Printf("--- %s: %s", s.Name, strings.ToLower(strings.TrimPrefix(s.Phase.String(), "PHASE_")))
+			if s.Phase == v1.EnginePhase_PHASE_DONE {
+				fmt.Printf(" (success: %v)", s.Conditions.GetSuccess())
+			}
+			fmt.Println()
+		case *v1.ListenResponse_Slice:
+			printSlice(c.Slice, mode)
+		}
+	}
+}
+
+// printSlice prints a single log event.
+func printSlice(ev *v1.LogSliceEvent, mode v1.ListenRequestLogs) {
+	if mode == v1.ListenRequestLogs_LOGS_RAW {
+		fmt.Println(ev.Payload)
+		return
+	}
+
+	switch ev.Type {
+	case v1.LogSliceType_SLICE_CONTENT:
+		fmt.Printf("[%s] %s\n", ev.Name, ev.Payload)
+	case v1.LogSliceType_SLICE_PHASE:
+		fmt.Printf("--- %s\n", ev.Payload)
+	default:
+		typ := strings.ToLower(strings.TrimPrefix(ev.Type.String(), "SLICE_"))
+		fmt.Println(strings.TrimSpace(fmt.Sprintf("[%s|%s] %s", ev.Name, typ, ev.Payload)))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(listenCmd)
+
+	listenCmd.Flags().StringVar(&listenCmdOpts.Logs, "logs", "unsliced", "log mode: disabled, unsliced, raw or html")
+	listenCmd.Flags().BoolVar(&listenCmdOpts.Updates, "updates", false, "prints the status updates of the Engine")
+}
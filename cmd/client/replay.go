@@ -0,0 +1,77 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var replayCmdOpts struct {
+	WaitUntil time.Duration
+	Follow    bool
+}
+
+// replayCmd represents the replay command
+var replayCmd = &cobra.Command{
+	Use:   "replay <name>",
+	Short: "Starts a new Engine based on a previous one",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req := &v1.StartFromPreviousEngineRequest{
+			PreviousEngine: args[0],
+		}
+		if replayCmdOpts.WaitUntil > 0 {
+			req.WaitUntil = timestamppb.New(time.Now().Add(replayCmdOpts.WaitUntil))
+		}
+
+		client, closeConn, err := dial()
+		if err != nil {
+			return err
+		}
+		defer closeConn()
+
+		ctx := context.Background()
+		resp, err := client.StartFromPreviousEngine(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.Status.Name)
+		if !replayCmdOpts.Follow {
+			return nil
+		}
+
+		return listen(ctx, client, resp.Status.Name, false, v1.ListenRequestLogs_LOGS_UNSLICED)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+
+	replayCmd.Flags().DurationVar(&replayCmdOpts.WaitUntil, "wait", 0, "delays the start of the Engine")
+	replayCmd.Flags().BoolVarP(&replayCmdOpts.Follow, "follow", "f", false, "listens to the log output once the Engine is started")
+}
@@ -24,11 +24,17 @@ import (
 	"fmt"
 	"os"
 
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
-var verbose bool
+var (
+	verbose bool
+	host    string
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -57,4 +63,15 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "en/disable verbose logging")
+	rootCmd.PersistentFlags().StringVar(&host, "host", "localhost:7777", "address of the Bhojpur Mathematics server")
+}
+
+// dial connects to the Bhojpur Mathematics server. The returned function closes the connection.
+func dial() (v1.MathematicsServiceClient, func(), error) {
+	conn, err := grpc.Dial(host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot connect to %s: %w", host, err)
+	}
+
+	return v1.NewMathematicsServiceClient(conn), func() { conn.Close() }, nil
 }
@@ -0,0 +1,135 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var startCmdOpts struct {
+	SpecName    string
+	Owner       string
+	Annotations []string
+	Sideload    string
+	NameSuffix  string
+	WaitUntil   time.Duration
+	Follow      bool
+}
+
+// startCmd represents the start command
+var startCmd = &cobra.Command{
+	Use:   "start <engine.yaml>",
+	Short: "Starts a new Engine from an engine YAML file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fn := args[0]
+		engineYAML, err := os.ReadFile(fn)
+		if err != nil {
+			return err
+		}
+
+		specName := startCmdOpts.SpecName
+		if specName == "" {
+			specName = strings.TrimSuffix(filepath.Base(fn), filepath.Ext(fn))
+		}
+
+		annotations, err := parseAnnotations(startCmdOpts.Annotations)
+		if err != nil {
+			return err
+		}
+
+		req := &v1.StartEngineRequest{
+			Metadata: &v1.EngineMetadata{
+				Owner:          startCmdOpts.Owner,
+				Trigger:        v1.EngineTrigger_TRIGGER_MANUAL,
+				Annotations:    annotations,
+				EngineSpecName: specName,
+			},
+			EnginePath: fn,
+			EngineYaml: engineYAML,
+			NameSuffix: startCmdOpts.NameSuffix,
+		}
+		if startCmdOpts.Sideload != "" {
+			req.Sideload, err = os.ReadFile(startCmdOpts.Sideload)
+			if err != nil {
+				return err
+			}
+		}
+		if startCmdOpts.WaitUntil > 0 {
+			req.WaitUntil = timestamppb.New(time.Now().Add(startCmdOpts.WaitUntil))
+		}
+
+		client, closeConn, err := dial()
+		if err != nil {
+			return err
+		}
+		defer closeConn()
+
+		ctx := context.Background()
+		resp, err := client.StartEngine(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.Status.Name)
+		if !startCmdOpts.Follow {
+			return nil
+		}
+
+		return listen(ctx, client, resp.Status.Name, false, v1.ListenRequestLogs_LOGS_UNSLICED)
+	},
+}
+
+// parseAnnotations parses key=value pairs into annotations.
+func parseAnnotations(kvs []string) ([]*v1.Annotation, error) {
+	res := make([]*v1.Annotation, 0, len(kvs))
+	for _, kv := range kvs {
+		segs := strings.SplitN(kv, "=", 2)
+		if len(segs) != 2 || segs[0] == "" {
+			return nil, fmt.Errorf("invalid annotation %q: must be key=value", kv)
+		}
+
+		res = append(res, &v1.Annotation{Key: segs[0], Value: segs[1]})
+	}
+
+	return res, nil
+}
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+
+	startCmd.Flags().StringVar(&startCmdOpts.SpecName, "spec-name", "", "name of the engine spec (defaults to the file name)")
+	startCmd.Flags().StringVar(&startCmdOpts.Owner, "owner", os.Getenv("USER"), "owner of the Engine")
+	startCmd.Flags().StringArrayVarP(&startCmdOpts.Annotations, "annotation", "a", nil, "annotation (key=value) passed to the Engine")
+	startCmd.Flags().StringVar(&startCmdOpts.Sideload, "sideload", "", "file whose content is sideloaded into the Engine")
+	startCmd.Flags().StringVar(&startCmdOpts.NameSuffix, "name-suffix", "", "suffix appended to the Engine name")
+	startCmd.Flags().DurationVar(&startCmdOpts.WaitUntil, "wait", 0, "delays the start of the Engine")
+	startCmd.Flags().BoolVarP(&startCmdOpts.Follow, "follow", "f", false, "listens to the log output once the Engine is started")
+}
@@ -0,0 +1,103 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	"github.com/bhojpur/mathematics/pkg/engine"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var serveCmdOpts struct {
+	GRPCAddr  string
+	WorkDir   string
+	Databases []string
+}
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Starts the Bhojpur Mathematics engine server",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbs, err := parseDatabases(serveCmdOpts.Databases)
+		if err != nil {
+			return err
+		}
+
+		lis, err := net.Listen("tcp", serveCmdOpts.GRPCAddr)
+		if err != nil {
+			return err
+		}
+
+		exec := engine.NewLocalExecutor()
+		exec.Dir = serveCmdOpts.WorkDir
+		exec.Databases = dbs
+
+		srv := grpc.NewServer()
+		v1.RegisterMathematicsServiceServer(srv, engine.NewService(exec))
+
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigs
+			log.Info("shutting down")
+			srv.Stop()
+		}()
+
+		log.WithField("addr", lis.Addr().String()).Info("serving Bhojpur Mathematics API")
+		return srv.Serve(lis)
+	},
+}
+
+// parseDatabases parses the --database flags of the form name=driver:dsn.
+func parseDatabases(flags []string) (map[string]engine.Database, error) {
+	dbs := map[string]engine.Database{}
+	for _, f := range flags {
+		name, source := f, ""
+		if i := strings.Index(f, "="); i >= 0 {
+			name, source = f[:i], f[i+1:]
+		}
+		i := strings.Index(source, ":")
+		if name == "" || i <= 0 {
+			return nil, fmt.Errorf("invalid database %q: expected name=driver:dsn", f)
+		}
+		dbs[name] = engine.Database{Driver: source[:i], DSN: source[i+1:]}
+	}
+	return dbs, nil
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveCmdOpts.GRPCAddr, "grpc-addr", ":7777", "address the gRPC API is served on")
+	serveCmd.Flags().StringVar(&serveCmdOpts.WorkDir, "workdir", "", "directory engine specs can read and write files in (no file access if not set)")
+	serveCmd.Flags().StringArrayVar(&serveCmdOpts.Databases, "database", nil, "database engine specs can import from, as name=driver:dsn (repeatable; no sql imports if not set)")
+}
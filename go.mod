@@ -67,6 +67,6 @@ require (
 	golang.org/x/xerrors v0.0.0-20220517211312-f3a8303e98df
 	gonum.org/v1/gonum v0.11.0
 	google.golang.org/genproto v0.0.0-20220527130721-00d5c0f3be58 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1
 	gotest.tools v2.2.0+incompatible // indirect
 )
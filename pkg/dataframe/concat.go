@@ -0,0 +1,146 @@
+package dataframe
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConcatOptions modifies the behavior of Concat.
+type ConcatOptions struct {
+
+	// Horizontal can be set to place the Series of the DataFrames side by
+	// side instead of stacking their rows.
+	Horizontal bool
+
+	// DontLock can be set to true if the DataFrames should not be locked.
+	DontLock bool
+}
+
+// Concat stacks the rows of the DataFrames into a new DataFrame.
+//
+// Series are aligned by name, in the order they are first found. A Series
+// that is missing from a DataFrame is filled with nil for its rows. Series
+// of the same type keep their type, a mix of SeriesInt64 and SeriesFloat64
+// becomes a SeriesFloat64, and any other mix becomes a SeriesMixed.
+//
+// With the Horizontal option, the Series of all DataFrames are copied side by
+// side instead. The DataFrames must then have the same number of rows and
+// different Series names.
+func Concat(ctx context.Context, dfs []*DataFrame, opts ...ConcatOptions) (*DataFrame, error) {
+	if len(opts) == 0 {
+		opts = append(opts, ConcatOptions{})
+	}
+
+	var sopts []Options
+	if !opts[0].DontLock {
+		locked := map[*DataFrame]bool{}
+		for _, df := range dfs {
+			if !locked[df] {
+				locked[df] = true
+				df.lock.RLock()
+				defer df.lock.RUnlock()
+			}
+		}
+	} else {
+		sopts = append(sopts, dontLock)
+	}
+
+	if opts[0].Horizontal {
+		return concatHorizontal(ctx, dfs, sopts)
+	}
+
+	// Series of each name, in the order the names are first found
+	names := []string{}
+	byName := map[string][]Series{}
+	for _, df := range dfs {
+		for _, s := range df.Series {
+			name := s.Name(sopts...)
+			if _, exists := byName[name]; !exists {
+				names = append(names, name)
+			}
+			byName[name] = append(byName[name], s)
+		}
+	}
+
+	n := 0
+	for _, df := range dfs {
+		n += df.n
+	}
+
+	seriess := []Series{}
+	for _, name := range names {
+		ns := commonSeries(byName[name], name, n)
+
+		for _, df := range dfs {
+			var s Series
+			for _, ds := range df.Series {
+				if ds.Name(sopts...) == name {
+					s = ds
+					break
+				}
+			}
+
+			for row := 0; row < df.n; row++ {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+
+				if s == nil {
+					ns.Append(nil, dontLock)
+				} else {
+					ns.Append(s.Value(row, sopts...), dontLock)
+				}
+			}
+		}
+		seriess = append(seriess, ns)
+	}
+
+	return NewDataFrame(seriess...), nil
+}
+
+func concatHorizontal(ctx context.Context, dfs []*DataFrame, sopts []Options) (*DataFrame, error) {
+	seriess := []Series{}
+	for i, df := range dfs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if df.n != dfs[0].n {
+			return nil, fmt.Errorf("different number of rows: %d and %d in DataFrame %d", dfs[0].n, df.n, i)
+		}
+		for _, s := range df.Series {
+			if len(sopts) == 0 {
+				s.Lock()
+				seriess = append(seriess, s.Copy())
+				s.Unlock()
+			} else {
+				seriess = append(seriess, s.Copy())
+			}
+		}
+	}
+
+	if err := checkUniqueNames(seriess); err != nil {
+		return nil, err
+	}
+	return NewDataFrame(seriess...), nil
+}
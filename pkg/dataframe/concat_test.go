@@ -0,0 +1,103 @@
+package dataframe
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConcat(t *testing.T) {
+	ctx := context.Background()
+
+	day1 := NewDataFrame(
+		NewSeriesString("store", nil, "a", "b"),
+		NewSeriesInt64("sales", nil, 10, 20),
+		NewSeriesInt64("units", nil, 1, 2),
+	)
+	day2 := NewDataFrame(
+		NewSeriesFloat64("sales", nil, 15.5),
+		NewSeriesString("store", nil, "c"),
+		NewSeriesString("units", nil, "many"),
+		NewSeriesString("note", nil, "late"),
+	)
+
+	df, err := Concat(ctx, []*DataFrame{day1, day2})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	expectedNames := []string{"store", "sales", "units", "note"}
+	expected := [][]interface{}{
+		{"a", "b", "c"},
+		{10.0, 20.0, 15.5},
+		{int64(1), int64(2), "many"},
+		{nil, nil, "late"},
+	}
+	if !cmp.Equal(df.Names(), expectedNames) {
+		t.Errorf("wrong names: expected: %v actual: %v", expectedNames, df.Names())
+	}
+	if !cmp.Equal(dataFrameValues(df), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, dataFrameValues(df))
+	}
+
+	expectedTypes := []string{"string", "float64", "mixed", "string"}
+	for i, s := range df.Series {
+		if s.Type() != expectedTypes[i] {
+			t.Errorf("wrong type: expected: %v actual: %v", expectedTypes[i], s.Type())
+		}
+	}
+}
+
+func TestConcatHorizontal(t *testing.T) {
+	ctx := context.Background()
+
+	left := NewDataFrame(NewSeriesString("store", nil, "a", "b"))
+	right := NewDataFrame(NewSeriesInt64("sales", nil, 10, 20))
+
+	df, err := Concat(ctx, []*DataFrame{left, right}, ConcatOptions{Horizontal: true})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+	expected := [][]interface{}{
+		{"a", "b"},
+		{int64(10), int64(20)},
+	}
+	if !cmp.Equal(dataFrameValues(df), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, dataFrameValues(df))
+	}
+
+	// The output does not share the Series
+	df.Update(0, 1, 99)
+	if right.Series[0].Value(0) != int64(10) {
+		t.Errorf("wrong val: expected: %v actual: %v", 10, right.Series[0].Value(0))
+	}
+
+	short := NewDataFrame(NewSeriesInt64("units", nil, 1))
+	if _, err := Concat(ctx, []*DataFrame{left, short}, ConcatOptions{Horizontal: true}); err == nil {
+		t.Errorf("wrong err: expected: error actual: %v", err)
+	}
+	if _, err := Concat(ctx, []*DataFrame{left, left}, ConcatOptions{Horizontal: true}); err == nil {
+		t.Errorf("wrong err: expected: error actual: %v", err)
+	}
+}
@@ -0,0 +1,506 @@
+package dataframe
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// AggregateFunc is an aggregation computed by GroupBy for each group.
+type AggregateFunc int
+
+const (
+	// AggCount counts the non-nil values.
+	AggCount AggregateFunc = iota
+	// AggSum sums the non-nil values. Int64 values are summed as int64.
+	AggSum
+	// AggMean is the mean of the non-nil values.
+	AggMean
+	// AggMin is the smallest non-nil value, according to IsLessThanFunc.
+	AggMin
+	// AggMax is the largest non-nil value, according to IsLessThanFunc.
+	AggMax
+	// AggStd is the sample standard deviation of the non-nil values.
+	AggStd
+	// AggMedian is the median of the non-nil values.
+	AggMedian
+	// AggFirst is the first non-nil value.
+	AggFirst
+	// AggLast is the last non-nil value.
+	AggLast
+	// AggCustom reduces the values with the Reduce function of the Aggregation.
+	AggCustom
+)
+
+var aggregateFuncNames = []string{"count", "sum", "mean", "min", "max", "std", "median", "first", "last", "custom"}
+
+// String returns the name of the aggregation.
+func (f AggregateFunc) String() string {
+	if f < 0 || int(f) >= len(aggregateFuncNames) {
+		return fmt.Sprintf("AggregateFunc(%d)", int(f))
+	}
+	return aggregateFuncNames[f]
+}
+
+// ReduceFn is used by AggCustom to reduce the values of a group to a single
+// value. vals contains the values of the group in row order, including nils.
+type ReduceFn func(vals []interface{}) (interface{}, error)
+
+// Aggregation describes a Series in the output of GroupBy.
+type Aggregation struct {
+
+	// Key can be an int (position of series) or string (name of series).
+	Key interface{}
+
+	// Func is the aggregation to compute.
+	Func AggregateFunc
+
+	// Reduce is required by AggCustom.
+	Reduce ReduceFn
+
+	// Name of the output Series. It defaults to the name of the
+	// aggregated Series followed by "_" and the name of Func.
+	Name string
+}
+
+// GroupByOptions modifies the behavior of GroupBy.
+type GroupByOptions struct {
+
+	// DropNilKeys drops the rows with a nil key. By default, nil is
+	// treated like any other key value.
+	DropNilKeys bool
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// GroupBy splits the rows of the DataFrame into groups with equal values in
+// the key Series, and returns a new DataFrame with one row per group, in the
+// order the groups first appear. The output contains the key Series followed
+// by one Series per aggregation.
+//
+// keys can contain ints (position of series) or strings (name of series).
+// Key values are compared with ==, except in a SeriesGeneric with a custom
+// IsEqualFunc, where they are compared with it like in Sort. Aggregations
+// other than AggCount and AggCustom ignore nil values, and give nil for
+// groups without any non-nil value (or fewer than two for AggStd).
+//
+// Example:
+//
+//	df.GroupBy(ctx, []interface{}{"region"}, []dataframe.Aggregation{
+//		{Key: "sales", Func: dataframe.AggSum},
+//		{Key: "sales", Func: dataframe.AggMean, Name: "avg"},
+//	})
+func (df *DataFrame) GroupBy(ctx context.Context, keys []interface{}, aggs []Aggregation, opts ...GroupByOptions) (*DataFrame, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one key is required")
+	}
+
+	if len(opts) == 0 {
+		opts = append(opts, GroupByOptions{})
+	}
+
+	var sopts []Options
+	if !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	} else {
+		sopts = append(sopts, dontLock)
+	}
+
+	keyCols := make([]int, len(keys))
+	for i, key := range keys {
+		col, err := df.column(key)
+		if err != nil {
+			return nil, err
+		}
+		keyCols[i] = col
+	}
+
+	aggCols := make([]int, len(aggs))
+	for i, agg := range aggs {
+		col, err := df.column(agg.Key)
+		if err != nil {
+			return nil, err
+		}
+		if agg.Func < 0 || agg.Func > AggCustom {
+			return nil, fmt.Errorf("unknown aggregation: %d", int(agg.Func))
+		}
+		if agg.Func == AggCustom && agg.Reduce == nil {
+			return nil, errors.New("Reduce is required for AggCustom")
+		}
+		aggCols[i] = col
+	}
+
+	groups, err := df.groupRows(ctx, keyCols, opts[0].DropNilKeys, sopts)
+	if err != nil {
+		return nil, err
+	}
+
+	seriess := []Series{}
+
+	// Key series
+	for _, col := range keyCols {
+		s := df.Series[col]
+		ns := newSeriesLike(s, s.Name(sopts...), len(groups))
+		for _, rows := range groups {
+			ns.Append(s.Value(rows[0], sopts...), dontLock)
+		}
+		seriess = append(seriess, ns)
+	}
+
+	// Aggregations
+	for i, agg := range aggs {
+		s := df.Series[aggCols[i]]
+
+		name := agg.Name
+		if name == "" {
+			name = s.Name(sopts...) + "_" + agg.Func.String()
+		}
+
+		ns := aggregationSeries(s, agg.Func, name, len(groups))
+		for _, rows := range groups {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			vals := make([]interface{}, 0, len(rows))
+			for _, row := range rows {
+				vals = append(vals, s.Value(row, sopts...))
+			}
+
+			val, err := aggregate(s, agg, vals)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			ns.Append(val, dontLock)
+		}
+		seriess = append(seriess, ns)
+	}
+
+	if err := checkUniqueNames(seriess); err != nil {
+		return nil, err
+	}
+	return NewDataFrame(seriess...), nil
+}
+
+// column converts a key, which can be an int (position of series) or
+// string (name of series), to the position of the series.
+func (df *DataFrame) column(key interface{}) (int, error) {
+	switch k := key.(type) {
+	case string:
+		col, err := df.NameToColumn(k, dontLock)
+		if err != nil {
+			return 0, errors.New(err.Error() + ": " + k)
+		}
+		return col, nil
+	case int:
+		if k < 0 || k >= len(df.Series) {
+			return 0, fmt.Errorf("series index out of range: %d", k)
+		}
+		return k, nil
+	default:
+		return 0, fmt.Errorf("key must be an int or string: %T", key)
+	}
+}
+
+// groupRows returns the rows of each group in the order the groups first
+// appear.
+func (df *DataFrame) groupRows(ctx context.Context, keyCols []int, dropNilKeys bool, sopts []Options) ([][]int, error) {
+	// Each distinct key value of a Series is assigned a number, so that
+	// the key of a row is the sequence of the numbers of its values.
+	numbers := make([]*keyNumbers, len(keyCols))
+	for i, col := range keyCols {
+		numbers[i] = newKeyNumbers(df.Series[col])
+	}
+
+	groups := [][]int{}
+	lookup := map[string]int{}
+	buf := make([]byte, len(keyCols)*binary.MaxVarintLen64)
+
+	nRows := df.n
+	for row := 0; row < nRows; row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		n := 0
+		drop := false
+		for i, col := range keyCols {
+			val := df.Series[col].Value(row, sopts...)
+			if val == nil && dropNilKeys {
+				drop = true
+				break
+			}
+
+			id, _ := numbers[i].number(val)
+			n += binary.PutUvarint(buf[n:], uint64(id))
+		}
+		if drop {
+			continue
+		}
+
+		g, exists := lookup[string(buf[:n])]
+		if !exists {
+			g = len(groups)
+			lookup[string(buf[:n])] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], row)
+	}
+
+	return groups, nil
+}
+
+type nilKey struct{}
+
+type timeKey struct {
+	sec  int64
+	nsec int
+}
+
+type fmtKey string
+
+// groupKey converts a value to a map key that is equal for equal values.
+func groupKey(val interface{}) interface{} {
+	switch v := val.(type) {
+	case nil:
+		return nilKey{}
+	case time.Time:
+		return timeKey{v.Unix(), v.Nanosecond()}
+	}
+
+	if !reflect.TypeOf(val).Comparable() {
+		return fmtKey(fmt.Sprintf("%T %#v", val, val))
+	}
+	return val
+}
+
+// keyNumbers numbers the distinct values of a key Series in the order they
+// are first found. Values are looked up by their groupKey, or by a scan with
+// the IsEqualFunc of a SeriesGeneric with a custom one, which == does not
+// necessarily agree with.
+type keyNumbers struct {
+	lookup   map[interface{}]int
+	isEqual  IsEqualFunc
+	distinct []interface{}
+	ids      []int
+}
+
+func newKeyNumbers(s Series) *keyNumbers {
+	k := &keyNumbers{lookup: map[interface{}]int{}}
+	if sg, ok := s.(*SeriesGeneric); ok && sg.customIsEqualFunc() {
+		k.isEqual = sg.isEqualFunc
+	}
+	return k
+}
+
+// number returns the number of val, and whether val was not found before.
+func (k *keyNumbers) number(val interface{}) (int, bool) {
+	if id, found := k.find(val); found {
+		return id, false
+	}
+
+	n := len(k.lookup) + len(k.distinct)
+	if k.isEqual != nil && val != nil {
+		k.distinct = append(k.distinct, val)
+		k.ids = append(k.ids, n)
+		return n, true
+	}
+	k.lookup[groupKey(val)] = n
+	return n, true
+}
+
+// find returns the number of val, and whether val was found.
+func (k *keyNumbers) find(val interface{}) (int, bool) {
+	if k.isEqual != nil && val != nil {
+		for i, d := range k.distinct {
+			if k.isEqual(d, val) {
+				return k.ids[i], true
+			}
+		}
+		return 0, false
+	}
+
+	id, exists := k.lookup[groupKey(val)]
+	return id, exists
+}
+
+// newSeriesLike creates an empty Series of the same type as s, or a
+// SeriesMixed if s does not implement NewSerieser.
+func newSeriesLike(s Series, name string, capacity int) Series {
+	switch x := s.(type) {
+	case NewSerieser:
+		return x.NewSeries(name, &SeriesInit{Capacity: capacity})
+	case *SeriesGeneric:
+		ns := NewSeriesGeneric(name, x.concreteType, &SeriesInit{Capacity: capacity})
+		ns.isEqualFunc = x.isEqualFunc
+		ns.isLessThanFunc = x.isLessThanFunc
+		return ns
+	}
+	return NewSeriesMixed(name, &SeriesInit{Capacity: capacity})
+}
+
+// aggregationSeries creates the output Series of an aggregation of s.
+func aggregationSeries(s Series, f AggregateFunc, name string, capacity int) Series {
+	init := &SeriesInit{Capacity: capacity}
+
+	switch f {
+	case AggCount:
+		return NewSeriesInt64(name, init)
+	case AggSum:
+		if _, ok := s.(*SeriesInt64); ok {
+			return NewSeriesInt64(name, init)
+		}
+		return NewSeriesFloat64(name, init)
+	case AggMean, AggStd, AggMedian:
+		return NewSeriesFloat64(name, init)
+	case AggMin, AggMax, AggFirst, AggLast:
+		return newSeriesLike(s, name, capacity)
+	default:
+		return NewSeriesMixed(name, init)
+	}
+}
+
+// aggregate computes agg over the values of a group of s.
+func aggregate(s Series, agg Aggregation, vals []interface{}) (interface{}, error) {
+	if agg.Func == AggCustom {
+		return agg.Reduce(vals)
+	}
+
+	nonNil := make([]interface{}, 0, len(vals))
+	for _, v := range vals {
+		if v != nil {
+			nonNil = append(nonNil, v)
+		}
+	}
+
+	switch agg.Func {
+	case AggCount:
+		return int64(len(nonNil)), nil
+	case AggFirst:
+		if len(nonNil) == 0 {
+			return nil, nil
+		}
+		return nonNil[0], nil
+	case AggLast:
+		if len(nonNil) == 0 {
+			return nil, nil
+		}
+		return nonNil[len(nonNil)-1], nil
+	case AggMin, AggMax:
+		if len(nonNil) == 0 {
+			return nil, nil
+		}
+		out := nonNil[0]
+		for _, v := range nonNil[1:] {
+			if agg.Func == AggMin && s.IsLessThanFunc(v, out) || agg.Func == AggMax && s.IsLessThanFunc(out, v) {
+				out = v
+			}
+		}
+		return out, nil
+	}
+
+	if len(nonNil) == 0 {
+		return nil, nil
+	}
+
+	if agg.Func == AggSum {
+		if _, ok := s.(*SeriesInt64); ok {
+			var sum int64
+			for _, v := range nonNil {
+				sum += v.(int64)
+			}
+			return sum, nil
+		}
+	}
+
+	fs := make([]float64, len(nonNil))
+	for i, v := range nonNil {
+		f, ok := toFloat64(v)
+		if !ok {
+			return nil, fmt.Errorf("%s requires numeric values: %T", agg.Func, v)
+		}
+		fs[i] = f
+	}
+
+	switch agg.Func {
+	case AggSum:
+		return floatSum(fs), nil
+	case AggMean:
+		return floatSum(fs) / float64(len(fs)), nil
+	case AggStd:
+		if len(fs) < 2 {
+			return nil, nil
+		}
+		mean := floatSum(fs) / float64(len(fs))
+		var ss float64
+		for _, f := range fs {
+			ss += (f - mean) * (f - mean)
+		}
+		return math.Sqrt(ss / float64(len(fs)-1)), nil
+	default:
+		sort.Float64s(fs)
+		mid := len(fs) / 2
+		if len(fs)%2 == 1 {
+			return fs[mid], nil
+		}
+		return (fs[mid-1] + fs[mid]) / 2, nil
+	}
+}
+
+func floatSum(fs []float64) float64 {
+	var sum float64
+	for _, f := range fs {
+		sum += f
+	}
+	return sum
+}
+
+// toFloat64 converts a numeric value to a float64.
+func toFloat64(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case float32:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case int:
+		return float64(x), true
+	case int32:
+		return float64(x), true
+	case uint64:
+		return float64(x), true
+	case uint32:
+		return float64(x), true
+	case uint:
+		return float64(x), true
+	}
+	return 0, false
+}
@@ -0,0 +1,163 @@
+package dataframe
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGroupBy(t *testing.T) {
+	ctx := context.Background()
+
+	df := NewDataFrame(
+		NewSeriesString("region", nil, "east", "west", "east", nil, "west", "east"),
+		NewSeriesInt64("year", nil, 2020, 2020, 2021, 2020, 2020, 2020),
+		NewSeriesFloat64("sales", nil, 10.0, 20.0, nil, 5.0, 40.0, 30.0),
+		NewSeriesInt64("units", nil, 1, 2, 3, 4, nil, 6),
+	)
+
+	gdf, err := df.GroupBy(ctx, []interface{}{"region"}, []Aggregation{
+		{Key: "sales", Func: AggCount},
+		{Key: "sales", Func: AggSum},
+		{Key: 2, Func: AggMean, Name: "avg"},
+		{Key: "sales", Func: AggMin},
+		{Key: "sales", Func: AggMax},
+		{Key: "sales", Func: AggStd},
+		{Key: "sales", Func: AggMedian},
+		{Key: "sales", Func: AggFirst},
+		{Key: "sales", Func: AggLast},
+		{Key: "units", Func: AggSum},
+		{Key: "units", Func: AggCustom, Reduce: func(vals []interface{}) (interface{}, error) {
+			return len(vals), nil
+		}},
+	})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	expected := `+------+--------+-------------+-----------+---------+-----------+-----------+--------------------+--------------+-------------+------------+-----------+--------------+
+|      | REGION | SALES COUNT | SALES SUM |   AVG   | SALES MIN | SALES MAX |     SALES STD      | SALES MEDIAN | SALES FIRST | SALES LAST | UNITS SUM | UNITS CUSTOM |
++------+--------+-------------+-----------+---------+-----------+-----------+--------------------+--------------+-------------+------------+-----------+--------------+
+|  0:  |  east  |      2      |    40     |   20    |    10     |    30     | 14.142135623730951 |      20      |     10      |     30     |    10     |      3       |
+|  1:  |  west  |      2      |    60     |   30    |    20     |    40     | 14.142135623730951 |      30      |     20      |     40     |     2     |      2       |
+|  2:  |  NaN   |      1      |     5     |    5    |     5     |     5     |        NaN         |      5       |      5      |     5      |     4     |      1       |
++------+--------+-------------+-----------+---------+-----------+-----------+--------------------+--------------+-------------+------------+-----------+--------------+
+| 3X12 | STRING |    INT64    |  FLOAT64  | FLOAT64 |  FLOAT64  |  FLOAT64  |      FLOAT64       |   FLOAT64    |   FLOAT64   |  FLOAT64   |   INT64   |    MIXED     |
++------+--------+-------------+-----------+---------+-----------+-----------+--------------------+--------------+-------------+------------+-----------+--------------+`
+
+	if strings.TrimSpace(gdf.Table()) != strings.TrimSpace(expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, gdf.Table())
+	}
+
+	// Several keys, without the nil key
+	gdf, err = df.GroupBy(ctx, []interface{}{0, "year"}, []Aggregation{
+		{Key: "units", Func: AggCount, Name: "n"},
+	}, GroupByOptions{DropNilKeys: true})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	expectedValues := [][]interface{}{
+		{"east", "west", "east"},
+		{int64(2020), int64(2020), int64(2021)},
+		{int64(2), int64(1), int64(1)},
+	}
+	if gdf.NRows() != 3 {
+		t.Fatalf("wrong val: expected: %v actual: %v", 3, gdf.NRows())
+	}
+	for col, vals := range expectedValues {
+		for row, val := range vals {
+			if actual := gdf.Series[col].Value(row); actual != val {
+				t.Errorf("wrong val at %d,%d: expected: %v actual: %v", row, col, val, actual)
+			}
+		}
+	}
+}
+
+func TestGroupByIsEqualFunc(t *testing.T) {
+	ctx := context.Background()
+
+	// Keys are compared with a custom IsEqualFunc
+	region := NewSeriesGeneric("region", "", nil, "north", "North", "south", nil, "NORTH", "South")
+	region.SetIsEqualFunc(func(a, b interface{}) bool {
+		return strings.EqualFold(a.(string), b.(string))
+	})
+	df := NewDataFrame(region, NewSeriesInt64("sales", nil, 1, 2, 3, 4, 5, 6))
+
+	gdf, err := df.GroupBy(ctx, []interface{}{"region"}, []Aggregation{{Key: "sales", Func: AggSum}})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+	expected := [][]interface{}{{"north", "south", nil}, {int64(8), int64(9), int64(4)}}
+	if actual := dataFrameValues(gdf); !cmp.Equal(actual, expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+
+	ct, err := Crosstab(ctx, region, NewSeriesString("kind", nil, "a", "a", "b", "a", "a", "b"))
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+	expected = [][]interface{}{{"north", "south"}, {int64(3), int64(0)}, {int64(0), int64(2)}}
+	if actual := dataFrameValues(ct); !cmp.Equal(actual, expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestGroupByErrors(t *testing.T) {
+	ctx := context.Background()
+
+	df := NewDataFrame(
+		NewSeriesString("name", nil, "a", "b"),
+		NewSeriesFloat64("x", nil, 1.0, 2.0),
+	)
+
+	for name, aggs := range map[string][]Aggregation{
+		"unknown series": {{Key: "y", Func: AggSum}},
+		"index":          {{Key: 5, Func: AggSum}},
+		"no reduce":      {{Key: "x", Func: AggCustom}},
+		"not numeric":    {{Key: "name", Func: AggMean}},
+		"duplicate name": {{Key: "x", Func: AggSum, Name: "name"}},
+		"reduce": {{Key: "x", Func: AggCustom, Reduce: func(vals []interface{}) (interface{}, error) {
+			return nil, errors.New("reduce")
+		}}},
+	} {
+		if _, err := df.GroupBy(ctx, []interface{}{"name"}, aggs); err == nil {
+			t.Errorf("%s: wrong err: expected: error actual: %v", name, err)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := df.GroupBy(cancelled, []interface{}{"name"}, nil); err != context.Canceled {
+		t.Errorf("wrong err: expected: %v actual: %v", context.Canceled, err)
+	}
+
+	df.Lock()
+	defer df.Unlock()
+	if _, err := df.GroupBy(ctx, []interface{}{"name"}, []Aggregation{{Key: "x", Func: AggSum}}, GroupByOptions{DontLock: true}); err != nil {
+		t.Errorf("wrong err: expected: %v actual: %v", nil, err)
+	}
+}
@@ -82,3 +82,16 @@ func BoolValueFormatter(v interface{}) string {
 // DontLock is short-hand for various functions that permit disabling locking.
 var DontLock = dontLock
 var dontLock = Options{DontLock: true}
+
+// checkUniqueNames returns an error if two series have the same name.
+func checkUniqueNames(seriess []Series) error {
+	names := map[string]struct{}{}
+	for _, s := range seriess {
+		name := s.Name(dontLock)
+		if _, exists := names[name]; exists {
+			return errors.New("names of series must be unique: " + name)
+		}
+		names[name] = struct{}{}
+	}
+	return nil
+}
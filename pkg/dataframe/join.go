@@ -0,0 +1,515 @@
+package dataframe
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// JoinType is the type of join performed by Join.
+type JoinType int
+
+const (
+	// InnerJoin keeps the pairs of rows with matching keys.
+	InnerJoin JoinType = iota
+	// LeftJoin keeps the pairs of rows with matching keys, and the rows of
+	// the left DataFrame without a match.
+	LeftJoin
+	// RightJoin keeps the pairs of rows with matching keys, and the rows of
+	// the right DataFrame without a match.
+	RightJoin
+	// OuterJoin keeps the pairs of rows with matching keys, and the rows of
+	// both DataFrames without a match.
+	OuterJoin
+	// AntiJoin keeps the rows of the left DataFrame without a match. Only
+	// the Series of the left DataFrame are returned.
+	AntiJoin
+)
+
+// JoinKey is a pair of key Series to join on.
+type JoinKey struct {
+
+	// Left can be an int (position of series) or string (name of series)
+	// in the left DataFrame.
+	Left interface{}
+
+	// Right can be an int (position of series) or string (name of series)
+	// in the right DataFrame. It defaults to Left.
+	Right interface{}
+}
+
+// JoinOptions modifies the behavior of Join.
+type JoinOptions struct {
+
+	// Suffixes are appended to the names of the Series of the left and right
+	// DataFrame that have the same name. They default to "_x" and "_y".
+	Suffixes [2]string
+
+	// MatchNilKeys makes nil keys match each other. By default, a row with a
+	// nil key never matches, as in SQL.
+	MatchNilKeys bool
+
+	// Sorted can be set if both DataFrames are sorted in ascending order by
+	// their keys (see Sort). A sort-merge join is then performed instead of a
+	// hash join, and the rows are returned in the order of the keys.
+	Sorted bool
+
+	// DontLock can be set to true if the DataFrames should not be locked.
+	DontLock bool
+}
+
+// Join combines the rows of df (the left DataFrame) with the rows of right
+// that have equal keys. Keys are compared with the IsEqualFunc of the left key
+// Series, and the key Series of both sides must have the same type.
+//
+// The output contains the Series of the left DataFrame followed by the
+// Series of the right DataFrame other than its keys. For rows that only exist
+// in the right DataFrame, the left key Series contain the right keys.
+//
+// By default a hash join is performed. A SeriesGeneric key with a custom
+// IsEqualFunc is instead compared with every distinct key of the other
+// DataFrame, like in GroupBy. The rows are returned in the order of the
+// left DataFrame (right DataFrame for RightJoin), followed by the
+// unmatched rows of the right DataFrame for OuterJoin.
+func (df *DataFrame) Join(ctx context.Context, right *DataFrame, how JoinType, keys []JoinKey, opts ...JoinOptions) (*DataFrame, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one key is required")
+	}
+	if how < InnerJoin || how > AntiJoin {
+		return nil, fmt.Errorf("unknown join type: %d", int(how))
+	}
+
+	if len(opts) == 0 {
+		opts = append(opts, JoinOptions{})
+	}
+	if opts[0].Suffixes == [2]string{} {
+		opts[0].Suffixes = [2]string{"_x", "_y"}
+	}
+
+	var sopts []Options
+	if !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+		if right != df {
+			right.lock.RLock()
+			defer right.lock.RUnlock()
+		}
+	} else {
+		sopts = append(sopts, dontLock)
+	}
+
+	j := &joiner{
+		left:     df,
+		right:    right,
+		how:      how,
+		matchNil: opts[0].MatchNilKeys,
+		sopts:    sopts,
+	}
+
+	for _, key := range keys {
+		lcol, err := df.column(key.Left)
+		if err != nil {
+			return nil, err
+		}
+		rkey := key.Right
+		if rkey == nil {
+			rkey = key.Left
+		}
+		rcol, err := right.column(rkey)
+		if err != nil {
+			return nil, err
+		}
+		if lt, rt := df.Series[lcol].Type(), right.Series[rcol].Type(); lt != rt {
+			return nil, fmt.Errorf("key series have different types: %s and %s", lt, rt)
+		}
+		j.leftKeys = append(j.leftKeys, df.Series[lcol])
+		j.rightKeys = append(j.rightKeys, right.Series[rcol])
+		j.leftCols = append(j.leftCols, lcol)
+		j.rightCols = append(j.rightCols, rcol)
+	}
+
+	var err error
+	if opts[0].Sorted {
+		err = j.mergeJoin(ctx)
+	} else {
+		err = j.hashJoin(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return j.output(ctx, opts[0].Suffixes)
+}
+
+// joiner holds the state of a join.
+type joiner struct {
+	left, right         *DataFrame
+	how                 JoinType
+	matchNil            bool
+	sopts               []Options
+	leftKeys, rightKeys []Series
+	leftCols, rightCols []int
+	leftRows, rightRows []int // pairs of joined rows, -1 for no row
+}
+
+func (j *joiner) emit(l, r int) {
+	j.leftRows = append(j.leftRows, l)
+	j.rightRows = append(j.rightRows, r)
+}
+
+func (j *joiner) emitMatch(l, r int) {
+	if j.how != AntiJoin {
+		j.emit(l, r)
+	}
+}
+
+func (j *joiner) emitLeft(l int) {
+	if j.how == LeftJoin || j.how == OuterJoin || j.how == AntiJoin {
+		j.emit(l, -1)
+	}
+}
+
+func (j *joiner) emitRight(r int) {
+	if j.how == RightJoin || j.how == OuterJoin {
+		j.emit(-1, r)
+	}
+}
+
+// keyValues returns the key values of a row.
+func (j *joiner) keyValues(keys []Series, row int) []interface{} {
+	vals := make([]interface{}, len(keys))
+	for i, s := range keys {
+		vals[i] = s.Value(row, j.sopts...)
+	}
+	return vals
+}
+
+// matchable returns false if the key values can not match any row.
+func (j *joiner) matchable(vals []interface{}) bool {
+	if j.matchNil {
+		return true
+	}
+	for _, v := range vals {
+		if v == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// equal compares the key values of a left row and a right row.
+func (j *joiner) equal(lvals, rvals []interface{}) bool {
+	for i, s := range j.leftKeys {
+		if lvals[i] == nil || rvals[i] == nil {
+			if lvals[i] != rvals[i] {
+				return false
+			}
+			continue
+		}
+		if !s.IsEqualFunc(lvals[i], rvals[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *joiner) hashJoin(ctx context.Context) error {
+	// The rows of the build side are indexed by their keys, and the rows of
+	// the probe side are looked up in order.
+	build, probe := j.right, j.left
+	buildKeys, probeKeys := j.rightKeys, j.leftKeys
+	if j.how == RightJoin {
+		build, probe = probe, build
+		buildKeys, probeKeys = probeKeys, buildKeys
+	}
+
+	// The keys are numbered with the IsEqualFunc of the left key Series
+	numbers := make([]*keyNumbers, len(j.leftKeys))
+	for i, s := range j.leftKeys {
+		numbers[i] = newKeyNumbers(s)
+	}
+	buf := make([]byte, len(buildKeys)*binary.MaxVarintLen64)
+
+	// encode returns the key of the values, or false if a value was never
+	// seen on the build side.
+	encode := func(vals []interface{}, add bool) (string, bool) {
+		n := 0
+		for i, v := range vals {
+			var id int
+			if add {
+				id, _ = numbers[i].number(v)
+			} else {
+				var found bool
+				if id, found = numbers[i].find(v); !found {
+					return "", false
+				}
+			}
+			n += binary.PutUvarint(buf[n:], uint64(id))
+		}
+		return string(buf[:n]), true
+	}
+
+	index := map[string][]int{}
+	for row := 0; row < build.n; row++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		vals := j.keyValues(buildKeys, row)
+		if !j.matchable(vals) {
+			continue
+		}
+		k, _ := encode(vals, true)
+		index[k] = append(index[k], row)
+	}
+
+	matched := make([]bool, build.n)
+	for row := 0; row < probe.n; row++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		vals := j.keyValues(probeKeys, row)
+		found := false
+		if j.matchable(vals) {
+			if k, ok := encode(vals, false); ok {
+				for _, b := range index[k] {
+					bvals := j.keyValues(buildKeys, b)
+					if j.how == RightJoin {
+						if !j.equal(bvals, vals) {
+							continue
+						}
+						j.emitMatch(b, row)
+					} else {
+						if !j.equal(vals, bvals) {
+							continue
+						}
+						j.emitMatch(row, b)
+					}
+					found = true
+					matched[b] = true
+				}
+			}
+		}
+
+		if !found {
+			if j.how == RightJoin {
+				j.emitRight(row)
+			} else {
+				j.emitLeft(row)
+			}
+		}
+	}
+
+	if j.how == OuterJoin {
+		for row, m := range matched {
+			if !m {
+				j.emitRight(row)
+			}
+		}
+	}
+
+	return nil
+}
+
+// compareKeys orders key values like Sort, with nil first.
+func compareKeys(keys []Series, a, b []interface{}) int {
+	for i, s := range keys {
+		switch {
+		case a[i] == nil && b[i] == nil:
+			continue
+		case a[i] == nil:
+			return -1
+		case b[i] == nil:
+			return 1
+		case s.IsEqualFunc(a[i], b[i]):
+			continue
+		case s.IsLessThanFunc(a[i], b[i]):
+			return -1
+		default:
+			return 1
+		}
+	}
+	return 0
+}
+
+// checkSorted returns an error if the rows are not sorted by the keys.
+func (j *joiner) checkSorted(ctx context.Context, name string, keys []Series, n int) error {
+	for row := 1; row < n; row++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if compareKeys(keys, j.keyValues(keys, row-1), j.keyValues(keys, row)) > 0 {
+			return fmt.Errorf("%s DataFrame is not sorted by the keys at row %d", name, row)
+		}
+	}
+	return nil
+}
+
+func (j *joiner) mergeJoin(ctx context.Context) error {
+	nl, nr := j.left.n, j.right.n
+	if err := j.checkSorted(ctx, "left", j.leftKeys, nl); err != nil {
+		return err
+	}
+	if err := j.checkSorted(ctx, "right", j.rightKeys, nr); err != nil {
+		return err
+	}
+
+	l, r := 0, 0
+	for l < nl || r < nr {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if l == nl {
+			j.emitRight(r)
+			r++
+			continue
+		}
+		lvals := j.keyValues(j.leftKeys, l)
+		if r == nr {
+			j.emitLeft(l)
+			l++
+			continue
+		}
+		rvals := j.keyValues(j.rightKeys, r)
+
+		c := compareKeys(j.leftKeys, lvals, rvals)
+		switch {
+		case c < 0, c == 0 && !j.matchable(lvals):
+			j.emitLeft(l)
+			l++
+			continue
+		case c > 0:
+			j.emitRight(r)
+			r++
+			continue
+		}
+
+		// Both runs of equal keys are joined
+		l2 := l + 1
+		for l2 < nl && compareKeys(j.leftKeys, lvals, j.keyValues(j.leftKeys, l2)) == 0 {
+			l2++
+		}
+		r2 := r + 1
+		for r2 < nr && compareKeys(j.rightKeys, rvals, j.keyValues(j.rightKeys, r2)) == 0 {
+			r2++
+		}
+		for ; l < l2; l++ {
+			for rr := r; rr < r2; rr++ {
+				j.emitMatch(l, rr)
+			}
+		}
+		r = r2
+	}
+
+	return nil
+}
+
+// output creates the joined DataFrame.
+func (j *joiner) output(ctx context.Context, suffixes [2]string) (*DataFrame, error) {
+	n := len(j.leftRows)
+
+	isRightKey := map[int]bool{}
+	for _, col := range j.rightCols {
+		isRightKey[col] = true
+	}
+	leftKeyOf := map[int]int{}
+	for i, col := range j.leftCols {
+		leftKeyOf[col] = i
+	}
+
+	var rightCols []int
+	if j.how != AntiJoin {
+		for col := range j.right.Series {
+			if !isRightKey[col] {
+				rightCols = append(rightCols, col)
+			}
+		}
+	}
+
+	// Names of both sides that collide get suffixes
+	rightNames := map[string]bool{}
+	for _, col := range rightCols {
+		rightNames[j.right.Series[col].Name(j.sopts...)] = true
+	}
+	leftNames := map[string]bool{}
+	for _, s := range j.left.Series {
+		leftNames[s.Name(j.sopts...)] = true
+	}
+
+	seriess := []Series{}
+
+	for col, s := range j.left.Series {
+		name := s.Name(j.sopts...)
+		if rightNames[name] {
+			name += suffixes[0]
+		}
+		ns := newSeriesLike(s, name, n)
+
+		k, isKey := leftKeyOf[col]
+		for i, l := range j.leftRows {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			switch {
+			case l >= 0:
+				ns.Append(s.Value(l, j.sopts...), dontLock)
+			case isKey:
+				ns.Append(j.rightKeys[k].Value(j.rightRows[i], j.sopts...), dontLock)
+			default:
+				ns.Append(nil, dontLock)
+			}
+		}
+		seriess = append(seriess, ns)
+	}
+
+	for _, col := range rightCols {
+		s := j.right.Series[col]
+		name := s.Name(j.sopts...)
+		if leftNames[name] {
+			name += suffixes[1]
+		}
+		ns := newSeriesLike(s, name, n)
+
+		for _, r := range j.rightRows {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			if r >= 0 {
+				ns.Append(s.Value(r, j.sopts...), dontLock)
+			} else {
+				ns.Append(nil, dontLock)
+			}
+		}
+		seriess = append(seriess, ns)
+	}
+
+	if err := checkUniqueNames(seriess); err != nil {
+		return nil, err
+	}
+	return NewDataFrame(seriess...), nil
+}
@@ -0,0 +1,225 @@
+package dataframe
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// dataFrameValues returns the values of the DataFrame by series.
+func dataFrameValues(df *DataFrame) [][]interface{} {
+	out := [][]interface{}{}
+	for _, s := range df.Series {
+		vals := []interface{}{}
+		for row := 0; row < s.NRows(); row++ {
+			vals = append(vals, s.Value(row))
+		}
+		out = append(out, vals)
+	}
+	return out
+}
+
+func TestJoin(t *testing.T) {
+	ctx := context.Background()
+
+	left := NewDataFrame(
+		NewSeriesInt64("id", nil, 1, 2, 2, 3, nil),
+		NewSeriesString("name", nil, "a", "b", "c", "d", "e"),
+	)
+	right := NewDataFrame(
+		NewSeriesInt64("id", nil, 2, 4, 1, nil),
+		NewSeriesString("name", nil, "x", "y", "z", "w"),
+		NewSeriesFloat64("score", nil, 0.5, 0.7, 0.1, 0.9),
+	)
+
+	tests := []struct {
+		how      JoinType
+		opts     JoinOptions
+		names    []string
+		expected [][]interface{}
+	}{
+		{InnerJoin, JoinOptions{}, []string{"id", "name_x", "name_y", "score"}, [][]interface{}{
+			{int64(1), int64(2), int64(2)},
+			{"a", "b", "c"},
+			{"z", "x", "x"},
+			{0.1, 0.5, 0.5},
+		}},
+		{LeftJoin, JoinOptions{Suffixes: [2]string{"_l", "_r"}}, []string{"id", "name_l", "name_r", "score"}, [][]interface{}{
+			{int64(1), int64(2), int64(2), int64(3), nil},
+			{"a", "b", "c", "d", "e"},
+			{"z", "x", "x", nil, nil},
+			{0.1, 0.5, 0.5, nil, nil},
+		}},
+		{RightJoin, JoinOptions{}, []string{"id", "name_x", "name_y", "score"}, [][]interface{}{
+			{int64(2), int64(2), int64(4), int64(1), nil},
+			{"b", "c", nil, "a", nil},
+			{"x", "x", "y", "z", "w"},
+			{0.5, 0.5, 0.7, 0.1, 0.9},
+		}},
+		{OuterJoin, JoinOptions{MatchNilKeys: true}, []string{"id", "name_x", "name_y", "score"}, [][]interface{}{
+			{int64(1), int64(2), int64(2), int64(3), nil, int64(4)},
+			{"a", "b", "c", "d", "e", nil},
+			{"z", "x", "x", nil, "w", "y"},
+			{0.1, 0.5, 0.5, nil, 0.9, 0.7},
+		}},
+		{AntiJoin, JoinOptions{}, []string{"id", "name"}, [][]interface{}{
+			{int64(3), nil},
+			{"d", "e"},
+		}},
+	}
+
+	for i, tc := range tests {
+		jdf, err := left.Join(ctx, right, tc.how, []JoinKey{{Left: "id"}}, tc.opts)
+		if err != nil {
+			t.Errorf("%d: wrong err: expected: %v actual: %v", i, nil, err)
+			continue
+		}
+		if !cmp.Equal(jdf.Names(), tc.names) {
+			t.Errorf("%d: wrong names: expected: %v actual: %v", i, tc.names, jdf.Names())
+		}
+		if !cmp.Equal(dataFrameValues(jdf), tc.expected) {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, dataFrameValues(jdf))
+		}
+	}
+}
+
+func TestJoinIsEqualFunc(t *testing.T) {
+	ctx := context.Background()
+
+	// Keys are compared with a custom IsEqualFunc
+	isEqual := func(a, b interface{}) bool {
+		return strings.EqualFold(a.(string), b.(string))
+	}
+	lcode := NewSeriesGeneric("code", "", nil, "A", "b", "c")
+	lcode.SetIsEqualFunc(isEqual)
+	rcode := NewSeriesGeneric("code", "", nil, "a", "B", "a")
+	rcode.SetIsEqualFunc(isEqual)
+	left := NewDataFrame(lcode, NewSeriesInt64("x", nil, 1, 2, 3))
+	right := NewDataFrame(rcode, NewSeriesInt64("y", nil, 4, 5, 6))
+
+	tests := []struct {
+		how      JoinType
+		expected [][]interface{}
+	}{
+		{InnerJoin, [][]interface{}{{"A", "A", "b"}, {int64(1), int64(1), int64(2)}, {int64(4), int64(6), int64(5)}}},
+		{RightJoin, [][]interface{}{{"A", "b", "A"}, {int64(1), int64(2), int64(1)}, {int64(4), int64(5), int64(6)}}},
+		{AntiJoin, [][]interface{}{{"c"}, {int64(3)}}},
+	}
+
+	for i, tc := range tests {
+		jdf, err := left.Join(ctx, right, tc.how, []JoinKey{{Left: "code"}})
+		if err != nil {
+			t.Errorf("%d: wrong err: expected: %v actual: %v", i, nil, err)
+			continue
+		}
+		if !cmp.Equal(dataFrameValues(jdf), tc.expected) {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, dataFrameValues(jdf))
+		}
+	}
+}
+
+func TestJoinSorted(t *testing.T) {
+	ctx := context.Background()
+
+	day := func(d int) time.Time {
+		return time.Date(2022, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	left := NewDataFrame(
+		NewSeriesTime("day", nil, nil, day(1), day(2), day(2), day(5)),
+		NewSeriesString("kind", nil, "c", "a", "a", "b", "a"),
+		NewSeriesFloat64("v", nil, 1.0, 2.0, 3.0, 4.0, 5.0),
+	)
+	right := NewDataFrame(
+		NewSeriesTime("when", nil, nil, day(2), day(2), day(3), day(5)),
+		NewSeriesString("kind", nil, "a", "a", "b", "a", "a"),
+		NewSeriesGeneric("w", "", nil, "p", "q", "r", "s", "t"),
+	)
+	keys := []JoinKey{{Left: "day", Right: "when"}, {Left: 1, Right: 1}}
+
+	for _, how := range []JoinType{InnerJoin, LeftJoin, RightJoin, OuterJoin, AntiJoin} {
+		hashed, err := left.Join(ctx, right, how, keys)
+		if err != nil {
+			t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+		}
+		merged, err := left.Join(ctx, right, how, keys, JoinOptions{Sorted: true})
+		if err != nil {
+			t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+		}
+
+		// The unmatched right rows are in key order
+		if how == OuterJoin {
+			hashed.Sort(ctx, []SortKey{{Key: "day"}, {Key: "kind"}}, SortOptions{Stable: true})
+		}
+		if !cmp.Equal(dataFrameValues(hashed), dataFrameValues(merged)) {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", how, dataFrameValues(hashed), dataFrameValues(merged))
+		}
+	}
+
+	inner, _ := left.Join(ctx, right, InnerJoin, keys, JoinOptions{Sorted: true})
+	expected := [][]interface{}{
+		{day(2), day(2), day(5)},
+		{"a", "b", "a"},
+		{3.0, 4.0, 5.0},
+		{"q", "r", "t"},
+	}
+	if !cmp.Equal(dataFrameValues(inner), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, dataFrameValues(inner))
+	}
+	if _, ok := inner.Series[3].(*SeriesGeneric); !ok {
+		t.Errorf("wrong type: expected: %v actual: %T", "*SeriesGeneric", inner.Series[3])
+	}
+
+	// Unsorted frames are rejected
+	left.Swap(1, 4)
+	if _, err := left.Join(ctx, right, InnerJoin, keys, JoinOptions{Sorted: true}); err == nil {
+		t.Errorf("wrong err: expected: error actual: %v", err)
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	ctx := context.Background()
+
+	left := NewDataFrame(NewSeriesInt64("id", nil, 1, 2))
+	right := NewDataFrame(NewSeriesString("id", nil, "1", "2"))
+
+	for i, keys := range [][]JoinKey{
+		nil,
+		{{Left: "id"}},
+		{{Left: "key"}},
+		{{Left: 0, Right: 3}},
+	} {
+		if _, err := left.Join(ctx, right, InnerJoin, keys); err == nil {
+			t.Errorf("%d: wrong err: expected: error actual: %v", i, err)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := left.Join(cancelled, left, InnerJoin, []JoinKey{{Left: "id"}}); err != context.Canceled {
+		t.Errorf("wrong err: expected: %v actual: %v", context.Canceled, err)
+	}
+}
@@ -22,9 +22,11 @@ package matrix
 
 import (
 	"context"
+	"math"
 	"testing"
 
 	dataframe "github.com/bhojpur/mathematics/pkg/dataframe"
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
 )
 
 func TestTranspose(t *testing.T) {
@@ -47,3 +49,24 @@ func TestTranspose(t *testing.T) {
 		t.Errorf("matrix transpose error")
 	}
 }
+
+func TestOLS(t *testing.T) {
+
+	x1 := dataframe.NewSeriesFloat64("x1", nil, 0, 1, 2, 3, 4, 5)
+	x2 := dataframe.NewSeriesFloat64("x2", nil, 1, 0, 1, 5, 2, 3)
+	df := dataframe.NewDataFrame(x1, x2)
+
+	// y = 1 + 2 x1 - 3 x2
+	y := stats.Float64Data{-2, 3, 2, -8, 3, 2}
+
+	r, err := stats.OLS(MatrixWrap{df}, y, true)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	for j, expected := range []float64{1, 2, -3} {
+		if math.Abs(r.Coefficients[j]-expected) > 1e-12 {
+			t.Errorf("wrong coefficient %d: expected: %v got: %v", j, expected, r.Coefficients[j])
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package dataframe
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PivotOptions modifies the behavior of Pivot.
+type PivotOptions struct {
+
+	// Aggregate can be set to aggregate the values that have the same index
+	// and column with Func (and Reduce for AggCustom). By default, such
+	// duplicates are an error.
+	Aggregate bool
+
+	// Func is the aggregation used when Aggregate is set.
+	Func AggregateFunc
+
+	// Reduce is required by AggCustom.
+	Reduce ReduceFn
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// Pivot reshapes the DataFrame from long to wide format. The output
+// contains the distinct values of the index Series, followed by one Series
+// per distinct value of the columns Series holding the values Series.
+// Distinct values, compared as in GroupBy, appear in the order they are
+// first found. Combinations of index and column without a row are nil.
+//
+// index, columns and values can be an int (position of series) or string
+// (name of series). The output Series are named with ValueString of the
+// columns Series, and have the type of the values Series, or the type of
+// the aggregation (see GroupBy) if Aggregate is set.
+func (df *DataFrame) Pivot(ctx context.Context, index, columns, values interface{}, opts ...PivotOptions) (*DataFrame, error) {
+	if len(opts) == 0 {
+		opts = append(opts, PivotOptions{})
+	}
+
+	var sopts []Options
+	if !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	} else {
+		sopts = append(sopts, dontLock)
+	}
+
+	agg := Aggregation{Func: opts[0].Func, Reduce: opts[0].Reduce}
+	if opts[0].Aggregate {
+		if agg.Func < 0 || agg.Func > AggCustom {
+			return nil, fmt.Errorf("unknown aggregation: %d", int(agg.Func))
+		}
+		if agg.Func == AggCustom && agg.Reduce == nil {
+			return nil, errors.New("Reduce is required for AggCustom")
+		}
+	}
+
+	cols := make([]int, 3)
+	for i, key := range []interface{}{index, columns, values} {
+		col, err := df.column(key)
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = col
+	}
+	is, cs, vs := df.Series[cols[0]], df.Series[cols[1]], df.Series[cols[2]]
+
+	rowIDs, indexRows, err := groupIDs(ctx, is, df.n, false, sopts)
+	if err != nil {
+		return nil, err
+	}
+	colIDs, columnRows, err := groupIDs(ctx, cs, df.n, false, sopts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows of each combination of column and index
+	cells := make([][][]int, len(columnRows))
+	for c := range cells {
+		cells[c] = make([][]int, len(indexRows))
+	}
+	for row := 0; row < df.n; row++ {
+		c, r := colIDs[row], rowIDs[row]
+		cells[c][r] = append(cells[c][r], row)
+	}
+
+	seriess := []Series{}
+
+	ns := newSeriesLike(is, is.Name(sopts...), len(indexRows))
+	for _, row := range indexRows {
+		ns.Append(is.Value(row, sopts...), dontLock)
+	}
+	seriess = append(seriess, ns)
+
+	for c, crow := range columnRows {
+		name := cs.ValueString(crow, sopts...)
+
+		var ns Series
+		if opts[0].Aggregate {
+			ns = aggregationSeries(vs, agg.Func, name, len(indexRows))
+		} else {
+			ns = newSeriesLike(vs, name, len(indexRows))
+		}
+
+		for r, rows := range cells[c] {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			switch {
+			case len(rows) == 0:
+				ns.Append(nil, dontLock)
+			case !opts[0].Aggregate:
+				if len(rows) > 1 {
+					return nil, fmt.Errorf("duplicate entries for index %s and column %s", is.ValueString(indexRows[r], sopts...), name)
+				}
+				ns.Append(vs.Value(rows[0], sopts...), dontLock)
+			default:
+				vals := make([]interface{}, 0, len(rows))
+				for _, row := range rows {
+					vals = append(vals, vs.Value(row, sopts...))
+				}
+				val, err := aggregate(vs, agg, vals)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+				ns.Append(val, dontLock)
+			}
+		}
+		seriess = append(seriess, ns)
+	}
+
+	if err := checkUniqueNames(seriess); err != nil {
+		return nil, err
+	}
+	return NewDataFrame(seriess...), nil
+}
+
+// MeltOptions modifies the behavior of Melt.
+type MeltOptions struct {
+
+	// VarName is the name of the Series holding the names of the melted
+	// Series. It defaults to "variable".
+	VarName string
+
+	// ValueName is the name of the Series holding the melted values. It
+	// defaults to "value".
+	ValueName string
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// Melt reshapes the DataFrame from wide to long format, as the inverse of
+// Pivot. For each Series in valueVars, and each row, the output contains
+// the values of the idVars Series, the name of the Series and its value.
+// If valueVars is empty, all Series not in idVars are melted.
+//
+// idVars and valueVars can contain ints (position of series) or strings
+// (name of series). The value Series has the type of the melted Series if
+// they are all of the same type, SeriesFloat64 if they are a mix of
+// SeriesInt64 and SeriesFloat64, and SeriesMixed otherwise.
+func (df *DataFrame) Melt(ctx context.Context, idVars []interface{}, valueVars []interface{}, opts ...MeltOptions) (*DataFrame, error) {
+	if len(opts) == 0 {
+		opts = append(opts, MeltOptions{})
+	}
+	if opts[0].VarName == "" {
+		opts[0].VarName = "variable"
+	}
+	if opts[0].ValueName == "" {
+		opts[0].ValueName = "value"
+	}
+
+	var sopts []Options
+	if !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	} else {
+		sopts = append(sopts, dontLock)
+	}
+
+	isID := map[int]bool{}
+	ids := []Series{}
+	for _, key := range idVars {
+		col, err := df.column(key)
+		if err != nil {
+			return nil, err
+		}
+		isID[col] = true
+		ids = append(ids, df.Series[col])
+	}
+
+	vars := []Series{}
+	for _, key := range valueVars {
+		col, err := df.column(key)
+		if err != nil {
+			return nil, err
+		}
+		vars = append(vars, df.Series[col])
+	}
+	if len(valueVars) == 0 {
+		for col, s := range df.Series {
+			if !isID[col] {
+				vars = append(vars, s)
+			}
+		}
+	}
+
+	n := df.n * len(vars)
+
+	seriess := []Series{}
+	for _, s := range ids {
+		seriess = append(seriess, newSeriesLike(s, s.Name(sopts...), n))
+	}
+	variable := NewSeriesString(opts[0].VarName, &SeriesInit{Capacity: n})
+	value := commonSeries(vars, opts[0].ValueName, n)
+	seriess = append(seriess, variable, value)
+
+	for _, v := range vars {
+		name := v.Name(sopts...)
+		for row := 0; row < df.n; row++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			for i, s := range ids {
+				seriess[i].Append(s.Value(row, sopts...), dontLock)
+			}
+			variable.Append(name, dontLock)
+			value.Append(v.Value(row, sopts...), dontLock)
+		}
+	}
+
+	if err := checkUniqueNames(seriess); err != nil {
+		return nil, err
+	}
+	return NewDataFrame(seriess...), nil
+}
+
+// CrosstabOptions modifies the behavior of Crosstab.
+type CrosstabOptions struct {
+
+	// DontLock can be set to true if the Series should not be locked.
+	DontLock bool
+}
+
+// Crosstab counts the occurrences of each combination of values of two
+// Series of the same length. The output contains the distinct values of
+// rows, followed by one SeriesInt64 per distinct value of cols, named with
+// its ValueString. Distinct values, compared as in GroupBy, appear in the
+// order they are first found. Rows where either value is nil are not
+// counted.
+func Crosstab(ctx context.Context, rows, cols Series, opts ...CrosstabOptions) (*DataFrame, error) {
+	var sopts []Options
+	if len(opts) > 0 && opts[0].DontLock {
+		sopts = append(sopts, dontLock)
+	}
+
+	n := rows.NRows(sopts...)
+	if cols.NRows(sopts...) != n {
+		return nil, errors.New("different number of rows in series")
+	}
+
+	rowIDs, rowFirst, err := groupIDs(ctx, rows, n, true, sopts)
+	if err != nil {
+		return nil, err
+	}
+	colIDs, colFirst, err := groupIDs(ctx, cols, n, true, sopts)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([][]int64, len(colFirst))
+	for c := range counts {
+		counts[c] = make([]int64, len(rowFirst))
+	}
+	for row := 0; row < n; row++ {
+		if rowIDs[row] >= 0 && colIDs[row] >= 0 {
+			counts[colIDs[row]][rowIDs[row]]++
+		}
+	}
+
+	seriess := []Series{}
+
+	ns := newSeriesLike(rows, rows.Name(sopts...), len(rowFirst))
+	for _, row := range rowFirst {
+		ns.Append(rows.Value(row, sopts...), dontLock)
+	}
+	seriess = append(seriess, ns)
+
+	for c, row := range colFirst {
+		seriess = append(seriess, NewSeriesInt64(cols.ValueString(row, sopts...), nil, counts[c]))
+	}
+
+	if err := checkUniqueNames(seriess); err != nil {
+		return nil, err
+	}
+	return NewDataFrame(seriess...), nil
+}
+
+// groupIDs numbers the distinct values of the first n rows of s in the
+// order they are first found, comparing them like GroupBy. It returns the
+// number of the value of each row, and the first row with each value. If
+// skipNil is set, nil values are numbered -1.
+func groupIDs(ctx context.Context, s Series, n int, skipNil bool, sopts []Options) ([]int, []int, error) {
+	ids := make([]int, n)
+	first := []int{}
+	numbers := newKeyNumbers(s)
+
+	for row := 0; row < n; row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		val := s.Value(row, sopts...)
+		if val == nil && skipNil {
+			ids[row] = -1
+			continue
+		}
+
+		id, isNew := numbers.number(val)
+		if isNew {
+			first = append(first, row)
+		}
+		ids[row] = id
+	}
+
+	return ids, first, nil
+}
+
+// commonSeries creates an empty Series that can hold the values of all
+// series: a Series of their type if they have the same type, a
+// SeriesFloat64 for a mix of SeriesInt64 and SeriesFloat64, and a
+// SeriesMixed otherwise.
+func commonSeries(series []Series, name string, capacity int) Series {
+	if len(series) == 0 {
+		return NewSeriesMixed(name, &SeriesInit{Capacity: capacity})
+	}
+
+	same, numeric := true, true
+	for _, s := range series {
+		if s.Type() != series[0].Type() {
+			same = false
+		}
+		if t := s.Type(); t != "int64" && t != "float64" {
+			numeric = false
+		}
+	}
+
+	switch {
+	case same:
+		return newSeriesLike(series[0], name, capacity)
+	case numeric:
+		return NewSeriesFloat64(name, &SeriesInit{Capacity: capacity})
+	default:
+		return NewSeriesMixed(name, &SeriesInit{Capacity: capacity})
+	}
+}
@@ -0,0 +1,154 @@
+package dataframe
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPivotAndMelt(t *testing.T) {
+	ctx := context.Background()
+
+	long := NewDataFrame(
+		NewSeriesString("city", nil, "paris", "paris", "rome", "rome", "oslo"),
+		NewSeriesInt64("year", nil, 2020, 2021, 2020, 2021, 2021),
+		NewSeriesInt64("visits", nil, 10, 12, 8, nil, 3),
+	)
+
+	wide, err := long.Pivot(ctx, "city", "year", "visits")
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	expectedNames := []string{"city", "2020", "2021"}
+	expected := [][]interface{}{
+		{"paris", "rome", "oslo"},
+		{int64(10), int64(8), nil},
+		{int64(12), nil, int64(3)},
+	}
+	if !cmp.Equal(wide.Names(), expectedNames) {
+		t.Errorf("wrong names: expected: %v actual: %v", expectedNames, wide.Names())
+	}
+	if !cmp.Equal(dataFrameValues(wide), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, dataFrameValues(wide))
+	}
+	if _, ok := wide.Series[1].(*SeriesInt64); !ok {
+		t.Errorf("wrong type: expected: %v actual: %T", "*SeriesInt64", wide.Series[1])
+	}
+
+	melted, err := wide.Melt(ctx, []interface{}{"city"}, nil, MeltOptions{VarName: "year", ValueName: "visits"})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+	expected = [][]interface{}{
+		{"paris", "rome", "oslo", "paris", "rome", "oslo"},
+		{"2020", "2020", "2020", "2021", "2021", "2021"},
+		{int64(10), int64(8), nil, int64(12), nil, int64(3)},
+	}
+	if !cmp.Equal(dataFrameValues(melted), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, dataFrameValues(melted))
+	}
+
+	// Duplicates need an aggregation
+	long.Append(nil, "paris", 2020, 4)
+	if _, err := long.Pivot(ctx, 0, 1, 2); err == nil {
+		t.Errorf("wrong err: expected: error actual: %v", err)
+	}
+	wide, err = long.Pivot(ctx, 0, 1, 2, PivotOptions{Aggregate: true, Func: AggMean})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+	expected = [][]interface{}{
+		{"paris", "rome", "oslo"},
+		{7.0, 8.0, nil},
+		{12.0, nil, 3.0},
+	}
+	if !cmp.Equal(dataFrameValues(wide), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, dataFrameValues(wide))
+	}
+}
+
+func TestMeltTypes(t *testing.T) {
+	ctx := context.Background()
+
+	df := NewDataFrame(
+		NewSeriesString("id", nil, "a", "b"),
+		NewSeriesInt64("x", nil, 1, 2),
+		NewSeriesFloat64("y", nil, 0.5, nil),
+		NewSeriesString("z", nil, "p", "q"),
+	)
+
+	melted, err := df.Melt(ctx, []interface{}{"id"}, []interface{}{"x", 2})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+	if _, ok := melted.Series[2].(*SeriesFloat64); !ok {
+		t.Errorf("wrong type: expected: %v actual: %T", "*SeriesFloat64", melted.Series[2])
+	}
+	expected := []interface{}{1.0, 2.0, 0.5, nil}
+	if !cmp.Equal(dataFrameValues(melted)[2], expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, dataFrameValues(melted)[2])
+	}
+
+	melted, err = df.Melt(ctx, []interface{}{"id"}, nil)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+	if _, ok := melted.Series[2].(*SeriesMixed); !ok || melted.NRows() != 6 {
+		t.Errorf("wrong type: expected: %v actual: %T", "*SeriesMixed", melted.Series[2])
+	}
+
+	if _, err := df.Melt(ctx, []interface{}{"id"}, nil, MeltOptions{VarName: "id"}); err == nil {
+		t.Errorf("wrong err: expected: error actual: %v", err)
+	}
+}
+
+func TestCrosstab(t *testing.T) {
+	ctx := context.Background()
+
+	sex := NewSeriesString("sex", nil, "m", "f", "f", "m", nil, "f")
+	smoker := NewSeriesString("smoker", nil, "yes", "no", "yes", "yes", "no", nil)
+
+	ct, err := Crosstab(ctx, sex, smoker)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	expectedNames := []string{"sex", "yes", "no"}
+	expected := [][]interface{}{
+		{"m", "f"},
+		{int64(2), int64(1)},
+		{int64(0), int64(1)},
+	}
+	if !cmp.Equal(ct.Names(), expectedNames) {
+		t.Errorf("wrong names: expected: %v actual: %v", expectedNames, ct.Names())
+	}
+	if !cmp.Equal(dataFrameValues(ct), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, dataFrameValues(ct))
+	}
+
+	if _, err := Crosstab(ctx, sex, NewSeriesString("x", nil, "a")); err == nil {
+		t.Errorf("wrong err: expected: error actual: %v", err)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"sync"
 
@@ -358,6 +359,12 @@ func (s *SeriesGeneric) IsEqualFunc(a, b interface{}) bool {
 	return s.isEqualFunc(a, b)
 }
 
+// customIsEqualFunc returns true if the values are compared with a function
+// other than DefaultIsEqualFunc.
+func (s *SeriesGeneric) customIsEqualFunc() bool {
+	return reflect.ValueOf(s.isEqualFunc).Pointer() != reflect.ValueOf(DefaultIsEqualFunc).Pointer()
+}
+
 // IsLessThanFunc returns true if a is less than b.
 func (s *SeriesGeneric) IsLessThanFunc(a, b interface{}) bool {
 
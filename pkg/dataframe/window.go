@@ -0,0 +1,404 @@
+package dataframe
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// WindowOptions modifies the behavior of Rolling, RollingDuration and
+// Expanding.
+type WindowOptions struct {
+
+	// MinPeriods is the minimum number of non-nil values in a window for it
+	// to produce a value. Windows with fewer values give nil. It defaults to
+	// the size of the window for Rolling, and to 1 otherwise.
+	MinPeriods int
+
+	// Center can be set to center the windows on their row instead of
+	// ending them at their row. It is ignored by Expanding.
+	Center bool
+
+	// DontLock can be set to true if the Series should not be locked.
+	DontLock bool
+}
+
+// Window is a set of windows over a Series, one per row, created by
+// Rolling, RollingDuration or Expanding. Its methods return a SeriesFloat64
+// with one value per window. Like Sum, they ignore nil values, and give nil
+// for windows without enough non-nil values.
+type Window struct {
+	name       string
+	values     []float64 // NaN for nil
+	lo, hi     []int     // window of each row is values[lo:hi]
+	minPeriods int
+}
+
+// Rolling returns windows of size rows over the series. By default, the
+// window of a row ends at that row.
+func (s *SeriesFloat64) Rolling(size int, opts ...WindowOptions) *Window {
+	return newRolling(s.Name(windowLockOptions(opts)...), s.windowValues(opts), size, opts)
+}
+
+// RollingDuration returns windows over the series that contain the rows
+// whose time in index is within period of the time of the row. By default,
+// the window of a row at time t contains the times in (t - period, t].
+// index must have the same length as the series, and contain no nil
+// values, in ascending order.
+func (s *SeriesFloat64) RollingDuration(index *SeriesTime, period time.Duration, opts ...WindowOptions) (*Window, error) {
+	return newRollingDuration(s.Name(windowLockOptions(opts)...), s.windowValues(opts), index, period, opts)
+}
+
+// Expanding returns windows over the series that contain all rows up to
+// and including their row.
+func (s *SeriesFloat64) Expanding(opts ...WindowOptions) *Window {
+	return newExpanding(s.Name(windowLockOptions(opts)...), s.windowValues(opts), opts)
+}
+
+func (s *SeriesFloat64) windowValues(opts []WindowOptions) []float64 {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return append([]float64(nil), s.Values...)
+}
+
+// Rolling returns windows of size rows over the series. By default, the
+// window of a row ends at that row.
+func (s *SeriesInt64) Rolling(size int, opts ...WindowOptions) *Window {
+	return newRolling(s.Name(windowLockOptions(opts)...), s.windowValues(opts), size, opts)
+}
+
+// RollingDuration returns windows over the series that contain the rows
+// whose time in index is within period of the time of the row. By default,
+// the window of a row at time t contains the times in (t - period, t].
+// index must have the same length as the series, and contain no nil
+// values, in ascending order.
+func (s *SeriesInt64) RollingDuration(index *SeriesTime, period time.Duration, opts ...WindowOptions) (*Window, error) {
+	return newRollingDuration(s.Name(windowLockOptions(opts)...), s.windowValues(opts), index, period, opts)
+}
+
+// Expanding returns windows over the series that contain all rows up to
+// and including their row.
+func (s *SeriesInt64) Expanding(opts ...WindowOptions) *Window {
+	return newExpanding(s.Name(windowLockOptions(opts)...), s.windowValues(opts), opts)
+}
+
+func (s *SeriesInt64) windowValues(opts []WindowOptions) []float64 {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	vals := make([]float64, len(s.values))
+	for i, v := range s.values {
+		if v == nil {
+			vals[i] = nan()
+		} else {
+			vals[i] = float64(*v)
+		}
+	}
+	return vals
+}
+
+func windowLockOptions(opts []WindowOptions) []Options {
+	if len(opts) > 0 && opts[0].DontLock {
+		return []Options{dontLock}
+	}
+	return nil
+}
+
+func newRolling(name string, vals []float64, size int, opts []WindowOptions) *Window {
+	if size < 1 {
+		panic("size of window must be at least 1")
+	}
+
+	w := newWindow(name, vals, size, opts)
+
+	offset := 0
+	if len(opts) > 0 && opts[0].Center {
+		offset = (size - 1) / 2
+	}
+	for i := range vals {
+		end := i + offset + 1
+		w.lo[i] = clampRow(end-size, len(vals))
+		w.hi[i] = clampRow(end, len(vals))
+	}
+	return w
+}
+
+func newRollingDuration(name string, vals []float64, index *SeriesTime, period time.Duration, opts []WindowOptions) (*Window, error) {
+	if period <= 0 {
+		return nil, errors.New("period must be positive")
+	}
+
+	var times []*time.Time
+	if len(opts) == 0 || !opts[0].DontLock {
+		index.lock.RLock()
+		times = append(times, index.Values...)
+		index.lock.RUnlock()
+	} else {
+		times = append(times, index.Values...)
+	}
+
+	if len(times) != len(vals) {
+		return nil, errors.New("different number of rows in series and index")
+	}
+	for i, t := range times {
+		if t == nil {
+			return nil, fmt.Errorf("index contains nil at row %d", i)
+		}
+		if i > 0 && t.Before(*times[i-1]) {
+			return nil, fmt.Errorf("index is not in ascending order at row %d", i)
+		}
+	}
+
+	w := newWindow(name, vals, 1, opts)
+
+	// The window of a row at time t is (t - before, t + after]
+	before, after := period, time.Duration(0)
+	if len(opts) > 0 && opts[0].Center {
+		before, after = period/2, period-period/2
+	}
+
+	lo, hi := 0, 0
+	for i, t := range times {
+		for lo < len(times) && !times[lo].After(t.Add(-before)) {
+			lo++
+		}
+		for hi < len(times) && !times[hi].After(t.Add(after)) {
+			hi++
+		}
+		w.lo[i], w.hi[i] = lo, hi
+	}
+	return w, nil
+}
+
+func newExpanding(name string, vals []float64, opts []WindowOptions) *Window {
+	w := newWindow(name, vals, 1, opts)
+	for i := range vals {
+		w.lo[i], w.hi[i] = 0, i+1
+	}
+	return w
+}
+
+func newWindow(name string, vals []float64, minPeriods int, opts []WindowOptions) *Window {
+	if len(opts) > 0 && opts[0].MinPeriods > 0 {
+		minPeriods = opts[0].MinPeriods
+	}
+	return &Window{
+		name:       name,
+		values:     vals,
+		lo:         make([]int, len(vals)),
+		hi:         make([]int, len(vals)),
+		minPeriods: minPeriods,
+	}
+}
+
+func clampRow(row, n int) int {
+	if row < 0 {
+		return 0
+	}
+	if row > n {
+		return n
+	}
+	return row
+}
+
+// Sum returns the sum of the non-nil values of each window. If opposing
+// infinites are found, a NaN is returned.
+func (w *Window) Sum(ctx context.Context) (*SeriesFloat64, error) {
+	return w.running(ctx, func(sum float64, count int) float64 {
+		return sum
+	})
+}
+
+// Mean returns the mean of the non-nil values of each window.
+func (w *Window) Mean(ctx context.Context) (*SeriesFloat64, error) {
+	return w.running(ctx, func(sum float64, count int) float64 {
+		return sum / float64(count)
+	})
+}
+
+// Min returns the smallest non-nil value of each window.
+func (w *Window) Min(ctx context.Context) (*SeriesFloat64, error) {
+	return w.Apply(ctx, func(vals []float64) float64 {
+		m := vals[0]
+		for _, v := range vals[1:] {
+			m = math.Min(m, v)
+		}
+		return m
+	})
+}
+
+// Max returns the largest non-nil value of each window.
+func (w *Window) Max(ctx context.Context) (*SeriesFloat64, error) {
+	return w.Apply(ctx, func(vals []float64) float64 {
+		m := vals[0]
+		for _, v := range vals[1:] {
+			m = math.Max(m, v)
+		}
+		return m
+	})
+}
+
+// Std returns the sample standard deviation of the non-nil values of each
+// window. Windows with fewer than 2 values give nil.
+func (w *Window) Std(ctx context.Context) (*SeriesFloat64, error) {
+	return w.Apply(ctx, func(vals []float64) float64 {
+		if len(vals) < 2 {
+			return nan()
+		}
+		mean := floatSum(vals) / float64(len(vals))
+		var ss float64
+		for _, v := range vals {
+			ss += (v - mean) * (v - mean)
+		}
+		return math.Sqrt(ss / float64(len(vals)-1))
+	})
+}
+
+// Median returns the median of the non-nil values of each window.
+func (w *Window) Median(ctx context.Context) (*SeriesFloat64, error) {
+	return w.Quantile(ctx, 0.5)
+}
+
+// Quantile returns the q-quantile of the non-nil values of each window,
+// interpolating linearly between values. q must be in [0, 1].
+func (w *Window) Quantile(ctx context.Context, q float64) (*SeriesFloat64, error) {
+	if !(q >= 0 && q <= 1) {
+		return nil, fmt.Errorf("quantile must be in [0, 1]: %v", q)
+	}
+
+	return w.Apply(ctx, func(vals []float64) float64 {
+		sorted := append([]float64(nil), vals...)
+		sort.Float64s(sorted)
+
+		pos := q * float64(len(sorted)-1)
+		i := int(pos)
+		if i == len(sorted)-1 {
+			return sorted[i]
+		}
+		return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+	})
+}
+
+// Apply returns the value of fn for the non-nil values of each window with
+// at least MinPeriods values. A NaN returned by fn is stored as nil.
+func (w *Window) Apply(ctx context.Context, fn func(vals []float64) float64) (*SeriesFloat64, error) {
+	out := NewSeriesFloat64(w.name, &SeriesInit{Size: len(w.values)})
+
+	buf := []float64{}
+	for i := range w.values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		buf = buf[:0]
+		for _, v := range w.values[w.lo[i]:w.hi[i]] {
+			if !isNaN(v) {
+				buf = append(buf, v)
+			}
+		}
+		if len(buf) == 0 || len(buf) < w.minPeriods {
+			continue
+		}
+		out.Update(i, fn(buf), dontLock)
+	}
+
+	return out, nil
+}
+
+// running computes fn of the sum and count of the non-nil values of each
+// window, updating them as the windows slide.
+func (w *Window) running(ctx context.Context, fn func(sum float64, count int) float64) (*SeriesFloat64, error) {
+	out := NewSeriesFloat64(w.name, &SeriesInit{Size: len(w.values)})
+
+	// Infinities are counted apart, so that they can leave the window. The
+	// sum is compensated (Neumaier), so that the rounding errors of large
+	// values do not stay in it once they have left the window.
+	var (
+		sum, compensation float64
+		count             int
+		posinfs, neginfs  int
+		lo, hi            int
+	)
+	update := func(v float64, sign int) {
+		switch {
+		case isNaN(v):
+			return
+		case isInf(v, 1):
+			posinfs += sign
+		case isInf(v, -1):
+			neginfs += sign
+		default:
+			v *= float64(sign)
+			t := sum + v
+			if math.Abs(sum) >= math.Abs(v) {
+				compensation += (sum - t) + v
+			} else {
+				compensation += (v - t) + sum
+			}
+			sum = t
+		}
+		count += sign
+	}
+
+	for i := range w.values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for ; hi < w.hi[i]; hi++ {
+			update(w.values[hi], 1)
+		}
+		for ; lo < w.lo[i]; lo++ {
+			update(w.values[lo], -1)
+		}
+		if count == 0 {
+			// Recover exactly from rounding errors
+			sum, compensation = 0, 0
+		}
+
+		if count == 0 || count < w.minPeriods {
+			continue
+		}
+
+		s := sum + compensation
+		switch {
+		case posinfs > 0 && neginfs > 0:
+			s = nan()
+		case posinfs > 0:
+			s = math.Inf(1)
+		case neginfs > 0:
+			s = math.Inf(-1)
+		}
+		out.Update(i, fn(s, count), dontLock)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,152 @@
+package dataframe
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// seriesValues returns the values of s, with nil as NaN.
+func seriesValues(s *SeriesFloat64) []float64 {
+	return append([]float64(nil), s.Values...)
+}
+
+// equalFloats considers NaNs equal, and ignores rounding errors.
+var equalFloats = cmp.Comparer(func(a, b float64) bool {
+	return a == b || isNaN(a) && isNaN(b) || math.Abs(a-b) < 1e-12
+})
+
+func TestRolling(t *testing.T) {
+	ctx := context.Background()
+	nan := math.NaN()
+
+	s := NewSeriesFloat64("price", nil, 1.0, 2.0, nil, 4.0, 5.0, 6.0)
+
+	tests := []struct {
+		w        *Window
+		fn       func(w *Window, ctx context.Context) (*SeriesFloat64, error)
+		expected []float64
+	}{
+		{s.Rolling(2), (*Window).Sum, []float64{nan, 3, nan, nan, 9, 11}},
+		{s.Rolling(2, WindowOptions{MinPeriods: 1}), (*Window).Sum, []float64{1, 3, 2, 4, 9, 11}},
+		{s.Rolling(3, WindowOptions{MinPeriods: 1, Center: true}), (*Window).Mean, []float64{1.5, 1.5, 3, 4.5, 5, 5.5}},
+		{s.Rolling(3, WindowOptions{MinPeriods: 2}), (*Window).Max, []float64{nan, 2, 2, 4, 5, 6}},
+		{s.Rolling(3, WindowOptions{MinPeriods: 2}), (*Window).Min, []float64{nan, 1, 1, 2, 4, 4}},
+		{s.Rolling(3, WindowOptions{MinPeriods: 1}), (*Window).Std, []float64{nan, math.Sqrt(0.5), math.Sqrt(0.5), math.Sqrt(2), math.Sqrt(0.5), 1}},
+		{s.Rolling(3, WindowOptions{MinPeriods: 1}), (*Window).Median, []float64{1, 1.5, 1.5, 3, 4.5, 5}},
+		{s.Expanding(), (*Window).Sum, []float64{1, 3, 3, 7, 12, 18}},
+		{s.Expanding(WindowOptions{MinPeriods: 4}), (*Window).Mean, []float64{nan, nan, nan, nan, 3, 3.6}},
+	}
+
+	for i, tc := range tests {
+		out, err := tc.fn(tc.w, ctx)
+		if err != nil {
+			t.Errorf("%d: wrong err: expected: %v actual: %v", i, nil, err)
+			continue
+		}
+		if out.Name() != "price" || !cmp.Equal(seriesValues(out), tc.expected, equalFloats) {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, seriesValues(out))
+		}
+	}
+
+	// Custom reducers and quantiles
+	out, _ := s.Rolling(2, WindowOptions{MinPeriods: 1}).Apply(ctx, func(vals []float64) float64 {
+		return float64(len(vals))
+	})
+	if expected := []float64{1, 2, 1, 1, 2, 2}; !cmp.Equal(seriesValues(out), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, seriesValues(out))
+	}
+	out, _ = s.Expanding().Quantile(ctx, 0.25)
+	if expected := []float64{1, 1.25, 1.25, 1.5, 1.75, 2}; !cmp.Equal(seriesValues(out), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, seriesValues(out))
+	}
+	if _, err := s.Expanding().Quantile(ctx, 2); err == nil {
+		t.Errorf("wrong err: expected: error actual: %v", err)
+	}
+
+	// Infinities leave the window like other values
+	inf := NewSeriesFloat64("x", nil, math.Inf(1), math.Inf(-1), 1.0, 2.0)
+	out, _ = inf.Rolling(2).Sum(ctx)
+	if expected := []float64{nan, nan, math.Inf(-1), 3}; !cmp.Equal(seriesValues(out), expected, equalFloats) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, seriesValues(out))
+	}
+
+	// Large values do not leave their rounding errors in later windows
+	mixed := NewSeriesFloat64("x", nil, 1e16, 1.0, 1.0, 1.0, 1.0)
+	out, _ = mixed.Rolling(2).Sum(ctx)
+	if expected := []float64{nan, 1e16, 2, 2, 2}; !cmp.Equal(seriesValues(out), expected, equalFloats) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, seriesValues(out))
+	}
+	mixed = NewSeriesFloat64("x", nil, 1e9, 0.1, 0.2, 0.1, 0.2, 0.1, 0.2)
+	out, _ = mixed.Rolling(2).Mean(ctx)
+	if expected := []float64{nan, 5e8 + 0.05, 0.15, 0.15, 0.15, 0.15, 0.15}; !cmp.Equal(seriesValues(out), expected, equalFloats) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, seriesValues(out))
+	}
+}
+
+func TestRollingInt64AndDuration(t *testing.T) {
+	ctx := context.Background()
+	nan := math.NaN()
+
+	day := func(d int) time.Time {
+		return time.Date(2022, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	index := NewSeriesTime("day", nil, day(1), day(2), day(4), day(5), day(9))
+	s := NewSeriesInt64("visits", nil, 1, 2, 3, nil, 5)
+
+	w, err := s.RollingDuration(index, 48*time.Hour)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+	out, _ := w.Sum(ctx)
+	if expected := []float64{1, 3, 3, 3, 5}; !cmp.Equal(seriesValues(out), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, seriesValues(out))
+	}
+
+	w, _ = s.RollingDuration(index, 96*time.Hour, WindowOptions{Center: true})
+	out, _ = w.Mean(ctx)
+	if expected := []float64{1.5, 2, 3, 3, 5}; !cmp.Equal(seriesValues(out), expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, seriesValues(out))
+	}
+
+	out, _ = s.Rolling(2).Mean(ctx)
+	if expected := []float64{nan, 1.5, 2.5, nan, nan}; !cmp.Equal(seriesValues(out), expected, equalFloats) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, seriesValues(out))
+	}
+
+	if _, err := s.RollingDuration(NewSeriesTime("day", nil, day(2), day(1), day(3), day(4), day(5)), time.Hour); err == nil {
+		t.Errorf("wrong err: expected: error actual: %v", err)
+	}
+	if _, err := s.RollingDuration(NewSeriesTime("day", nil, day(1)), time.Hour); err == nil {
+		t.Errorf("wrong err: expected: error actual: %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := s.Expanding().Sum(cancelled); err != context.Canceled {
+		t.Errorf("wrong err: expected: %v actual: %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,288 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	dataframe "github.com/bhojpur/mathematics/pkg/dataframe"
+	"github.com/bhojpur/mathematics/pkg/dataframe/forecast"
+	"github.com/bhojpur/mathematics/pkg/dataframe/forecast/algs/hw"
+	"github.com/bhojpur/mathematics/pkg/dataframe/forecast/algs/ses"
+	"github.com/bhojpur/mathematics/pkg/dataframe/imports"
+	"github.com/bhojpur/mathematics/pkg/dataframe/pandas"
+)
+
+// Executor runs the work of an Engine.
+type Executor interface {
+
+	// Execute runs the Engine described by req. Log output is written to lg.
+	// Execute must return promptly once ctx is canceled.
+	Execute(ctx context.Context, req *v1.StartEngineRequest, lg *Log) ([]*v1.EngineResult, error)
+}
+
+// JobFunc runs a single kind of job.
+type JobFunc func(ctx context.Context, req *v1.StartEngineRequest, lg *Log) ([]*v1.EngineResult, error)
+
+// LocalExecutor runs jobs in-process. If the request carries an engine YAML,
+// it is parsed as a Spec and executed. Otherwise the job is selected by the
+// engine spec name found in the metadata of the request.
+type LocalExecutor struct {
+	Jobs map[string]JobFunc
+
+	// Dir is the directory the paths in a Spec are resolved against. A Spec
+	// cannot access files outside of it, or any file if it is not set.
+	Dir string
+
+	// Databases are the databases a Spec can import from by name. A Spec
+	// cannot connect to any other database.
+	Databases map[string]Database
+}
+
+// Database is a database/sql data source a Spec can import from.
+type Database struct {
+	Driver string
+	DSN    string
+}
+
+// NewLocalExecutor creates a LocalExecutor with the built-in jobs:
+//
+//	statistics: describes the CSV data found in the sideload.
+//	forecast: forecasts a column of the CSV data found in the sideload.
+func NewLocalExecutor() *LocalExecutor {
+	return &LocalExecutor{
+		Jobs: map[string]JobFunc{
+			"statistics": StatisticsJob,
+			"forecast":   ForecastJob,
+		},
+	}
+}
+
+// Execute implements the Executor interface.
+func (e *LocalExecutor) Execute(ctx context.Context, req *v1.StartEngineRequest, lg *Log) ([]*v1.EngineResult, error) {
+	if len(req.EngineYaml) > 0 {
+		spec, err := ParseSpec(req.EngineYaml, req.Metadata.GetAnnotations())
+		if err != nil {
+			return nil, err
+		}
+
+		return e.RunSpec(ctx, spec, req.Sideload, lg)
+	}
+
+	name := req.Metadata.GetEngineSpecName()
+
+	fn, exists := e.Jobs[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown engine spec: %q", name)
+	}
+
+	return fn(ctx, req, lg)
+}
+
+// Annotation returns the value of the annotation key.
+func Annotation(md *v1.EngineMetadata, key string) (string, bool) {
+	for _, a := range md.GetAnnotations() {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// loadSideload loads the CSV data found in the sideload of req.
+func loadSideload(ctx context.Context, req *v1.StartEngineRequest, lg *Log) (*dataframe.DataFrame, error) {
+	lg.Start("load")
+
+	if len(req.Sideload) == 0 {
+		err := errors.New("sideload must contain CSV data")
+		lg.Fail("load", err)
+		return nil, err
+	}
+
+	df, err := imports.LoadFromCSV(ctx, bytes.NewReader(req.Sideload), imports.CSVLoadOptions{InferDataTypes: true})
+	if err != nil {
+		lg.Fail("load", err)
+		return nil, err
+	}
+
+	lg.Printf("load", "loaded %d rows of %v", df.NRows(), df.Names())
+	lg.Done("load")
+
+	return df, nil
+}
+
+// StatisticsJob describes the CSV data found in the sideload of req.
+func StatisticsJob(ctx context.Context, req *v1.StartEngineRequest, lg *Log) ([]*v1.EngineResult, error) {
+	df, err := loadSideload(ctx, req, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	lg.Start("describe")
+	out, err := pandas.Describe(ctx, df)
+	if err != nil {
+		lg.Fail("describe", err)
+		return nil, err
+	}
+	lg.Printf("describe", "%s", out.String())
+	lg.Done("describe")
+
+	return []*v1.EngineResult{
+		{
+			Type:        "describe",
+			Payload:     out.String(),
+			Description: "summary statistics",
+		},
+	}, nil
+}
+
+// ForecastJob forecasts a column of the CSV data found in the sideload of req.
+// It is configured by the following annotations:
+//
+//	column: name of the column to forecast (required).
+//	algorithm: "ses" (default) or "hw".
+//	n: number of values to forecast (default 1).
+//	alpha, beta, gamma: smoothing parameters.
+//	period: seasonal period (hw only).
+func ForecastJob(ctx context.Context, req *v1.StartEngineRequest, lg *Log) ([]*v1.EngineResult, error) {
+	md := req.Metadata
+
+	column, exists := Annotation(md, "column")
+	if !exists {
+		return nil, errors.New("annotation column is required")
+	}
+
+	n, err := uintAnnotation(md, "n", 1)
+	if err != nil {
+		return nil, err
+	}
+	alpha, err := floatAnnotation(md, "alpha", 0.5)
+	if err != nil {
+		return nil, err
+	}
+	beta, err := floatAnnotation(md, "beta", 0.5)
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := floatAnnotation(md, "gamma", 0.5)
+	if err != nil {
+		return nil, err
+	}
+	period, err := uintAnnotation(md, "period", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	algName, _ := Annotation(md, "algorithm")
+	alg, cfg, err := newForecaster(algName, alpha, beta, gamma, period)
+	if err != nil {
+		return nil, err
+	}
+
+	df, err := loadSideload(ctx, req, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	sf, err := seriesFloat64(ctx, df, column)
+	if err != nil {
+		return nil, err
+	}
+
+	lg.Start("forecast")
+	pred, _, _, err := forecast.Forecast(ctx, sf, nil, alg, cfg, n, nil)
+	if err != nil {
+		lg.Fail("forecast", err)
+		return nil, err
+	}
+	predicted := pred.(*dataframe.SeriesFloat64)
+	lg.Printf("forecast", "%v", predicted.Values)
+	lg.Done("forecast")
+
+	return []*v1.EngineResult{
+		{
+			Type:        "forecast",
+			Payload:     fmt.Sprint(predicted.Values),
+			Description: fmt.Sprintf("%d forecasted values of %s", n, column),
+		},
+	}, nil
+}
+
+// newForecaster returns the forecasting algorithm named alg together with its configuration.
+func newForecaster(alg string, alpha, beta, gamma float64, period uint) (forecast.ForecastingAlgorithm, interface{}, error) {
+	switch alg {
+	case "", "ses":
+		return ses.NewExponentialSmoothing(), ses.ExponentialSmoothingConfig{Alpha: alpha}, nil
+	case "hw":
+		return hw.NewHoltWinters(), hw.HoltWintersConfig{Alpha: alpha, Beta: beta, Gamma: gamma, Period: period}, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown forecasting algorithm: %q", alg)
+}
+
+// seriesFloat64 returns the column of df as a SeriesFloat64, converting it if required.
+func seriesFloat64(ctx context.Context, df *dataframe.DataFrame, column string) (*dataframe.SeriesFloat64, error) {
+	col, err := df.NameToColumn(column)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, column)
+	}
+
+	switch s := df.Series[col].(type) {
+	case *dataframe.SeriesFloat64:
+		return s, nil
+	case dataframe.ToSeriesFloat64:
+		return s.ToSeriesFloat64(ctx, false)
+	}
+
+	return nil, fmt.Errorf("column %s can not be converted to float64", column)
+}
+
+func floatAnnotation(md *v1.EngineMetadata, key string, def float64) (float64, error) {
+	v, exists := Annotation(md, key)
+	if !exists {
+		return def, nil
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("annotation %s: %w", key, err)
+	}
+	return f, nil
+}
+
+func uintAnnotation(md *v1.EngineMetadata, key string, def uint) (uint, error) {
+	v, exists := Annotation(md, key)
+	if !exists {
+		return def, nil
+	}
+
+	u, err := strconv.ParseUint(v, 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("annotation %s: %w", key, err)
+	}
+	return uint(u), nil
+}
@@ -0,0 +1,170 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+)
+
+// MatchesFilter returns true if s matches the filter. All expressions must match,
+// while a single expression matches if any of its terms match. An empty filter
+// matches everything.
+//
+// Supported fields are: name, owner, phase, trigger, success, spec,
+// repo.host, repo.owner, repo.repo, repo.ref, repo.revision and
+// annotation.<key>. Phases and triggers are given by their short
+// lower-case names, e.g. "running" or "manual".
+func MatchesFilter(s *v1.EngineStatus, filter []*v1.FilterExpression) bool {
+	for _, expr := range filter {
+		if len(expr.Terms) == 0 {
+			continue
+		}
+
+		var matched bool
+		for _, term := range expr.Terms {
+			if matchesTerm(s, term) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesTerm(s *v1.EngineStatus, term *v1.FilterTerm) bool {
+	val, exists := fieldValue(s, term.Field)
+
+	var res bool
+	switch term.Operation {
+	case v1.FilterOp_OP_EQUALS:
+		res = exists && val == term.Value
+	case v1.FilterOp_OP_STARTS_WITH:
+		res = exists && strings.HasPrefix(val, term.Value)
+	case v1.FilterOp_OP_ENDS_WITH:
+		res = exists && strings.HasSuffix(val, term.Value)
+	case v1.FilterOp_OP_CONTAINS:
+		res = exists && strings.Contains(val, term.Value)
+	case v1.FilterOp_OP_EXISTS:
+		res = exists
+	}
+
+	if term.Negate {
+		return !res
+	}
+	return res
+}
+
+// fieldValue returns the string value of a field of s and whether the field is set.
+func fieldValue(s *v1.EngineStatus, field string) (string, bool) {
+	md := s.Metadata
+	if md == nil {
+		md = &v1.EngineMetadata{}
+	}
+	repo := md.Repository
+	if repo == nil {
+		repo = &v1.Repository{}
+	}
+
+	var val string
+	switch field {
+	case "name":
+		val = s.Name
+	case "owner":
+		val = md.Owner
+	case "phase":
+		return phaseName(s.Phase), true
+	case "trigger":
+		return strings.ToLower(strings.TrimPrefix(md.Trigger.String(), "TRIGGER_")), true
+	case "success":
+		return strconv.FormatBool(s.Conditions.GetSuccess()), true
+	case "spec":
+		val = md.EngineSpecName
+	case "repo.host":
+		val = repo.Host
+	case "repo.owner":
+		val = repo.Owner
+	case "repo.repo":
+		val = repo.Repo
+	case "repo.ref":
+		val = repo.Ref
+	case "repo.revision":
+		val = repo.Revision
+	default:
+		if key := strings.TrimPrefix(field, "annotation."); key != field {
+			for _, a := range md.Annotations {
+				if a.Key == key {
+					return a.Value, true
+				}
+			}
+		}
+		return "", false
+	}
+
+	return val, val != ""
+}
+
+// isLess reports whether a sorts before b according to order.
+// Unknown fields are ignored.
+func isLess(a, b *v1.EngineStatus, order []*v1.OrderExpression) bool {
+	for _, o := range order {
+		var c int
+		switch o.Field {
+		case "created":
+			c = compareTime(a.Metadata.GetCreated().AsTime(), b.Metadata.GetCreated().AsTime())
+		case "finished":
+			c = compareTime(a.Metadata.GetFinished().AsTime(), b.Metadata.GetFinished().AsTime())
+		case "phase":
+			c = int(a.Phase) - int(b.Phase)
+		default:
+			av, _ := fieldValue(a, o.Field)
+			bv, _ := fieldValue(b, o.Field)
+			c = strings.Compare(av, bv)
+		}
+
+		if c == 0 {
+			continue
+		}
+		if o.Ascending {
+			return c < 0
+		}
+		return c > 0
+	}
+
+	return false
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,108 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+)
+
+// Log collects the log output of a single Engine. The output is grouped into
+// named slices, each of which is started, written to and then marked as done
+// or failed.
+type Log struct {
+	j *job
+}
+
+// Start opens a new slice.
+func (l *Log) Start(slice string) {
+	l.emit(slice, v1.LogSliceType_SLICE_START, "")
+}
+
+// Printf writes a formatted message to a slice. Multi-line messages
+// are split into one event per line.
+func (l *Log) Printf(slice string, format string, args ...interface{}) {
+	msg := strings.TrimRight(fmt.Sprintf(format, args...), "\n")
+
+	for _, line := range strings.Split(msg, "\n") {
+		l.emit(slice, v1.LogSliceType_SLICE_CONTENT, line)
+	}
+}
+
+// Done marks a slice as successfully completed.
+func (l *Log) Done(slice string) {
+	l.emit(slice, v1.LogSliceType_SLICE_DONE, "")
+}
+
+// Fail marks a slice as failed.
+func (l *Log) Fail(slice string, err error) {
+	l.emit(slice, v1.LogSliceType_SLICE_FAIL, err.Error())
+}
+
+func (l *Log) emit(slice string, typ v1.LogSliceType, payload string) {
+	if l == nil || l.j == nil {
+		return
+	}
+
+	l.j.appendLog(&v1.LogSliceEvent{
+		Name:    slice,
+		Type:    typ,
+		Payload: payload,
+	})
+}
+
+// renderSlice converts a stored log event into the representation requested by mode:
+//
+//	LOGS_UNSLICED: the event as it was recorded.
+//	LOGS_RAW: a single content line of the form "[slice|TYPE] payload".
+//	LOGS_HTML: the event with its payload HTML-escaped.
+func renderSlice(ev *v1.LogSliceEvent, mode v1.ListenRequestLogs) *v1.LogSliceEvent {
+	switch mode {
+	case v1.ListenRequestLogs_LOGS_RAW:
+		var prefix string
+		if ev.Type == v1.LogSliceType_SLICE_CONTENT {
+			prefix = "[" + ev.Name + "]"
+		} else {
+			prefix = "[" + ev.Name + "|" + strings.TrimPrefix(ev.Type.String(), "SLICE_") + "]"
+		}
+		return &v1.LogSliceEvent{
+			Name:    ev.Name,
+			Type:    v1.LogSliceType_SLICE_CONTENT,
+			Payload: strings.TrimSpace(prefix + " " + ev.Payload),
+		}
+	case v1.ListenRequestLogs_LOGS_HTML:
+		return &v1.LogSliceEvent{
+			Name:    ev.Name,
+			Type:    ev.Type,
+			Payload: html.EscapeString(ev.Payload),
+		}
+	default:
+		return ev
+	}
+}
+
+// phaseName returns the short, lower-case name of a phase, e.g. "running".
+func phaseName(p v1.EnginePhase) string {
+	return strings.ToLower(strings.TrimPrefix(p.String(), "PHASE_"))
+}
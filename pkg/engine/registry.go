@@ -0,0 +1,435 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrNotFound signifies that no Engine with the requested name is known.
+var ErrNotFound = errors.New("engine not found")
+
+// ErrCannotReplay signifies that an Engine does not have the can-replay condition set.
+var ErrCannotReplay = errors.New("engine cannot be replayed")
+
+// subscriberBuffer is the number of status updates a subscriber may lag behind
+// before further updates are dropped.
+const subscriberBuffer = 64
+
+// Registry keeps track of all Engine(s) started in this process and runs
+// them on an Executor. It is safe for concurrent use.
+type Registry struct {
+	exec Executor
+
+	mu      sync.RWMutex
+	engines map[string]*job
+	counter map[string]int
+	subs    map[chan *v1.EngineStatus][]*v1.FilterExpression
+}
+
+// NewRegistry creates a new Registry which runs its Engine(s) on exec.
+func NewRegistry(exec Executor) *Registry {
+	return &Registry{
+		exec:    exec,
+		engines: map[string]*job{},
+		counter: map[string]int{},
+		subs:    map[chan *v1.EngineStatus][]*v1.FilterExpression{},
+	}
+}
+
+// job is a single Engine known to the Registry.
+type job struct {
+	req    *v1.StartEngineRequest
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	status  *v1.EngineStatus
+	logs    []*v1.LogSliceEvent
+	rev     int
+	done    bool
+	changed chan struct{} // closed and replaced whenever status or logs change
+}
+
+// snapshot returns a copy of the current status.
+func (j *job) snapshot() *v1.EngineStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return proto.Clone(j.status).(*v1.EngineStatus)
+}
+
+// broadcast wakes up all listeners. The lock must be held.
+func (j *job) broadcast() {
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// appendLog adds events to the log of the job.
+func (j *job) appendLog(evs ...*v1.LogSliceEvent) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.logs = append(j.logs, evs...)
+	j.broadcast()
+}
+
+// Start registers a new Engine and runs it in the background.
+func (r *Registry) Start(req *v1.StartEngineRequest) (*v1.EngineStatus, error) {
+	if req == nil || req.Metadata == nil {
+		return nil, errors.New("metadata is required")
+	}
+
+	req = proto.Clone(req).(*v1.StartEngineRequest)
+	if req.Metadata.Created == nil {
+		req.Metadata.Created = timestamppb.Now()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{
+		req:     req,
+		cancel:  cancel,
+		changed: make(chan struct{}),
+	}
+
+	r.mu.Lock()
+	name := r.newName(req)
+	j.status = &v1.EngineStatus{
+		Name:     name,
+		Metadata: proto.Clone(req.Metadata).(*v1.EngineMetadata),
+		Phase:    v1.EnginePhase_PHASE_PREPARING,
+		Conditions: &v1.EngineConditions{
+			CanReplay: true,
+			WaitUntil: req.WaitUntil,
+		},
+	}
+	r.engines[name] = j
+	r.mu.Unlock()
+
+	status := j.snapshot()
+	r.notify(status)
+
+	go r.run(ctx, j)
+
+	return status, nil
+}
+
+// newName produces a unique Engine name. The lock must be held.
+func (r *Registry) newName(req *v1.StartEngineRequest) string {
+	base := req.Metadata.EngineSpecName
+	if base == "" {
+		base = "engine"
+	}
+
+	r.counter[base]++
+	name := fmt.Sprintf("%s.%d", base, r.counter[base])
+	if req.NameSuffix != "" {
+		name += "." + req.NameSuffix
+	}
+
+	return name
+}
+
+// Replay starts a new Engine using the same request as a previous one.
+func (r *Registry) Replay(name string, waitUntil *timestamppb.Timestamp) (*v1.EngineStatus, error) {
+	r.mu.RLock()
+	j, ok := r.engines[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	j.mu.Lock()
+	canReplay := j.status.Conditions.CanReplay
+	req := proto.Clone(j.req).(*v1.StartEngineRequest)
+	j.mu.Unlock()
+
+	if !canReplay {
+		return nil, ErrCannotReplay
+	}
+
+	req.Metadata.Created = nil
+	req.Metadata.Finished = nil
+	req.Metadata.Trigger = v1.EngineTrigger_TRIGGER_MANUAL
+	req.WaitUntil = waitUntil
+
+	return r.Start(req)
+}
+
+// run drives a job through its phases.
+func (r *Registry) run(ctx context.Context, j *job) {
+	defer j.cancel()
+
+	lg := &Log{j: j}
+
+	if wu := j.req.WaitUntil; wu != nil && time.Until(wu.AsTime()) > 0 {
+		r.setPhase(j, v1.EnginePhase_PHASE_WAITING, nil)
+
+		t := time.NewTimer(time.Until(wu.AsTime()))
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			r.finish(j, nil, ctx.Err(), false)
+			return
+		}
+	}
+
+	r.setPhase(j, v1.EnginePhase_PHASE_STARTING, nil)
+	r.setPhase(j, v1.EnginePhase_PHASE_RUNNING, func(s *v1.EngineStatus) {
+		s.Conditions.DidExecute = true
+	})
+
+	results, err := r.execute(ctx, j, lg)
+	r.finish(j, results, err, true)
+}
+
+// execute runs the job on the executor, recovering from panics
+// raised by the underlying packages.
+func (r *Registry) execute(ctx context.Context, j *job, lg *Log) (results []*v1.EngineResult, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("engine panicked: %v", rec)
+		}
+	}()
+
+	return r.exec.Execute(ctx, j.req, lg)
+}
+
+// finish moves a job through cleanup into the done phase.
+func (r *Registry) finish(j *job, results []*v1.EngineResult, err error, executed bool) {
+	r.setPhase(j, v1.EnginePhase_PHASE_CLEANUP, nil)
+
+	for _, res := range results {
+		j.appendLog(&v1.LogSliceEvent{
+			Name:    res.Type,
+			Type:    v1.LogSliceType_SLICE_RESULT,
+			Payload: res.Payload,
+		})
+	}
+
+	r.setPhase(j, v1.EnginePhase_PHASE_DONE, func(s *v1.EngineStatus) {
+		s.Metadata.Finished = timestamppb.Now()
+		s.Results = results
+		s.Conditions.Success = err == nil
+		if err != nil {
+			s.Conditions.FailureCount++
+			if errors.Is(err, context.Canceled) {
+				s.Details = "stopped"
+			} else {
+				s.Details = err.Error()
+			}
+		}
+		if !executed {
+			s.Conditions.DidExecute = false
+		}
+	})
+}
+
+// setPhase updates the phase of a job and notifies listeners and subscribers.
+// mod can be used to make further changes to the status.
+func (r *Registry) setPhase(j *job, phase v1.EnginePhase, mod func(s *v1.EngineStatus)) {
+	j.mu.Lock()
+	j.status.Phase = phase
+	if mod != nil {
+		mod(j.status)
+	}
+	j.logs = append(j.logs, &v1.LogSliceEvent{
+		Type:    v1.LogSliceType_SLICE_PHASE,
+		Payload: phaseName(phase),
+	})
+	j.rev++
+	if phase == v1.EnginePhase_PHASE_DONE {
+		j.done = true
+	}
+	j.broadcast()
+	status := proto.Clone(j.status).(*v1.EngineStatus)
+	j.mu.Unlock()
+
+	log.WithField("name", status.Name).WithField("phase", phaseName(phase)).Debug("engine phase changed")
+	r.notify(status)
+}
+
+// Get returns the status of a single Engine.
+func (r *Registry) Get(name string) (*v1.EngineStatus, error) {
+	r.mu.RLock()
+	j, ok := r.engines[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return j.snapshot(), nil
+}
+
+// Stop cancels an Engine. Stopping an Engine which is already done has no effect.
+func (r *Registry) Stop(name string) error {
+	r.mu.RLock()
+	j, ok := r.engines[name]
+	r.mu.RUnlock()
+	if !ok {
+		return ErrNotFound
+	}
+
+	j.cancel()
+	return nil
+}
+
+// List returns all Engine(s) matching filter, sorted by order. If order is empty, the most recently
+// created Engine(s) are returned first. start and limit select a page of the results; a limit of 0
+// returns all remaining results. The total number of matching Engine(s) is also returned.
+func (r *Registry) List(filter []*v1.FilterExpression, order []*v1.OrderExpression, start, limit int) ([]*v1.EngineStatus, int) {
+	r.mu.RLock()
+	jobs := make([]*job, 0, len(r.engines))
+	for _, j := range r.engines {
+		jobs = append(jobs, j)
+	}
+	r.mu.RUnlock()
+
+	res := []*v1.EngineStatus{}
+	for _, j := range jobs {
+		s := j.snapshot()
+		if MatchesFilter(s, filter) {
+			res = append(res, s)
+		}
+	}
+
+	if len(order) == 0 {
+		order = []*v1.OrderExpression{{Field: "created", Ascending: false}}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return isLess(res[i], res[j], order)
+	})
+
+	total := len(res)
+	if start >= total {
+		return []*v1.EngineStatus{}, total
+	}
+	res = res[start:]
+	if limit > 0 && limit < len(res) {
+		res = res[:limit]
+	}
+
+	return res, total
+}
+
+// Subscribe returns a channel that receives the status of every Engine matching filter
+// whenever it changes. Calling the returned function ends the subscription.
+//
+// NOTE: Updates are dropped for subscribers that do not keep up.
+func (r *Registry) Subscribe(filter []*v1.FilterExpression) (<-chan *v1.EngineStatus, func()) {
+	ch := make(chan *v1.EngineStatus, subscriberBuffer)
+
+	r.mu.Lock()
+	r.subs[ch] = filter
+	r.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			r.mu.Lock()
+			delete(r.subs, ch)
+			r.mu.Unlock()
+		})
+	}
+}
+
+// notify sends a status update to all matching subscribers.
+func (r *Registry) notify(s *v1.EngineStatus) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for ch, filter := range r.subs {
+		if !MatchesFilter(s, filter) {
+			continue
+		}
+		select {
+		case ch <- s:
+		default:
+			log.WithField("name", s.Name).Warn("subscriber too slow: dropping engine update")
+		}
+	}
+}
+
+// Listen streams the updates and log output of an Engine to send until the Engine is done,
+// send returns an error or ctx is canceled. The log events already produced are replayed first.
+func (r *Registry) Listen(ctx context.Context, name string, updates bool, logs v1.ListenRequestLogs, send func(*v1.ListenResponse) error) error {
+	r.mu.RLock()
+	j, ok := r.engines[name]
+	r.mu.RUnlock()
+	if !ok {
+		return ErrNotFound
+	}
+
+	var (
+		idx     int
+		seenRev = -1
+	)
+
+	for {
+		j.mu.Lock()
+		evs := j.logs[idx:]
+		idx = len(j.logs)
+		var status *v1.EngineStatus
+		if updates && j.rev != seenRev {
+			status = proto.Clone(j.status).(*v1.EngineStatus)
+			seenRev = j.rev
+		}
+		done := j.done
+		changed := j.changed
+		j.mu.Unlock()
+
+		if status != nil {
+			err := send(&v1.ListenResponse{Content: &v1.ListenResponse_Update{Update: status}})
+			if err != nil {
+				return err
+			}
+		}
+
+		if logs != v1.ListenRequestLogs_LOGS_DISABLED {
+			for _, ev := range evs {
+				err := send(&v1.ListenResponse{Content: &v1.ListenResponse_Slice{Slice: renderSlice(ev, logs)}})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
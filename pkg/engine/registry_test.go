@@ -0,0 +1,253 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+)
+
+type funcExecutor JobFunc
+
+func (f funcExecutor) Execute(ctx context.Context, req *v1.StartEngineRequest, lg *Log) ([]*v1.EngineResult, error) {
+	return f(ctx, req, lg)
+}
+
+func waitDone(t *testing.T, r *Registry, name string) *v1.EngineStatus {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := r.Listen(ctx, name, false, v1.ListenRequestLogs_LOGS_DISABLED, func(*v1.ListenResponse) error { return nil })
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	s, err := r.Get(name)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	return s
+}
+
+func TestRegistryPhases(t *testing.T) {
+	r := NewRegistry(funcExecutor(func(ctx context.Context, req *v1.StartEngineRequest, lg *Log) ([]*v1.EngineResult, error) {
+		lg.Start("work")
+		lg.Printf("work", "line 1\nline <2>")
+		lg.Done("work")
+		return []*v1.EngineResult{{Type: "answer", Payload: "42"}}, nil
+	}))
+
+	s, err := r.Start(&v1.StartEngineRequest{Metadata: &v1.EngineMetadata{Owner: "test", EngineSpecName: "demo"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != "demo.1" {
+		t.Errorf("unexpected name: %s", s.Name)
+	}
+
+	var (
+		phases []v1.EnginePhase
+		lines  []string
+	)
+	err = r.Listen(context.Background(), s.Name, true, v1.ListenRequestLogs_LOGS_RAW, func(resp *v1.ListenResponse) error {
+		switch c := resp.Content.(type) {
+		case *v1.ListenResponse_Update:
+			phases = append(phases, c.Update.Phase)
+		case *v1.ListenResponse_Slice:
+			lines = append(lines, c.Slice.Payload)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if phases[len(phases)-1] != v1.EnginePhase_PHASE_DONE {
+		t.Errorf("last phase is %v", phases[len(phases)-1])
+	}
+
+	log := strings.Join(lines, "\n")
+	for _, exp := range []string{"[|PHASE] running", "[work|START]", "[work] line 1", "[work] line <2>", "[work|DONE]", "[answer|RESULT] 42", "[|PHASE] done"} {
+		if !strings.Contains(log, exp) {
+			t.Errorf("log does not contain %q:\n%s", exp, log)
+		}
+	}
+
+	s = waitDone(t, r, s.Name)
+	if !s.Conditions.Success || !s.Conditions.DidExecute || len(s.Results) != 1 || s.Metadata.Finished == nil {
+		t.Errorf("unexpected status: %v", s)
+	}
+}
+
+func TestRegistryFailure(t *testing.T) {
+	r := NewRegistry(funcExecutor(func(ctx context.Context, req *v1.StartEngineRequest, lg *Log) ([]*v1.EngineResult, error) {
+		return nil, errors.New("boom")
+	}))
+
+	s, _ := r.Start(&v1.StartEngineRequest{Metadata: &v1.EngineMetadata{}})
+	s = waitDone(t, r, s.Name)
+
+	if s.Conditions.Success || s.Conditions.FailureCount != 1 || s.Details != "boom" {
+		t.Errorf("unexpected status: %v", s)
+	}
+}
+
+func TestRegistryStop(t *testing.T) {
+	r := NewRegistry(funcExecutor(func(ctx context.Context, req *v1.StartEngineRequest, lg *Log) ([]*v1.EngineResult, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+
+	s, _ := r.Start(&v1.StartEngineRequest{Metadata: &v1.EngineMetadata{}})
+	if err := r.Stop(s.Name); err != nil {
+		t.Fatal(err)
+	}
+	s = waitDone(t, r, s.Name)
+
+	if s.Conditions.Success || s.Details != "stopped" {
+		t.Errorf("unexpected status: %v", s)
+	}
+
+	if err := r.Stop("unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRegistryList(t *testing.T) {
+	r := NewRegistry(funcExecutor(func(ctx context.Context, req *v1.StartEngineRequest, lg *Log) ([]*v1.EngineResult, error) {
+		return nil, nil
+	}))
+
+	for _, owner := range []string{"alice", "bob", "alice", "carol"} {
+		s, _ := r.Start(&v1.StartEngineRequest{Metadata: &v1.EngineMetadata{Owner: owner, EngineSpecName: "job"}})
+		waitDone(t, r, s.Name)
+	}
+
+	tests := []struct {
+		name   string
+		filter []*v1.FilterExpression
+		order  []*v1.OrderExpression
+		start  int
+		limit  int
+		total  int
+		names  []string
+	}{
+		{
+			name:  "all",
+			order: []*v1.OrderExpression{{Field: "name", Ascending: true}},
+			total: 4,
+			names: []string{"job.1", "job.2", "job.3", "job.4"},
+		},
+		{
+			name:   "equals",
+			filter: []*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "owner", Value: "alice"}}}},
+			order:  []*v1.OrderExpression{{Field: "name", Ascending: false}},
+			total:  2,
+			names:  []string{"job.3", "job.1"},
+		},
+		{
+			name: "or within expression",
+			filter: []*v1.FilterExpression{{Terms: []*v1.FilterTerm{
+				{Field: "owner", Value: "bob"},
+				{Field: "owner", Value: "carol"},
+			}}},
+			order: []*v1.OrderExpression{{Field: "name", Ascending: true}},
+			total: 2,
+			names: []string{"job.2", "job.4"},
+		},
+		{
+			name: "negated and paged",
+			filter: []*v1.FilterExpression{
+				{Terms: []*v1.FilterTerm{{Field: "owner", Value: "bob", Negate: true}}},
+				{Terms: []*v1.FilterTerm{{Field: "phase", Value: "done"}}},
+			},
+			order: []*v1.OrderExpression{{Field: "name", Ascending: true}},
+			start: 1,
+			limit: 1,
+			total: 3,
+			names: []string{"job.3"},
+		},
+		{
+			name:   "exists",
+			filter: []*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "annotation.x", Operation: v1.FilterOp_OP_EXISTS}}}},
+			total:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		res, total := r.List(tt.filter, tt.order, tt.start, tt.limit)
+		if total != tt.total {
+			t.Errorf("%s: expected total %d, got %d", tt.name, tt.total, total)
+		}
+
+		var names []string
+		for _, s := range res {
+			names = append(names, s.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(tt.names, ",") {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.names, names)
+		}
+	}
+}
+
+func TestStatisticsJob(t *testing.T) {
+	r := NewRegistry(NewLocalExecutor())
+
+	s, _ := r.Start(&v1.StartEngineRequest{
+		Metadata: &v1.EngineMetadata{EngineSpecName: "statistics"},
+		Sideload: []byte("a,b\n1,2\n3,4\n5,6\n"),
+	})
+	s = waitDone(t, r, s.Name)
+
+	if !s.Conditions.Success || len(s.Results) != 1 {
+		t.Fatalf("unexpected status: %v", s)
+	}
+	if !strings.Contains(s.Results[0].Payload, "mean") {
+		t.Errorf("unexpected result: %s", s.Results[0].Payload)
+	}
+}
+
+func TestForecastJob(t *testing.T) {
+	r := NewRegistry(NewLocalExecutor())
+
+	s, _ := r.Start(&v1.StartEngineRequest{
+		Metadata: &v1.EngineMetadata{
+			EngineSpecName: "forecast",
+			Annotations: []*v1.Annotation{
+				{Key: "column", Value: "sales"},
+				{Key: "n", Value: "3"},
+			},
+		},
+		Sideload: []byte("sales\n10\n12\n11\n13\n12\n"),
+	})
+	s = waitDone(t, r, s.Name)
+
+	if !s.Conditions.Success || len(s.Results) != 1 {
+		t.Fatalf("unexpected status: %v", s)
+	}
+}
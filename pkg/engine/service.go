@@ -0,0 +1,150 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Service implements the MathematicsService gRPC API on top of a Registry.
+type Service struct {
+	v1.UnimplementedMathematicsServiceServer
+
+	Engines *Registry
+}
+
+// NewService creates a new Service backed by an in-process Registry
+// which runs its Engine(s) on exec.
+func NewService(exec Executor) *Service {
+	return &Service{
+		Engines: NewRegistry(exec),
+	}
+}
+
+// StartEngine starts a new Engine based on its specification.
+func (srv *Service) StartEngine(ctx context.Context, req *v1.StartEngineRequest) (*v1.StartEngineResponse, error) {
+	if req.Metadata == nil {
+		return nil, status.Error(codes.InvalidArgument, "metadata is required")
+	}
+
+	s, err := srv.Engines.Start(req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &v1.StartEngineResponse{Status: s}, nil
+}
+
+// StartFromPreviousEngine starts a new Engine based on a previous one.
+func (srv *Service) StartFromPreviousEngine(ctx context.Context, req *v1.StartFromPreviousEngineRequest) (*v1.StartEngineResponse, error) {
+	s, err := srv.Engines.Replay(req.PreviousEngine, req.WaitUntil)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &v1.StartEngineResponse{Status: s}, nil
+}
+
+// ListEngines searches for Engine(s) known to this service.
+func (srv *Service) ListEngines(ctx context.Context, req *v1.ListEnginesRequest) (*v1.ListEnginesResponse, error) {
+	if req.Start < 0 || req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "start and limit must not be negative")
+	}
+
+	res, total := srv.Engines.List(req.Filter, req.Order, int(req.Start), int(req.Limit))
+
+	return &v1.ListEnginesResponse{
+		Total:  int32(total),
+		Result: res,
+	}, nil
+}
+
+// Subscribe streams updates of all Engine(s) matching the filter.
+func (srv *Service) Subscribe(req *v1.SubscribeRequest, resp v1.MathematicsService_SubscribeServer) error {
+	updates, unsubscribe := srv.Engines.Subscribe(req.Filter)
+	defer unsubscribe()
+
+	ctx := resp.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case s := <-updates:
+			err := resp.Send(&v1.SubscribeResponse{Result: s})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetEngine retrieves details of a single Engine.
+func (srv *Service) GetEngine(ctx context.Context, req *v1.GetEngineRequest) (*v1.GetEngineResponse, error) {
+	s, err := srv.Engines.Get(req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &v1.GetEngineResponse{Result: s}, nil
+}
+
+// Listen streams the updates and log output of an Engine.
+func (srv *Service) Listen(req *v1.ListenRequest, resp v1.MathematicsService_ListenServer) error {
+	err := srv.Engines.Listen(resp.Context(), req.Name, req.Updates, req.Logs, resp.Send)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	return nil
+}
+
+// StopEngine stops a currently running Engine.
+func (srv *Service) StopEngine(ctx context.Context, req *v1.StopEngineRequest) (*v1.StopEngineResponse, error) {
+	err := srv.Engines.Stop(req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &v1.StopEngineResponse{}, nil
+}
+
+// toStatus converts err into a gRPC status error.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrCannotReplay):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,386 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec describes a declarative analytics pipeline. Data is imported,
+// transformed by a sequence of steps and finally exported.
+//
+// Example:
+//
+//	name: sales-forecast
+//	description: Forecasts the monthly sales
+//	args:
+//	  - name: periods
+//	    description: number of months to forecast
+//	    default: "12"
+//	import:
+//	  format: csv
+//	  path: sales.csv
+//	steps:
+//	  - filter:
+//	      where:
+//	        - {column: region, op: "==", value: north}
+//	  - interpolate: {column: sales, method: linear}
+//	  - forecast:
+//	      column: sales
+//	      alpha: 0.4
+//	      n: ${periods}
+//	export:
+//	  format: csv
+//
+// References of the form ${name} are replaced by the value of the annotation
+// with the same name. Every referenced name must be declared in args.
+type Spec struct {
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description,omitempty"`
+	Args        []SpecArg  `yaml:"args,omitempty"`
+	Import      ImportSpec `yaml:"import"`
+	Steps       []StepSpec `yaml:"steps,omitempty"`
+	Export      ExportSpec `yaml:"export"`
+}
+
+// SpecArg declares an argument of a Spec. Arguments are passed as annotations.
+type SpecArg struct {
+	Name        string `yaml:"name"`
+	Required    bool   `yaml:"required,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Default     string `yaml:"default,omitempty"`
+}
+
+// ImportSpec declares where the data of a Spec comes from.
+type ImportSpec struct {
+
+	// Format is one of csv, json, parquet or sql.
+	Format string `yaml:"format"`
+
+	// Path is the file to import. If not set, the sideload of the Engine is imported.
+	// It is not used by the sql format.
+	Path string `yaml:"path,omitempty"`
+
+	// Comma is the field delimiter of the csv format. The default is ",".
+	Comma string `yaml:"comma,omitempty"`
+
+	// InferTypes sets whether the csv format infers the data types of the columns. The default is true.
+	InferTypes *bool `yaml:"inferTypes,omitempty"`
+
+	// Database is the name of the database used by the sql format. Only the
+	// databases configured in the LocalExecutor can be used.
+	Database string `yaml:"database,omitempty"`
+
+	// Query is the query used by the sql format.
+	Query string `yaml:"query,omitempty"`
+}
+
+// StepSpec is a single step of a Spec. Exactly one of the step kinds must be set.
+type StepSpec struct {
+	Filter      *FilterStep      `yaml:"filter,omitempty"`
+	Evaluate    *EvaluateStep    `yaml:"evaluate,omitempty"`
+	Interpolate *InterpolateStep `yaml:"interpolate,omitempty"`
+	Forecast    *ForecastStep    `yaml:"forecast,omitempty"`
+	Describe    *DescribeStep    `yaml:"describe,omitempty"`
+}
+
+// FilterStep keeps the rows for which all conditions hold.
+type FilterStep struct {
+	Where []Condition `yaml:"where"`
+}
+
+// Condition compares the value of a column. Op is one of ==, !=, <, <=, >, >=, nil or notnil.
+// Numeric columns are compared numerically, all other columns by their string representation.
+type Condition struct {
+	Column string      `yaml:"column"`
+	Op     string      `yaml:"op"`
+	Value  interface{} `yaml:"value,omitempty"`
+}
+
+// EvaluateStep evaluates a function for every row using funcs.Evaluate and stores the result in Column.
+// The float64 columns are available as variables by their names.
+// If Column does not exist, a new float64 column is added.
+type EvaluateStep struct {
+	Column string `yaml:"column"`
+	Fn     string `yaml:"fn"`
+}
+
+// InterpolateStep fills in the nil values of a float64 column, or of all float64 columns if Column is not set.
+type InterpolateStep struct {
+	Column string `yaml:"column,omitempty"`
+
+	// Method is one of forwardfill (default), backwardfill, linear, spline or lagrange.
+	Method string `yaml:"method,omitempty"`
+
+	// Order is required by the spline and lagrange methods.
+	Order int `yaml:"order,omitempty"`
+
+	// Direction is one of forward (default), backward or both.
+	Direction string `yaml:"direction,omitempty"`
+
+	// Limit sets the maximum number of consecutive nil values to fill.
+	Limit *int `yaml:"limit,omitempty"`
+}
+
+// ForecastStep forecasts the next N values of a column. The forecast is reported
+// as a result. If Replace is set, it also replaces the data for the following steps.
+type ForecastStep struct {
+	Column string `yaml:"column"`
+
+	// Algorithm is one of ses (default) or hw.
+	Algorithm string `yaml:"algorithm,omitempty"`
+
+	// N is the number of values to forecast. It must be at least 1.
+	N uint `yaml:"n"`
+
+	// Alpha, Beta and Gamma are the smoothing parameters. As in ForecastJob,
+	// they default to 0.5.
+	Alpha   *float64 `yaml:"alpha,omitempty"`
+	Beta    *float64 `yaml:"beta,omitempty"`
+	Gamma   *float64 `yaml:"gamma,omitempty"`
+	Period  uint     `yaml:"period,omitempty"`
+	Replace bool     `yaml:"replace,omitempty"`
+}
+
+// DescribeStep reports summary statistics using pandas.Describe.
+type DescribeStep struct {
+	Columns     []string  `yaml:"columns,omitempty"`
+	Percentiles []float64 `yaml:"percentiles,omitempty"`
+}
+
+// ExportSpec declares where the data of a Spec goes once all steps are done.
+type ExportSpec struct {
+
+	// Format is one of csv, json, excel or parquet.
+	Format string `yaml:"format"`
+
+	// Path is the file to export to. If not set, the data is reported as a result,
+	// which is only supported by the csv and json formats.
+	Path string `yaml:"path,omitempty"`
+}
+
+var argRef = regexp.MustCompile(`\$\{([A-Za-z0-9_.-]+)\}`)
+
+// ParseSpec parses a Spec and substitutes the argument references using the
+// annotations. It returns an error if a required argument is missing or the
+// Spec is invalid.
+func ParseSpec(engineYAML []byte, annotations []*v1.Annotation) (*Spec, error) {
+	// The references are not necessarily valid YAML before they are substituted,
+	// so they are dropped while the declared args are read.
+	var header struct {
+		Args []SpecArg `yaml:"args"`
+	}
+	err := yaml.Unmarshal(argRef.ReplaceAll(engineYAML, nil), &header)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse engine spec: %w", err)
+	}
+
+	args, err := resolveArgs(header.Args, annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	// The references are replaced by placeholders and the values are only
+	// substituted into the scalars of the parsed Spec, so that they cannot
+	// change its structure.
+	prefix := "__arg"
+	for bytes.Contains(engineYAML, []byte(prefix)) {
+		prefix += "_"
+	}
+	var missing, values []string
+	marked := argRef.ReplaceAllFunc(engineYAML, func(ref []byte) []byte {
+		name := string(argRef.FindSubmatch(ref)[1])
+		val, exists := args[name]
+		if !exists {
+			missing = append(missing, name)
+		}
+		values = append(values, val)
+		return []byte(fmt.Sprintf("%s%d__", prefix, len(values)-1))
+	})
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("engine spec references undeclared args: %s", strings.Join(missing, ", "))
+	}
+
+	var doc yaml.Node
+	err = yaml.Unmarshal(marked, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse engine spec: %w", err)
+	}
+	var rendered []byte
+	if doc.Kind != 0 {
+		substituteArgs(&doc, regexp.MustCompile(regexp.QuoteMeta(prefix)+`(\d+)__`), values)
+		rendered, err = yaml.Marshal(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse engine spec: %w", err)
+		}
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(rendered))
+	dec.KnownFields(true)
+
+	var spec Spec
+	err = dec.Decode(&spec)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse engine spec: %w", err)
+	}
+
+	err = spec.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &spec, nil
+}
+
+// substituteArgs replaces the placeholders in the scalars of n by the values
+// of the arguments. A plain scalar takes the type of its value, e.g. an
+// integer, while a quoted one stays a string.
+func substituteArgs(n *yaml.Node, placeholder *regexp.Regexp, values []string) {
+	if n.Kind == yaml.ScalarNode && placeholder.MatchString(n.Value) {
+		n.Value = placeholder.ReplaceAllStringFunc(n.Value, func(ref string) string {
+			i, _ := strconv.Atoi(placeholder.FindStringSubmatch(ref)[1])
+			return values[i]
+		})
+		if n.Style == 0 {
+			n.Tag = ""
+		}
+	}
+	for _, c := range n.Content {
+		substituteArgs(c, placeholder, values)
+	}
+}
+
+// resolveArgs returns the value of each declared argument.
+func resolveArgs(declared []SpecArg, annotations []*v1.Annotation) (map[string]string, error) {
+	args := map[string]string{}
+	var missing []string
+
+	for _, arg := range declared {
+		val, exists := Annotation(&v1.EngineMetadata{Annotations: annotations}, arg.Name)
+		if !exists {
+			if arg.Required {
+				missing = append(missing, arg.Name)
+				continue
+			}
+			val = arg.Default
+		}
+		args[arg.Name] = val
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required args: %s", strings.Join(missing, ", "))
+	}
+
+	return args, nil
+}
+
+// Validate checks if the Spec is well-formed.
+func (s *Spec) Validate() error {
+	names := map[string]struct{}{}
+	for _, arg := range s.Args {
+		if arg.Name == "" {
+			return errors.New("args must have a name")
+		}
+		if _, exists := names[arg.Name]; exists {
+			return fmt.Errorf("arg %s is declared twice", arg.Name)
+		}
+		names[arg.Name] = struct{}{}
+	}
+
+	switch s.Import.Format {
+	case "csv", "json", "parquet":
+	case "sql":
+		if s.Import.Database == "" || s.Import.Query == "" {
+			return errors.New("import: sql requires database and query")
+		}
+	default:
+		return fmt.Errorf("import: unknown format %q", s.Import.Format)
+	}
+
+	for i, step := range s.Steps {
+		if _, err := step.kind(); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+		if step.Forecast != nil && step.Forecast.N < 1 {
+			return fmt.Errorf("step %d: forecast: n must be at least 1", i+1)
+		}
+	}
+
+	switch s.Export.Format {
+	case "csv", "json":
+	case "excel", "parquet":
+		if s.Export.Path == "" {
+			return fmt.Errorf("export: %s requires a path", s.Export.Format)
+		}
+	default:
+		return fmt.Errorf("export: unknown format %q", s.Export.Format)
+	}
+
+	return nil
+}
+
+// kind returns the name of the step kind that is set.
+func (s StepSpec) kind() (string, error) {
+	var kinds []string
+	if s.Filter != nil {
+		kinds = append(kinds, "filter")
+	}
+	if s.Evaluate != nil {
+		kinds = append(kinds, "evaluate")
+	}
+	if s.Interpolate != nil {
+		kinds = append(kinds, "interpolate")
+	}
+	if s.Forecast != nil {
+		kinds = append(kinds, "forecast")
+	}
+	if s.Describe != nil {
+		kinds = append(kinds, "describe")
+	}
+
+	if len(kinds) != 1 {
+		return "", fmt.Errorf("exactly one of filter, evaluate, interpolate, forecast or describe must be set (found %d)", len(kinds))
+	}
+
+	return kinds[0], nil
+}
+
+// Arguments returns the arguments of the Spec as they are advertised to the user interface.
+func (s *Spec) Arguments() []*v1.DesiredAnnotation {
+	res := make([]*v1.DesiredAnnotation, 0, len(s.Args))
+	for _, arg := range s.Args {
+		res = append(res, &v1.DesiredAnnotation{
+			Name:        arg.Name,
+			Required:    arg.Required,
+			Description: arg.Description,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,467 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+	dataframe "github.com/bhojpur/mathematics/pkg/dataframe"
+	"github.com/bhojpur/mathematics/pkg/dataframe/exports"
+	"github.com/bhojpur/mathematics/pkg/dataframe/forecast"
+	"github.com/bhojpur/mathematics/pkg/dataframe/forecast/interpolation"
+	"github.com/bhojpur/mathematics/pkg/dataframe/funcs"
+	"github.com/bhojpur/mathematics/pkg/dataframe/imports"
+	"github.com/bhojpur/mathematics/pkg/dataframe/pandas"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go-source/local"
+)
+
+// RunSpec executes a Spec. Its paths are relative to the Dir of e and must
+// not leave it; without a Dir, the Spec cannot read or write files. A sql
+// import can only use the Databases of e. The sideload is imported if the
+// Spec does not name an import path.
+func (e *LocalExecutor) RunSpec(ctx context.Context, spec *Spec, sideload []byte, lg *Log) ([]*v1.EngineResult, error) {
+	var results []*v1.EngineResult
+
+	lg.Start("import")
+	df, err := e.importData(ctx, spec.Import, sideload)
+	if err != nil {
+		lg.Fail("import", err)
+		return nil, err
+	}
+	lg.Printf("import", "imported %d rows of %v", df.NRows(), df.Names())
+	lg.Done("import")
+
+	for i, step := range spec.Steps {
+		kind, _ := step.kind()
+		slice := fmt.Sprintf("%d-%s", i+1, kind)
+
+		lg.Start(slice)
+		var res *v1.EngineResult
+		df, res, err = runStep(ctx, df, step)
+		if err != nil {
+			lg.Fail(slice, err)
+			return nil, fmt.Errorf("step %d (%s): %w", i+1, kind, err)
+		}
+		if res != nil {
+			lg.Printf(slice, "%s", res.Payload)
+			results = append(results, res)
+		}
+		lg.Printf(slice, "%d rows remaining", df.NRows())
+		lg.Done(slice)
+	}
+
+	lg.Start("export")
+	res, err := exportData(ctx, spec.Export, e.Dir, df)
+	if err != nil {
+		lg.Fail("export", err)
+		return nil, err
+	}
+	if res != nil {
+		results = append(results, res)
+	} else {
+		lg.Printf("export", "exported to %s", spec.Export.Path)
+	}
+	lg.Done("export")
+
+	return results, nil
+}
+
+// resolvePath resolves p relative to dir. Specs may come from clients, so
+// absolute paths and paths outside of dir are rejected, including paths
+// that leave dir through a symbolic link. A path that does not exist yet
+// is resolved through its parent directory.
+func resolvePath(dir, p string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("cannot access %s: no working directory is set", p)
+	}
+	clean := filepath.Clean(p)
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("cannot access %s: path is outside of the working directory", p)
+	}
+
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("cannot access %s: %w", p, err)
+	}
+	fn := filepath.Join(root, clean)
+	real, err := filepath.EvalSymlinks(fn)
+	if os.IsNotExist(err) {
+		if _, lerr := os.Lstat(fn); lerr == nil {
+			// A dangling symbolic link would be followed when the file is created
+			return "", fmt.Errorf("cannot access %s: %w", p, err)
+		}
+		var parent string
+		parent, err = filepath.EvalSymlinks(filepath.Dir(fn))
+		real = filepath.Join(parent, filepath.Base(fn))
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot access %s: %w", p, err)
+	}
+
+	rel, err := filepath.Rel(root, real)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("cannot access %s: path is outside of the working directory", p)
+	}
+	return real, nil
+}
+
+func (e *LocalExecutor) importData(ctx context.Context, imp ImportSpec, sideload []byte) (*dataframe.DataFrame, error) {
+	if imp.Format == "sql" {
+		database, exists := e.Databases[imp.Database]
+		if !exists {
+			return nil, fmt.Errorf("unknown database %q", imp.Database)
+		}
+		db, err := sql.Open(database.Driver, database.DSN)
+		if err != nil {
+			return nil, err
+		}
+		defer db.Close()
+
+		opts := &imports.SQLLoadOptions{Query: imp.Query}
+		if database.Driver == "mysql" {
+			opts.Database = imports.MySQL
+		}
+		return imports.LoadFromSQL(ctx, db, opts)
+	}
+
+	data := sideload
+	if imp.Path != "" {
+		fn, err := resolvePath(e.Dir, imp.Path)
+		if err != nil {
+			return nil, err
+		}
+		data, err = os.ReadFile(fn)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(data) == 0 {
+		return nil, errors.New("no data to import: set import.path or provide a sideload")
+	}
+
+	switch imp.Format {
+	case "csv":
+		opts := imports.CSVLoadOptions{InferDataTypes: imp.InferTypes == nil || *imp.InferTypes}
+		if imp.Comma != "" {
+			opts.Comma = []rune(imp.Comma)[0]
+		}
+		return imports.LoadFromCSV(ctx, bytes.NewReader(data), opts)
+	case "json":
+		return imports.LoadFromJSON(ctx, bytes.NewReader(data))
+	case "parquet":
+		return imports.LoadFromParquet(ctx, buffer.NewBufferFileFromBytes(data))
+	}
+
+	return nil, fmt.Errorf("unknown import format %q", imp.Format)
+}
+
+func runStep(ctx context.Context, df *dataframe.DataFrame, step StepSpec) (*dataframe.DataFrame, *v1.EngineResult, error) {
+	switch {
+	case step.Filter != nil:
+		df, err := filterStep(ctx, df, step.Filter)
+		return df, nil, err
+	case step.Evaluate != nil:
+		return df, nil, evaluateStep(ctx, df, step.Evaluate)
+	case step.Interpolate != nil:
+		return df, nil, interpolateStep(ctx, df, step.Interpolate)
+	case step.Forecast != nil:
+		return forecastStep(ctx, df, step.Forecast)
+	case step.Describe != nil:
+		res, err := describeStep(ctx, df, step.Describe)
+		return df, res, err
+	}
+
+	return df, nil, errors.New("empty step")
+}
+
+func filterStep(ctx context.Context, df *dataframe.DataFrame, f *FilterStep) (*dataframe.DataFrame, error) {
+	for _, c := range f.Where {
+		if _, err := df.NameToColumn(c.Column); err != nil {
+			return nil, fmt.Errorf("%v: %s", err, c.Column)
+		}
+	}
+
+	fn := func(vals map[interface{}]interface{}, row, nRows int) (dataframe.FilterAction, error) {
+		for _, c := range f.Where {
+			ok, err := c.holds(vals[c.Column])
+			if err != nil {
+				return dataframe.DROP, &dataframe.RowError{Row: row, Err: err}
+			}
+			if !ok {
+				return dataframe.DROP, nil
+			}
+		}
+		return dataframe.KEEP, nil
+	}
+
+	res, err := dataframe.Filter(ctx, df, dataframe.FilterDataFrameFn(fn))
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*dataframe.DataFrame), nil
+}
+
+// holds returns true if the condition holds for val.
+func (c Condition) holds(val interface{}) (bool, error) {
+	switch c.Op {
+	case "nil":
+		return val == nil, nil
+	case "notnil":
+		return val != nil, nil
+	}
+
+	if val == nil {
+		return false, nil
+	}
+
+	var cmp int
+	a, aNum := toFloat64(val)
+	b, bNum := toFloat64(c.Value)
+	if aNum && bNum {
+		switch {
+		case a < b:
+			cmp = -1
+		case a > b:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(fmt.Sprint(val), fmt.Sprint(c.Value))
+	}
+
+	switch c.Op {
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+
+	return false, fmt.Errorf("unknown operator %q", c.Op)
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func evaluateStep(ctx context.Context, df *dataframe.DataFrame, e *EvaluateStep) error {
+	if _, err := df.NameToColumn(e.Column); err != nil {
+		err := df.AddSeries(dataframe.NewSeriesFloat64(e.Column, &dataframe.SeriesInit{Size: df.NRows()}), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return funcs.Evaluate(ctx, df, funcs.RegFunc(e.Fn), e.Column)
+}
+
+func interpolateStep(ctx context.Context, df *dataframe.DataFrame, ip *InterpolateStep) error {
+	opts := interpolation.InterpolateOptions{
+		Limit:   ip.Limit,
+		InPlace: true,
+	}
+
+	switch ip.Method {
+	case "", "forwardfill":
+		opts.Method = interpolation.ForwardFill{}
+	case "backwardfill":
+		opts.Method = interpolation.BackwardFill{}
+	case "linear":
+		opts.Method = interpolation.Linear{}
+	case "spline":
+		opts.Method = interpolation.Spline{Order: ip.Order}
+	case "lagrange":
+		opts.Method = interpolation.Lagrange{Order: ip.Order}
+	default:
+		return fmt.Errorf("unknown interpolation method %q", ip.Method)
+	}
+
+	switch ip.Direction {
+	case "", "forward":
+		opts.FillDirection = interpolation.Forward
+	case "backward":
+		opts.FillDirection = interpolation.Backward
+	case "both":
+		opts.FillDirection = interpolation.Forward | interpolation.Backward
+	default:
+		return fmt.Errorf("unknown fill direction %q", ip.Direction)
+	}
+
+	if ip.Column == "" {
+		_, err := interpolation.Interpolate(ctx, df, opts)
+		return err
+	}
+
+	col, err := df.NameToColumn(ip.Column)
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, ip.Column)
+	}
+	sf, ok := df.Series[col].(*dataframe.SeriesFloat64)
+	if !ok {
+		return fmt.Errorf("column %s must be a float64 column", ip.Column)
+	}
+
+	_, err = interpolation.Interpolate(ctx, sf, opts)
+	return err
+}
+
+// valueOr returns the value of p, or def if p is not set.
+func valueOr(p *float64, def float64) float64 {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+func forecastStep(ctx context.Context, df *dataframe.DataFrame, f *ForecastStep) (*dataframe.DataFrame, *v1.EngineResult, error) {
+	alg, cfg, err := newForecaster(f.Algorithm, valueOr(f.Alpha, 0.5), valueOr(f.Beta, 0.5), valueOr(f.Gamma, 0.5), f.Period)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sf, err := seriesFloat64(ctx, df, f.Column)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pred, _, _, err := forecast.Forecast(ctx, sf, nil, alg, cfg, f.N, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	predicted := pred.(*dataframe.SeriesFloat64)
+
+	res := &v1.EngineResult{
+		Type:        "forecast",
+		Payload:     fmt.Sprint(predicted.Values),
+		Description: fmt.Sprintf("%d forecasted values of %s", f.N, f.Column),
+	}
+
+	if f.Replace {
+		predicted.Rename(f.Column)
+		df = dataframe.NewDataFrame(predicted)
+	}
+
+	return df, res, nil
+}
+
+func describeStep(ctx context.Context, df *dataframe.DataFrame, d *DescribeStep) (*v1.EngineResult, error) {
+	opts := pandas.DescribeOptions{Percentiles: d.Percentiles}
+	for _, c := range d.Columns {
+		opts.Whitelist = append(opts.Whitelist, c)
+	}
+
+	out, err := pandas.Describe(ctx, df, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.EngineResult{
+		Type:        "describe",
+		Payload:     out.String(),
+		Description: "summary statistics",
+	}, nil
+}
+
+func exportData(ctx context.Context, exp ExportSpec, dir string, df *dataframe.DataFrame) (*v1.EngineResult, error) {
+	if exp.Path == "" {
+		var buf bytes.Buffer
+		err := exportTo(ctx, &buf, exp.Format, df)
+		if err != nil {
+			return nil, err
+		}
+
+		return &v1.EngineResult{
+			Type:        "export",
+			Payload:     buf.String(),
+			Description: fmt.Sprintf("%d rows as %s", df.NRows(), exp.Format),
+		}, nil
+	}
+
+	fn, err := resolvePath(dir, exp.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if exp.Format == "parquet" {
+		fw, err := local.NewLocalFileWriter(fn)
+		if err != nil {
+			return nil, err
+		}
+		err = exportTo(ctx, fw, exp.Format, df)
+		if cerr := fw.Close(); err == nil {
+			err = cerr
+		}
+		return nil, err
+	}
+
+	f, err := os.Create(fn)
+	if err != nil {
+		return nil, err
+	}
+	err = exportTo(ctx, f, exp.Format, df)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	return nil, err
+}
+
+func exportTo(ctx context.Context, w io.Writer, format string, df *dataframe.DataFrame) error {
+	switch format {
+	case "csv":
+		return exports.ExportToCSV(ctx, w, df)
+	case "json":
+		return exports.ExportToJSON(ctx, w, df)
+	case "excel":
+		return exports.ExportToExcel(ctx, w, df)
+	case "parquet":
+		return exports.ExportToParquet(ctx, w, df)
+	}
+
+	return fmt.Errorf("unknown export format %q", format)
+}
@@ -0,0 +1,263 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "github.com/bhojpur/mathematics/pkg/api/v1"
+)
+
+const testSpec = `
+name: sales
+args:
+  - name: region
+    required: true
+  - name: limit
+    default: "12"
+import:
+  format: csv
+steps:
+  - filter:
+      where:
+        - {column: region, op: "==", value: "${region}"}
+        - {column: sales, op: "<", value: ${limit}}
+  - evaluate: {column: double, fn: "2*sales"}
+  - describe: {columns: [sales]}
+export:
+  format: csv
+`
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec), []*v1.Annotation{{Key: "region", Value: "north"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	where := spec.Steps[0].Filter.Where
+	if where[0].Value != "north" || where[1].Value != 12 {
+		t.Errorf("unexpected conditions: %v", where)
+	}
+	if args := spec.Arguments(); len(args) != 2 || !args[0].Required {
+		t.Errorf("unexpected arguments: %v", args)
+	}
+}
+
+func TestParseSpecInjection(t *testing.T) {
+	values := []string{
+		`north", path: /etc/passwd, x: "`,
+		"north\"}]\nimport: {format: sql, database: main, query: q}\n#",
+		"1}\n  path: /etc/passwd\n",
+		"'north': \n- x",
+	}
+
+	for i, val := range values {
+		spec, err := ParseSpec([]byte(testSpec), []*v1.Annotation{{Key: "region", Value: val}, {Key: "limit", Value: val}})
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		where := spec.Steps[0].Filter.Where
+		if where[0].Value != val || where[1].Value != val {
+			t.Errorf("%d: unexpected conditions: %v", i, where)
+		}
+		if spec.Import.Format != "csv" || spec.Import.Path != "" || spec.Import.Database != "" {
+			t.Errorf("%d: unexpected import: %+v", i, spec.Import)
+		}
+	}
+}
+
+func TestParseSpecErrors(t *testing.T) {
+	tests := []struct {
+		yaml        string
+		annotations []*v1.Annotation
+		err         string
+	}{
+		{testSpec, nil, "missing required args: region"},
+		{"import: {format: csv, path: ${file}}\nexport: {format: csv}", nil, "undeclared args: file"},
+		{"import: {format: xls}\nexport: {format: csv}", nil, "unknown format"},
+		{"import: {format: sql, query: q}\nexport: {format: csv}", nil, "requires database"},
+		{"import: {format: csv}\nexport: {format: parquet}", nil, "requires a path"},
+		{"import: {format: csv}\nsteps: [{}]\nexport: {format: csv}", nil, "step 1"},
+		{"import: {format: csv}\nsteps: [{forecast: {column: sales, n: 0}}]\nexport: {format: csv}", nil, "n must be at least 1"},
+		{"import: {format: csv}\nexport: {format: csv}\nunknown: 1", nil, "not found"},
+	}
+
+	for i, tc := range tests {
+		_, err := ParseSpec([]byte(tc.yaml), tc.annotations)
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%d: expected error containing %q, got: %v", i, tc.err, err)
+		}
+	}
+}
+
+func TestRunSpec(t *testing.T) {
+	r := NewRegistry(NewLocalExecutor())
+
+	s, _ := r.Start(&v1.StartEngineRequest{
+		Metadata: &v1.EngineMetadata{
+			EngineSpecName: "sales",
+			Annotations:    []*v1.Annotation{{Key: "region", Value: "north"}},
+		},
+		EngineYaml: []byte(testSpec),
+		Sideload:   []byte("region,sales\nnorth,10.5\nsouth,11.5\nnorth,5.5\nnorth,20.5\n"),
+	})
+	s = waitDone(t, r, s.Name)
+
+	if !s.Conditions.Success || len(s.Results) != 2 {
+		t.Fatalf("unexpected status: %v", s)
+	}
+	if s.Results[0].Type != "describe" || !strings.Contains(s.Results[0].Payload, "8") {
+		t.Errorf("unexpected describe result: %s", s.Results[0].Payload)
+	}
+
+	exp := "region,sales,double\nnorth,10.5,21\nnorth,5.5,11\n"
+	if s.Results[1].Type != "export" || s.Results[1].Payload != exp {
+		t.Errorf("unexpected export result: %q", s.Results[1].Payload)
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outside, "passwd"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, link := range [][2]string{
+		{outside, "out"},
+		{filepath.Join(outside, "passwd"), "passwd"},
+		{filepath.Join(outside, "missing"), "dangling"},
+	} {
+		if err := os.Symlink(link[0], filepath.Join(dir, link[1])); err != nil {
+			t.Skip("cannot create symbolic links:", err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "in"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("..", filepath.Join(dir, "in", "up")); err != nil {
+		t.Fatal(err)
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir, path string
+		want      string
+		err       string
+	}{
+		{dir, "sales.csv", filepath.Join(root, "sales.csv"), ""},
+		{dir, "in/../sales.csv", filepath.Join(root, "sales.csv"), ""},
+		{dir, "in/up/sales.csv", filepath.Join(root, "sales.csv"), ""},
+		{dir, "../sales.csv", "", "outside"},
+		{dir, "in/../../../etc/passwd", "", "outside"},
+		{dir, "..", "", "outside"},
+		{dir, "/etc/passwd", "", "outside"},
+		{dir, "out/sales.csv", "", "outside"},
+		{dir, "passwd", "", "outside"},
+		{dir, "dangling", "", "no such file"},
+		{dir, "missing/sales.csv", "", "no such file"},
+		{"", "sales.csv", "", "no working directory"},
+	}
+
+	for i, tc := range tests {
+		got, err := resolvePath(tc.dir, tc.path)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%d: expected error containing %q, got: %v", i, tc.err, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%d: expected %s, got: %s, %v", i, tc.want, got, err)
+		}
+	}
+}
+
+func TestRunSpecFiles(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "in.csv"), []byte("sales\n1\n2\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(dir, yaml string) error {
+		spec, err := ParseSpec([]byte(yaml), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = (&LocalExecutor{Dir: dir}).RunSpec(context.Background(), spec, nil, nil)
+		return err
+	}
+	if err := run(dir, "import: {format: csv, path: in.csv}\nexport: {format: csv, path: out.csv}"); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "out.csv")); err != nil || string(data) != "sales\n1\n2\n" {
+		t.Errorf("unexpected export: %q, %v", data, err)
+	}
+
+	for i, yaml := range []string{
+		"import: {format: csv, path: ../in.csv}\nexport: {format: csv}",
+		"import: {format: csv, path: in.csv}\nexport: {format: csv, path: ../out.csv}",
+	} {
+		if err := run(dir, yaml); err == nil || !strings.Contains(err.Error(), "outside") {
+			t.Errorf("%d: expected error, got: %v", i, err)
+		}
+	}
+	if err := run("", "import: {format: csv, path: in.csv}\nexport: {format: csv}"); err == nil {
+		t.Error("expected error without a working directory")
+	}
+	if err := run(dir, "import: {format: sql, database: main, query: q}\nexport: {format: csv}"); err == nil || !strings.Contains(err.Error(), "unknown database") {
+		t.Errorf("expected error for an unconfigured database, got: %v", err)
+	}
+}
+
+func TestForecastStepDefaults(t *testing.T) {
+	sideload := []byte("sales\n10\n12\n11\n13\n12\n")
+
+	// The forecast step and ForecastJob agree without explicit parameters
+	spec, err := ParseSpec([]byte("import: {format: csv}\nsteps: [{forecast: {column: sales, n: 3}}]\nexport: {format: csv}"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromSpec, err := (&LocalExecutor{}).RunSpec(context.Background(), spec, sideload, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJob, err := ForecastJob(context.Background(), &v1.StartEngineRequest{
+		Metadata: &v1.EngineMetadata{
+			Annotations: []*v1.Annotation{{Key: "column", Value: "sales"}, {Key: "n", Value: "3"}},
+		},
+		Sideload: sideload,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromSpec[0].Payload != fromJob[0].Payload {
+		t.Errorf("forecast step gave %s, ForecastJob gave %s", fromSpec[0].Payload, fromJob[0].Payload)
+	}
+}
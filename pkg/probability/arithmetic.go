@@ -23,47 +23,49 @@ package probability
 type arithmeticOperation struct {
 	a, b    Uncertain
 	i       int
+	op      string
 	combine combineFunc
 }
 
 type combineFunc func(x float64, y float64) float64
 
 func Add(a Uncertain, b Uncertain) Uncertain {
-	return newArithmetic(a, b, func(x, y float64) float64 {
+	return newArithmetic(a, b, "+", func(x, y float64) float64 {
 		return x + y
 	})
 }
 
 func Sub(a Uncertain, b Uncertain) Uncertain {
-	return newArithmetic(a, b, func(x, y float64) float64 {
+	return newArithmetic(a, b, "-", func(x, y float64) float64 {
 		return x - y
 	})
 }
 
 func Mul(a Uncertain, b Uncertain) Uncertain {
-	return newArithmetic(a, b, func(x, y float64) float64 {
+	return newArithmetic(a, b, "*", func(x, y float64) float64 {
 		return x * y
 	})
 }
 
 func Div(a Uncertain, b Uncertain) Uncertain {
-	return newArithmetic(a, b, func(x, y float64) float64 {
+	return newArithmetic(a, b, "/", func(x, y float64) float64 {
 		return x / y
 	})
 }
 
-func newArithmetic(a, b Uncertain, op combineFunc) *arithmeticOperation {
+func newArithmetic(a, b Uncertain, op string, combine combineFunc) *arithmeticOperation {
 	return &arithmeticOperation{
 		a:       a,
 		b:       b,
-		combine: op,
+		op:      op,
+		combine: combine,
 		i:       newID(),
 	}
 }
 
-func (ar *arithmeticOperation) sampleWithTrace() *sample {
-	as := ar.a.sampleWithTrace()
-	bs := ar.b.sampleWithTrace()
+func (ar *arithmeticOperation) sampleWithTrace(r *draw) *sample {
+	as := ar.a.sampleWithTrace(r)
+	bs := ar.b.sampleWithTrace(r)
 	v := ar.combine(as.value, bs.value)
 	s := as.combine(bs)
 	s.value = v
@@ -71,12 +73,16 @@ func (ar *arithmeticOperation) sampleWithTrace() *sample {
 	return s
 }
 
-func (ar *arithmeticOperation) sample() float64 {
-	a := ar.a.sample()
-	b := ar.b.sample()
+func (ar *arithmeticOperation) sample(r *draw) float64 {
+	a := ar.a.sample(r)
+	b := ar.b.sample(r)
 	return ar.combine(a, b)
 }
 
 func (ar *arithmeticOperation) id() int {
 	return ar.i
 }
+
+func (ar *arithmeticOperation) inputs() []Uncertain {
+	return []Uncertain{ar.a, ar.b}
+}
@@ -39,3 +39,16 @@ func TestGaussianMultiply(t *testing.T) {
 		t.Fatalf("True mean above sample mean interval")
 	}
 }
+
+func TestSharedNode(t *testing.T) {
+	// A node reached twice has one value per draw
+	x := NewGaussian(0, 1)
+	for _, v := range sampleValues(Materialize(Sub(x, x), 100, Seed(1))) {
+		if v != 0 {
+			t.Fatalf("x - x gave %f", v)
+		}
+	}
+	if s := Materialize(Add(x, x), 10_000, Seed(1)); !Within(s.Variance(), 4, 0.2) {
+		t.Errorf("Var(x + x) = %f", s.Variance())
+	}
+}
@@ -20,10 +20,7 @@ package probability
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-import (
-	"fmt"
-	"math"
-)
+import "math"
 
 type Bernoulli struct {
 	probability float64
@@ -37,13 +34,27 @@ func Flip(probability float64) *Bernoulli {
 }
 
 func NewBernoulli(probability float64) *Bernoulli {
-	if probability > 1.0 || probability < 0.0 {
-		panic("Trying to create a bernoulli probability outside [0.0, 1.0], got " + fmt.Sprintf("%0.7f", probability))
+	b, err := TryNewBernoulli(probability)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryNewBernoulli is NewBernoulli returning an error for an invalid
+// probability.
+func TryNewBernoulli(probability float64) (*Bernoulli, error) {
+	if err := checkProbability("bernoulli", probability); err != nil {
+		return nil, err
 	}
 	return &Bernoulli{
 		probability: probability,
 		i:           newID(),
-	}
+	}, nil
+}
+
+func (b *Bernoulli) validate() error {
+	return checkProbability("bernoulli", b.probability)
 }
 
 func convertBoolSampleToFloat(b bool) float64 {
@@ -60,99 +71,149 @@ func convertFloatSampleToBool(f float64) bool {
 	return true
 }
 
-func (b *Bernoulli) sample() float64 {
-	if b.sampleBool() {
+func (b *Bernoulli) sample(r *draw) float64 {
+	if b.sampleBool(r) {
 		return 1.0
 	}
 	return 0.0
 }
 
-func (b *Bernoulli) sampleBool() bool {
-	r := randFloat64()
-	if r < b.probability {
-		return true
+func (b *Bernoulli) sampleBool(r *draw) bool {
+	if v, ok := r.lookup(b.i); ok {
+		return convertFloatSampleToBool(v)
 	}
-	return false
+	t := r.Float64() < b.probability
+	r.store(b.i, convertBoolSampleToFloat(t))
+	return t
 }
 
 func (b *Bernoulli) id() int {
 	return b.i
 }
 
-func (b *Bernoulli) sampleWithTrace() *sample {
-	val := b.sample()
+func (b *Bernoulli) sampleWithTrace(r *draw) *sample {
+	val := b.sample(r)
 	s := newSample(val)
 	s.addTrace(b.i, val)
 	return s
 }
 
-func (b *Bernoulli) Pr() bool {
-	return Pr(b)
+func (b *Bernoulli) Pr(opts ...Option) bool {
+	return Pr(b, opts...)
 }
 
-func Pr(b UncertainBool) bool {
-	return ProbTrueAtLeast(b, 0.5)
+func Pr(b UncertainBool, opts ...Option) bool {
+	return ProbTrueAtLeast(b, 0.5, opts...)
 }
 
+// ProbTrueAtLeast tests whether b is true with at least probability prob.
 func ProbTrueAtLeast(b UncertainBool, prob float64, opts ...Option) bool {
-	errorPercent := getPercentError(opts, 0.05)
-	return sequentialProbabilityRatioTest(b, prob, errorPercent, 0.03, opts...)
+	return ProbTrueAtLeastResult(b, prob, opts...).Value
 }
 
-// sequentialProbabilityRatioTest implements
-// https://en.wikipedia.org/wiki/Sequential_probability_ratio_test.
-// prob is the threshhold that this binary random variable has a true
-// probability at least prob.
-// confidence is the p value for how much error we accept (for 95% confidence, this is 5% or 0.05)
-// indifference is the size of the indifference region (where we're not sure)
-func sequentialProbabilityRatioTest(b UncertainBool, prob, confidence, indifference float64, opts ...Option) bool {
-	maxSampleSize := getSampleSize(opts, 10_000)
-	initSampleSize := 10
-	sampleSizeStep := 10
+// PrResult is the outcome of testing whether an UncertainBool is true with
+// at least some probability.
+type PrResult struct {
+	// Value is the answer of the test.
+	Value bool
+	// Decided is false if the SPRT reached the sample size without a
+	// decision. Value is then false.
+	Decided bool
+	// Samples is the number of samples drawn.
+	Samples int
+	// Probability is the fraction of the samples that were true.
+	Probability float64
+}
 
-	nSamples := 0
+// ProbTrueAtLeastResult is ProbTrueAtLeast reporting the number of samples
+// it used. It uses the TestMethod of the options, SPRT by default, and
+// samples on parallel workers with Workers.
+func ProbTrueAtLeastResult(b UncertainBool, prob float64, opts ...Option) PrResult {
+	method := getMethod(opts, SPRT)
+	if getWorkers(opts, 0) > 0 {
+		return parallelProbTrueAtLeast(b, prob, method, opts)
+	}
+	if method == FixedN {
+		return fixedSampleTest(b, prob, opts...)
+	}
+	return sequentialProbabilityRatioTest(b, prob, opts...)
+}
 
-	h0 := prob - indifference
-	h1 := prob + indifference
+// sprtEpsilon keeps the hypotheses of the SPRT inside (0, 1)
+const sprtEpsilon = 1e-9
 
-	alpha := confidence
-	beta := confidence
-	alphaLog := math.Log(beta / (1.0 - alpha))
-	betaLog := math.Log((1.0 - beta) / alpha)
+// sprt holds the log likelihood ratios and the bounds of Wald's sequential
+// probability ratio test.
+type sprt struct {
+	llTrue  float64
+	llFalse float64
+	lower   float64
+	upper   float64
+}
 
-	k := 0
+// newSPRT tests the hypothesis that the probability is at least
+// prob+indifference against the hypothesis that it is at most
+// prob-indifference. It panics if the error rates or the indifference
+// give no meaningful bounds.
+func newSPRT(prob float64, opts []Option) sprt {
+	alpha, beta := getErrorRates(opts, getPercentError(opts, 0.05))
+	if !(alpha > 0 && beta > 0 && alpha+beta < 1) {
+		panic(invalidParameter("error rates of a sequential probability ratio test must be in (0, 1) with a sum below 1, got %0.7f and %0.7f", alpha, beta))
+	}
+	indifference := getIndifference(opts, 0.03)
+	if !(indifference > 0) {
+		panic(invalidParameter("indifference of a sequential probability ratio test must be positive, got %0.7f", indifference))
+	}
 
-	wSum := 0.0
-	wSumTrue := 0.0
+	h0 := math.Max(prob-indifference, sprtEpsilon)
+	h1 := math.Min(prob+indifference, 1-sprtEpsilon)
+	return sprt{
+		llTrue:  math.Log(h1 / h0),
+		llFalse: math.Log((1 - h1) / (1 - h0)),
+		lower:   math.Log(beta / (1 - alpha)),
+		upper:   math.Log((1 - beta) / alpha),
+	}
+}
 
-	for nSamples = 0; nSamples < initSampleSize; nSamples++ {
-		sample := b.sampleBool()
-		if sample {
-			k += 1
-			wSumTrue += 1.0
-		} else {
-		}
-		wSum += 1.0
+// decide returns whether k true samples out of n decide the test, and
+// the decision.
+func (t sprt) decide(k, n int) (bool, bool) {
+	logLikelihood := float64(k)*t.llTrue + float64(n-k)*t.llFalse
+	if logLikelihood >= t.upper {
+		return true, true
 	}
+	if logLikelihood <= t.lower {
+		return true, false
+	}
+	return false, false
+}
 
-	for nSamples <= maxSampleSize {
-		logLikelihood := wSumTrue*math.Log(h1/h0) + (wSum-wSumTrue)*math.Log((1-h1)/(1-h0))
-		if logLikelihood >= betaLog {
-			return true
-		}
-		if logLikelihood <= alphaLog {
-			return false
-		}
+// sequentialProbabilityRatioTest implements
+// https://en.wikipedia.org/wiki/Sequential_probability_ratio_test.
+// prob is the threshhold that this binary random variable has a true
+// probability at least prob.
+func sequentialProbabilityRatioTest(b UncertainBool, prob float64, opts ...Option) PrResult {
+	r := newDraw(newRand(opts))
+	test := newSPRT(prob, opts)
+	maxSampleSize := getSampleSize(opts, 10_000)
+	sampleSizeStep := 10
 
+	k, n := 0, 0
+	for n < maxSampleSize {
 		for i := 0; i < sampleSizeStep; i++ {
-			sample := b.sample()
-			if sample == 1.0 {
-				k += 1
-				wSumTrue += 1.0
+			if b.sampleBool(r.next()) {
+				k++
+			}
+			n++
+		}
+		if decided, value := test.decide(k, n); decided {
+			return PrResult{
+				Value:       value,
+				Decided:     true,
+				Samples:     n,
+				Probability: float64(k) / float64(n),
 			}
-			wSum += 1.0
 		}
-		nSamples += sampleSizeStep
 	}
 
 	// From the original implementation...
@@ -163,5 +224,36 @@ func sequentialProbabilityRatioTest(b UncertainBool, prob, confidence, indiffere
 	// It's an okay assumption, but compared to sample size steps, explaining as a
 	// function input whether I'd like to return a false positive or a false negative
 	// is perhaps more useful. Ultimately, though, this is a TODO.
-	return false
+	return PrResult{
+		Samples:     n,
+		Probability: float64(k) / float64(n),
+	}
+}
+
+// fixedSampleTest compares the fraction of SampleSize samples that are
+// true with prob.
+func fixedSampleTest(b UncertainBool, prob float64, opts ...Option) PrResult {
+	r := newDraw(newRand(opts))
+	n := getSampleSize(opts, 10_000)
+
+	k := 0
+	for i := 0; i < n; i++ {
+		if b.sampleBool(r.next()) {
+			k++
+		}
+	}
+	p := float64(k) / float64(n)
+	return PrResult{
+		Value:       p >= prob,
+		Decided:     true,
+		Samples:     n,
+		Probability: p,
+	}
+}
+
+func (b *Bernoulli) support() interval {
+	return interval{
+		lo: 1 - math.Ceil(1-b.probability),
+		hi: math.Ceil(b.probability),
+	}
 }
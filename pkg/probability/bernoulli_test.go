@@ -20,7 +20,11 @@ package probability
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"testing"
+)
 
 func TestExpectedValueGeneric(t *testing.T) {
 	p := 0.5
@@ -29,3 +33,75 @@ func TestExpectedValueGeneric(t *testing.T) {
 	t.Log(meanCI)
 	Within(meanCI.Mean, p, epsilon)
 }
+
+func TestProbTrueAtLeastResult(t *testing.T) {
+	x := NewBernoulli(0.8)
+
+	// The SPRT stops far earlier than the sample size on a clear answer
+	res := ProbTrueAtLeastResult(x, 0.5, Seed(1))
+	if !res.Value || !res.Decided || res.Samples >= 10_000 || res.Samples%10 != 0 {
+		t.Errorf("SPRT gave %+v", res)
+	}
+	if res := ProbTrueAtLeastResult(x, 0.95, Seed(1)); res.Value || !res.Decided {
+		t.Errorf("SPRT gave %+v for a probability above 0.8", res)
+	}
+
+	// Stricter error rates need more samples
+	strict := ProbTrueAtLeastResult(x, 0.7, Seed(1), ErrorRates(0.001, 0.001))
+	loose := ProbTrueAtLeastResult(x, 0.7, Seed(1), ErrorRates(0.1, 0.1))
+	if !strict.Value || !loose.Value || strict.Samples <= loose.Samples {
+		t.Errorf("Strict test took %d samples, loose test took %d", strict.Samples, loose.Samples)
+	}
+
+	// Without a decision the test gives up at the sample size
+	res = ProbTrueAtLeastResult(x, 0.8, Seed(1), SampleSize(500), Indifference(0.001))
+	if res.Value || res.Decided || res.Samples != 500 {
+		t.Errorf("Undecided SPRT gave %+v", res)
+	}
+
+	res = ProbTrueAtLeastResult(x, 0.5, Seed(1), Method(FixedN), SampleSize(2000))
+	if !res.Value || !res.Decided || res.Samples != 2000 || !Within(res.Probability, 0.8, 0.03) {
+		t.Errorf("Fixed sample test gave %+v", res)
+	}
+
+	res = ProbTrueAtLeastResult(x, 0.5, Seed(1), Workers(4))
+	if !res.Value || !res.Decided || res.Samples != evalBatchSize {
+		t.Errorf("Parallel SPRT gave %+v", res)
+	}
+	res = ProbTrueAtLeastResult(x, 0.5, Seed(1), Workers(4), Method(FixedN), SampleSize(5000))
+	if !res.Value || res.Samples != 5000 {
+		t.Errorf("Parallel fixed sample test gave %+v", res)
+	}
+
+	if !ProbTrueAtLeast(NewBernoulli(1), 1) {
+		t.Error("SPRT failed at a probability of 1")
+	}
+}
+
+func TestProbTrueAtLeastInvalidOptions(t *testing.T) {
+	x := NewBernoulli(0.8)
+	for i, opts := range [][]Option{
+		{ErrorRates(0, 0.05)},
+		{ErrorRates(0.05, 1)},
+		{ErrorRates(0.6, 0.5)},
+		{ErrorRates(math.NaN(), 0.05)},
+		{PercentError(0.5)},
+		{Indifference(0)},
+		{Indifference(-0.1)},
+		{Indifference(0), Workers(2)},
+	} {
+		func() {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrInvalidParameter) {
+					t.Errorf("%d: SPRT panicked with %v", i, err)
+				}
+			}()
+			ProbTrueAtLeastResult(x, 0.5, append(opts, Seed(1))...)
+		}()
+	}
+
+	// The fixed sample test does not use them
+	if res := ProbTrueAtLeastResult(x, 0.5, Seed(1), Method(FixedN), ErrorRates(0, 0)); !res.Value {
+		t.Errorf("Fixed sample test gave %+v", res)
+	}
+}
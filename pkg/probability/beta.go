@@ -0,0 +1,84 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+type Beta struct {
+	alpha, beta float64
+	i           int
+}
+
+var _ Uncertain = &Beta{}
+
+// NewBeta creates a beta distribution on [0, 1] with the shape
+// parameters alpha and beta.
+func NewBeta(alpha, beta float64) *Beta {
+	b, err := TryNewBeta(alpha, beta)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryNewBeta is NewBeta returning an error for invalid parameters.
+func TryNewBeta(alpha, beta float64) (*Beta, error) {
+	if err := checkBeta(alpha, beta); err != nil {
+		return nil, err
+	}
+	return &Beta{
+		alpha: alpha,
+		beta:  beta,
+		i:     newID(),
+	}, nil
+}
+
+func checkBeta(alpha, beta float64) error {
+	if !isPositive(alpha) || !isPositive(beta) {
+		return invalidParameter("shape parameters of a beta distribution must be positive, got %0.7f, %0.7f", alpha, beta)
+	}
+	return nil
+}
+
+func (b *Beta) validate() error {
+	return checkBeta(b.alpha, b.beta)
+}
+
+func (b *Beta) sample(r *draw) float64 {
+	if v, ok := r.lookup(b.i); ok {
+		return v
+	}
+	x := randGamma(r.Rand, b.alpha)
+	return r.store(b.i, x/(x+randGamma(r.Rand, b.beta)))
+}
+
+func (b *Beta) sampleWithTrace(r *draw) *sample {
+	val := b.sample(r)
+	s := newSample(val)
+	s.addTrace(b.i, val)
+	return s
+}
+
+func (b *Beta) id() int {
+	return b.i
+}
+
+func (b *Beta) support() interval {
+	return interval{lo: 0, hi: 1}
+}
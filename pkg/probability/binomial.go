@@ -0,0 +1,103 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+
+	"github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+type Binomial struct {
+	n           int
+	probability float64
+	i           int
+}
+
+var _ Uncertain = &Binomial{}
+
+// NewBinomial creates a binomial distribution of the number of successes
+// in n trials with the given probability of success.
+func NewBinomial(n int, probability float64) *Binomial {
+	b, err := TryNewBinomial(n, probability)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryNewBinomial is NewBinomial returning an error for invalid
+// parameters.
+func TryNewBinomial(n int, probability float64) (*Binomial, error) {
+	if err := checkBinomial(n, probability); err != nil {
+		return nil, err
+	}
+	return &Binomial{
+		n:           n,
+		probability: probability,
+		i:           newID(),
+	}, nil
+}
+
+func checkBinomial(n int, probability float64) error {
+	if n < 0 {
+		return invalidParameter("number of trials of a binomial distribution must not be negative, got %d", n)
+	}
+	return checkProbability("binomial", probability)
+}
+
+func (b *Binomial) validate() error {
+	return checkBinomial(b.n, b.probability)
+}
+
+func (b *Binomial) sample(r *draw) float64 {
+	if v, ok := r.lookup(b.i); ok {
+		return v
+	}
+	if b.n > 30 {
+		return r.store(b.i, statistics.BinomPpf(openUnit(r.Rand), b.n, b.probability))
+	}
+	k := 0
+	for j := 0; j < b.n; j++ {
+		if r.Float64() < b.probability {
+			k++
+		}
+	}
+	return r.store(b.i, float64(k))
+}
+
+func (b *Binomial) sampleWithTrace(r *draw) *sample {
+	val := b.sample(r)
+	s := newSample(val)
+	s.addTrace(b.i, val)
+	return s
+}
+
+func (b *Binomial) id() int {
+	return b.i
+}
+
+func (b *Binomial) support() interval {
+	return interval{
+		lo: float64(b.n) * math.Floor(b.probability),
+		hi: float64(b.n) * math.Ceil(b.probability),
+	}
+}
@@ -21,26 +21,39 @@ package probability
 // THE SOFTWARE.
 
 type ConditionalDistribution struct {
-	condition UncertainBool
-	input     Uncertain
-	i         int
+	condition   UncertainBool
+	input       Uncertain
+	maxAttempts int
+	i           int
 }
 
-func ProbGivenCondition(input Uncertain, condition UncertainBool) Uncertain {
+// ProbGivenCondition is the distribution of input given that condition is
+// true, sampled by rejection. Sampling panics with ErrMaxAttempts if it
+// cannot satisfy the condition in MaxAttempts draws (100,000 by default,
+// 0 for no limit). Evaluate and RejectionSampling return the error
+// instead.
+func ProbGivenCondition(input Uncertain, condition UncertainBool, opts ...Option) Uncertain {
 	return &ConditionalDistribution{
-		condition: condition,
-		input:     input,
-		i:         newID(),
+		condition:   condition,
+		input:       input,
+		maxAttempts: getMaxAttempts(opts, 100_000),
+		i:           newID(),
 	}
 }
 
-func (c *ConditionalDistribution) sampleWithTrace() *sample {
-	for {
-		s := c.condition.sampleWithTrace()
+// sampleWithTrace makes every attempt a new draw of the condition and the
+// input, so a rejected attempt does not repeat its values.
+func (c *ConditionalDistribution) sampleWithTrace(r *draw) *sample {
+	attempt := newDraw(r.Rand)
+	for attempts := 0; ; attempts++ {
+		if c.maxAttempts > 0 && attempts == c.maxAttempts {
+			panic(ErrMaxAttempts)
+		}
+		s := c.condition.sampleWithTrace(attempt.next())
 		inputval, ok := s.trace[c.input.id()]
 		if !ok {
 			panic("not in trace")
-			///inputval = c.input.sample()
+			///inputval = c.input.sample(r)
 		}
 		if convertFloatSampleToBool(s.value) {
 			s.value = inputval
@@ -50,8 +63,8 @@ func (c *ConditionalDistribution) sampleWithTrace() *sample {
 	}
 }
 
-func (c *ConditionalDistribution) sample() float64 {
-	return c.sampleWithTrace().value
+func (c *ConditionalDistribution) sample(r *draw) float64 {
+	return c.sampleWithTrace(r).value
 }
 
 func (c *ConditionalDistribution) id() int {
@@ -74,31 +87,31 @@ func IfElse(condition UncertainBool, trueCond Uncertain, falseCond Uncertain) *I
 	}
 }
 
-func (ife *IfElseDistribution) sampleWithTrace() *sample {
-	t := ife.test.sampleWithTrace()
+func (ife *IfElseDistribution) sampleWithTrace(r *draw) *sample {
+	t := ife.test.sampleWithTrace(r)
 	var s *sample
 	if convertFloatSampleToBool(t.value) {
-		s = ife.trueBranch.sampleWithTrace()
+		s = ife.trueBranch.sampleWithTrace(r)
 	} else {
-		s = ife.falseBranch.sampleWithTrace()
+		s = ife.falseBranch.sampleWithTrace(r)
 	}
 	return s.combine(t)
 }
 
-func (ife *IfElseDistribution) sample() float64 {
-	return ife.sampleWithTrace().value
+func (ife *IfElseDistribution) sample(r *draw) float64 {
+	return ife.sampleWithTrace(r).value
 }
 
 func (ife *IfElseDistribution) id() int {
 	return ife.i
 }
 
-func (ife *IfElseDistribution) sampleBool() bool {
-	return convertFloatSampleToBool(ife.sample())
+func (ife *IfElseDistribution) sampleBool(r *draw) bool {
+	return convertFloatSampleToBool(ife.sample(r))
 }
 
-func (ife *IfElseDistribution) Pr() bool {
-	return Pr(ife.ToBool())
+func (ife *IfElseDistribution) Pr(opts ...Option) bool {
+	return Pr(ife.ToBool(), opts...)
 }
 
 func (ife *IfElseDistribution) ToBool() UncertainBool {
@@ -109,3 +122,11 @@ func (ife *IfElseDistribution) ToBool() UncertainBool {
 	}
 	return GreaterThan(ife, NewConstant(0.5))
 }
+
+func (c *ConditionalDistribution) inputs() []Uncertain {
+	return []Uncertain{c.condition, c.input}
+}
+
+func (ife *IfElseDistribution) inputs() []Uncertain {
+	return []Uncertain{ife.test, ife.trueBranch, ife.falseBranch}
+}
@@ -34,11 +34,21 @@ func (mci MeanAndConfidenceInterval) String() string {
 	return fmt.Sprintf("%0.4f +- %0.4f", mci.Mean, mci.CI)
 }
 
+// ExpectedValueWithConfidence estimates the expected value of u from
+// SampleSize samples. With Workers, it is evaluated on parallel workers
+// as in Evaluate, stopping early once PercentError is reached.
 func ExpectedValueWithConfidence(u Uncertain, opts ...Option) MeanAndConfidenceInterval {
 	sampleSize := getSampleSize(opts, 1000)
 	zScore := getZScore(opts, zScore95)
 
-	m := Materialize(u, sampleSize)
+	if getWorkers(opts, 0) > 0 {
+		percentError := getPercentError(opts, 0)
+		return evaluate(getContext(opts), u, sampleSize, opts, func(p PartialResult) bool {
+			return percentError > 0 && p.CI <= percentError*math.Abs(p.Mean)
+		}).MeanAndConfidenceInterval
+	}
+
+	m := Materialize(u, sampleSize, opts...)
 
 	mean := m.Average()
 
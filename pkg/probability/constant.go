@@ -34,7 +34,7 @@ func NewConstant(val float64) *Constant {
 	}
 }
 
-func (c *Constant) sample() float64 {
+func (c *Constant) sample(r *draw) float64 {
 	return c.val
 }
 
@@ -42,8 +42,23 @@ func (c *Constant) id() int {
 	return c.i
 }
 
-func (c *Constant) sampleWithTrace() *sample {
+func (c *Constant) sampleWithTrace(r *draw) *sample {
 	s := newSample(c.val)
 	s.addTrace(c.i, c.val)
 	return s
 }
+
+func (c *Constant) quantile(p float64) float64 {
+	return c.val
+}
+
+func (c *Constant) atoms() []float64 {
+	return []float64{c.val}
+}
+
+func (c *Constant) validate() error {
+	if !isFinite(c.val) {
+		return invalidParameter("constant must be finite, got %0.7f", c.val)
+	}
+	return nil
+}
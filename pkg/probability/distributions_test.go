@@ -0,0 +1,83 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+)
+
+func meanVariance(s *Samples) (float64, float64) {
+	mean := s.Average()
+	var ss float64
+	for _, v := range s.Samples {
+		ss += (v.value - mean) * (v.value - mean)
+	}
+	return mean, ss / float64(len(s.Samples)-1)
+}
+
+func TestDistributionMoments(t *testing.T) {
+	tests := []struct {
+		name           string
+		u              Uncertain
+		mean, variance float64
+	}{
+		{"exponential", NewExponential(2), 0.5, 0.25},
+		{"poisson", NewPoisson(4), 4, 4},
+		{"poisson large", NewPoisson(100), 100, 100},
+		{"binomial", NewBinomial(20, 0.3), 6, 4.2},
+		{"binomial large", NewBinomial(200, 0.3), 60, 42},
+		{"beta", NewBeta(2, 5), 2.0 / 7, 10.0 / (49 * 8)},
+		{"gamma", NewGamma(3, 2), 6, 12},
+		{"gamma small shape", NewGamma(0.5, 1), 0.5, 0.5},
+		{"log-normal", NewLogNormal(0, 0.5), math.Exp(0.125), (math.Exp(0.25) - 1) * math.Exp(0.25)},
+		{"student's t", NewStudentT(5, 1, 2), 1, 4 * 5.0 / 3},
+		{"triangular", NewTriangular(0, 1, 4), 5.0 / 3, (16 + 1 - 4) / 18.0},
+	}
+	for _, test := range tests {
+		mean, variance := meanVariance(Materialize(test.u, 40000, Seed(11)))
+		sd := math.Sqrt(test.variance)
+		if math.Abs(mean-test.mean) > 4*sd/math.Sqrt(40000) {
+			t.Errorf("%s: mean %0.4f != %0.4f", test.name, mean, test.mean)
+		}
+		if math.Abs(variance/test.variance-1) > 0.05 {
+			t.Errorf("%s: variance %0.4f != %0.4f", test.name, variance, test.variance)
+		}
+	}
+}
+
+func TestDistributionTrace(t *testing.T) {
+	// Conditioning requires the value of the input to be shared through the trace
+	x := NewGamma(2, 1)
+	y := ProbGivenCondition(x, GreaterThan(x, NewConstant(3)))
+	for _, s := range Materialize(y, 100, Seed(1)).Samples {
+		if s.value <= 3 {
+			t.Fatalf("Conditional sample %0.4f is not above 3", s.value)
+		}
+	}
+
+	p := NewPoisson(2)
+	anyEvents := IfElse(GreaterThan(p, NewConstant(0)), NewConstant(1), NewConstant(0))
+	v := Materialize(anyEvents, 10000, Seed(2)).Average()
+	if !Within(v, 1-math.Exp(-2), 0.02) {
+		t.Errorf("Probability of any event is %0.4f, expected %0.4f", v, 1-math.Exp(-2))
+	}
+}
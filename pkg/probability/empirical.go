@@ -0,0 +1,103 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhojpur/mathematics/pkg/dataframe"
+	"github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+type Empirical struct {
+	data []float64
+	i    int
+}
+
+var _ Uncertain = &Empirical{}
+
+// NewEmpirical creates a distribution that resamples the observed data
+// with replacement. The data is copied.
+func NewEmpirical(data statistics.Float64Data) *Empirical {
+	e, err := TryNewEmpirical(data)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// TryNewEmpirical is NewEmpirical returning an error without data.
+func TryNewEmpirical(data statistics.Float64Data) (*Empirical, error) {
+	if len(data) == 0 {
+		return nil, invalidParameter("must have at least some data in an empirical distribution")
+	}
+	return &Empirical{
+		data: append([]float64(nil), data...),
+		i:    newID(),
+	}, nil
+}
+
+// NewEmpiricalFromColumn creates an empirical distribution from the
+// values of a column of df. Nil values are skipped. Columns other than
+// float64 are converted if they implement dataframe.ToSeriesFloat64.
+func NewEmpiricalFromColumn(ctx context.Context, df *dataframe.DataFrame, column string) (*Empirical, error) {
+	col, err := df.NameToColumn(column)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, column)
+	}
+
+	conv, ok := df.Series[col].(dataframe.ToSeriesFloat64)
+	if !ok {
+		return nil, fmt.Errorf("column %s can not be converted to float64", column)
+	}
+	sf, err := conv.ToSeriesFloat64(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(sf.Values) == 0 {
+		return nil, fmt.Errorf("column %s has no values", column)
+	}
+
+	return NewEmpirical(sf.Values), nil
+}
+
+func (e *Empirical) sample(r *draw) float64 {
+	if v, ok := r.lookup(e.i); ok {
+		return v
+	}
+	return r.store(e.i, e.data[r.Intn(len(e.data))])
+}
+
+func (e *Empirical) sampleWithTrace(r *draw) *sample {
+	val := e.sample(r)
+	s := newSample(val)
+	s.addTrace(e.i, val)
+	return s
+}
+
+func (e *Empirical) id() int {
+	return e.i
+}
+
+func (e *Empirical) atoms() []float64 {
+	return e.data
+}
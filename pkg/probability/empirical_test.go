@@ -0,0 +1,80 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bhojpur/mathematics/pkg/dataframe"
+	"github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestEmpirical(t *testing.T) {
+	data := statistics.Float64Data{1, 2, 2, 7}
+	e := NewEmpirical(data)
+	data[0] = 100
+
+	seen := map[float64]bool{}
+	for _, s := range Materialize(e, 1000, Seed(4)).Samples {
+		seen[s.value] = true
+	}
+	if len(seen) != 3 || !seen[1] || !seen[2] || !seen[7] {
+		t.Errorf("Empirical samples %v are not the observed data", seen)
+	}
+	if v := Materialize(e, 20000, Seed(4)).Average(); !Within(v, 3, 0.1) {
+		t.Errorf("Empirical mean %0.4f != 3", v)
+	}
+}
+
+func TestEmpiricalFromColumn(t *testing.T) {
+	ctx := context.Background()
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesFloat64("latency", nil, 10, nil, 30),
+		dataframe.NewSeriesInt64("count", nil, 1, 2, nil),
+		dataframe.NewSeriesString("host", nil, "a", "b", "c"),
+	)
+
+	e, err := NewEmpiricalFromColumn(ctx, df, "latency")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range Materialize(e, 100, Seed(1)).Samples {
+		if s.value != 10 && s.value != 30 {
+			t.Fatalf("Sample %0.4f of latency is not observed", s.value)
+		}
+	}
+
+	e, err = NewEmpiricalFromColumn(ctx, df, "count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := Materialize(e, 10000, Seed(1)).Average(); !Within(v, 1.5, 0.05) {
+		t.Errorf("Mean of count %0.4f != 1.5", v)
+	}
+
+	if _, err := NewEmpiricalFromColumn(ctx, df, "missing"); err == nil {
+		t.Error("Expected an error for a missing column")
+	}
+	if _, err := NewEmpiricalFromColumn(ctx, df, "host"); err == nil {
+		t.Error("Expected an error for a column of strings")
+	}
+}
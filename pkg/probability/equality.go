@@ -30,6 +30,7 @@ type compareFunc func(x float64, y float64) bool
 type comparisonOperation struct {
 	a, b     Uncertain
 	i        int
+	op       string
 	comparer compareFunc
 }
 
@@ -42,53 +43,54 @@ type comparisonOperation struct {
 // where the probability of a 1.0 is reflected by
 // how often a < b.
 func LessThan(a Uncertain, b Uncertain) UncertainBool {
-	return newComparison(a, b, func(x, y float64) bool {
+	return newComparison(a, b, "<", func(x, y float64) bool {
 		return x < y
 	})
 }
 
 func GreaterThan(a Uncertain, b Uncertain) UncertainBool {
-	return newComparison(a, b, func(x, y float64) bool {
+	return newComparison(a, b, ">", func(x, y float64) bool {
 		return x > y
 	})
 }
 
 func NotEquals(a Uncertain, b Uncertain) UncertainBool {
-	return newComparison(a, b, func(x, y float64) bool {
+	return newComparison(a, b, "!=", func(x, y float64) bool {
 		return x != y
 	})
 }
 
 func Equals(a Uncertain, b Uncertain) UncertainBool {
-	return newComparison(a, b, func(x, y float64) bool {
+	return newComparison(a, b, "==", func(x, y float64) bool {
 		return x == y
 	})
 }
 
-func newComparison(a Uncertain, b Uncertain, compare compareFunc) *comparisonOperation {
+func newComparison(a Uncertain, b Uncertain, op string, compare compareFunc) *comparisonOperation {
 	return &comparisonOperation{
 		a:        a,
 		b:        b,
 		i:        newID(),
+		op:       op,
 		comparer: compare,
 	}
 }
 
-func (comp *comparisonOperation) sampleBool() bool {
-	return comp.comparer(comp.a.sample(), comp.b.sample())
+func (comp *comparisonOperation) sampleBool(r *draw) bool {
+	return comp.comparer(comp.a.sample(r), comp.b.sample(r))
 }
 
-func (comp *comparisonOperation) sample() float64 {
-	return convertBoolSampleToFloat(comp.sampleBool())
+func (comp *comparisonOperation) sample(r *draw) float64 {
+	return convertBoolSampleToFloat(comp.sampleBool(r))
 }
 
 func (comp *comparisonOperation) id() int {
 	return comp.i
 }
 
-func (comp *comparisonOperation) sampleWithTrace() *sample {
-	asample := comp.a.sampleWithTrace()
-	bsample := comp.b.sampleWithTrace()
+func (comp *comparisonOperation) sampleWithTrace(r *draw) *sample {
+	asample := comp.a.sampleWithTrace(r)
+	bsample := comp.b.sampleWithTrace(r)
 	out := asample.combine(bsample)
 	out.value = convertBoolSampleToFloat(
 		comp.comparer(
@@ -100,6 +102,10 @@ func (comp *comparisonOperation) sampleWithTrace() *sample {
 	return out
 }
 
-func (comp *comparisonOperation) Pr() bool {
-	return Pr(comp)
+func (comp *comparisonOperation) Pr(opts ...Option) bool {
+	return Pr(comp, opts...)
+}
+
+func (comp *comparisonOperation) inputs() []Uncertain {
+	return []Uncertain{comp.a, comp.b}
 }
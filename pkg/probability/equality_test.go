@@ -61,8 +61,8 @@ func TestMontyHall(t *testing.T) {
 	// Now monty opens a door
 	switchWins := Not(match)
 
-	v := Materialize(switchWins, 1000).Average()
-	t.Log(ExpectedValueWithConfidence(switchWins, SampleSize(20000)))
+	v := Materialize(switchWins, 1000, Seed(3)).Average()
+	t.Log(ExpectedValueWithConfidence(switchWins, SampleSize(20000), Seed(3)))
 	if !Within(v, 0.666, epsilon) {
 		t.Error("Switching should win 2/3 of the time")
 	}
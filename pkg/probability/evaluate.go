@@ -0,0 +1,340 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// evalBatchSize is the number of samples a worker draws at a time
+const evalBatchSize = 1024
+
+// PartialResult is the estimate of the expected value after N samples.
+// Err is set on the last result if the evaluation was cancelled or a
+// sample failed, e.g. with ErrMaxAttempts.
+type PartialResult struct {
+	MeanAndConfidenceInterval
+	N   int
+	Err error
+}
+
+// Evaluate estimates the expected value of u on parallel workers and
+// streams a PartialResult after every batch of samples. It draws at most
+// SampleSize samples (100,000 by default) on Workers goroutines
+// (GOMAXPROCS by default). With PercentError, it stops early once the
+// confidence interval is within that fraction of the mean, e.g. 0.01 for
+// 1%. The confidence interval uses ZScore (95% by default).
+//
+// Every batch draws from its own stream of the Seed and the batches are
+// combined in order, so the results only depend on the seed and not on
+// the number of workers. A panic while drawing a sample ends the
+// evaluation with the panic as the error of the last result. The channel
+// is closed when the evaluation ends.
+// It must be drained, or ctx cancelled, to release the workers.
+func Evaluate(ctx context.Context, u Uncertain, opts ...Option) <-chan PartialResult {
+	out := make(chan PartialResult, 1)
+	n := getSampleSize(opts, 100_000)
+	percentError := getPercentError(opts, 0)
+	converged := func(p PartialResult) bool {
+		return percentError > 0 && p.CI <= percentError*math.Abs(p.Mean)
+	}
+	go func() {
+		defer close(out)
+		res := evaluate(ctx, u, n, opts, func(p PartialResult) bool {
+			if p.N == n || converged(p) {
+				return true
+			}
+			select {
+			case out <- p:
+				return false
+			case <-ctx.Done():
+				return true
+			}
+		})
+		if res.Err == nil && res.N < n && !converged(res) {
+			res.Err = ctx.Err()
+		}
+		select {
+		case out <- res:
+		case <-ctx.Done():
+			// The reader may be gone, so the last result takes the
+			// place of a partial result it has not read rather than
+			// waiting for it.
+			select {
+			case <-out:
+			default:
+			}
+			out <- res
+		}
+	}()
+	return out
+}
+
+// moments are the running mean and sum of squared deviations of a set of
+// samples, merged with the formulas of Chan et al.
+type moments struct {
+	n    float64
+	mean float64
+	m2   float64
+}
+
+func (m *moments) add(x float64) {
+	m.n++
+	d := x - m.mean
+	m.mean += d / m.n
+	m.m2 += d * (x - m.mean)
+}
+
+func (m *moments) merge(o moments) {
+	if o.n == 0 {
+		return
+	}
+	n := m.n + o.n
+	d := o.mean - m.mean
+	m.mean += d * o.n / n
+	m.m2 += o.m2 + d*d*m.n*o.n/n
+	m.n = n
+}
+
+func (m moments) result(zScore float64) PartialResult {
+	ci := math.Inf(1)
+	if m.n > 1 {
+		ci = zScore * math.Sqrt(m.m2/(m.n-1)) / math.Sqrt(m.n)
+	}
+	return PartialResult{
+		MeanAndConfidenceInterval: MeanAndConfidenceInterval{
+			Mean: m.mean,
+			CI:   ci,
+		},
+		N: int(m.n),
+	}
+}
+
+// evaluate draws up to n samples of u in parallel and calls progress with
+// the estimate after every batch until it returns true. It returns the
+// last estimate.
+func evaluate(ctx context.Context, u Uncertain, n int, opts []Option, progress func(PartialResult) bool) PartialResult {
+	zScore := getZScore(opts, zScore95)
+	batches := make([]moments, (n+evalBatchSize-1)/evalBatchSize)
+	var total moments
+	err := runBatches(ctx, n, opts, func(r *rand.Rand, b, lo, hi int) {
+		d := newDraw(r)
+		for i := lo; i < hi; i++ {
+			batches[b].add(u.sample(d.next()))
+		}
+	}, func(b int) bool {
+		total.merge(batches[b])
+		return progress(total.result(zScore))
+	})
+	res := total.result(zScore)
+	res.Err = err
+	return res
+}
+
+// samplingPanic is a panic in a worker of runBatches. It is returned as
+// an error, so the caller can report it or panic again in its own
+// goroutine where it can be recovered.
+type samplingPanic struct {
+	value interface{}
+}
+
+func (p samplingPanic) Error() string {
+	if err, ok := p.value.(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprintf("probability: sampling panicked: %v", p.value)
+}
+
+func (p samplingPanic) Unwrap() error {
+	err, _ := p.value.(error)
+	return err
+}
+
+// repanic panics again with the value of a samplingPanic.
+func repanic(err error) {
+	if p, ok := err.(samplingPanic); ok {
+		panic(p.value)
+	}
+}
+
+// drawBatch calls draw and returns a panic as a samplingPanic.
+func drawBatch(draw func(r *rand.Rand, b, lo, hi int), r *rand.Rand, b, lo, hi int) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = samplingPanic{v}
+		}
+	}()
+	draw(r, b, lo, hi)
+	return nil
+}
+
+// runBatches draws n samples in batches on parallel workers. Batch b
+// draws samples [lo, hi) from stream b+1 of the seed. The batches are
+// passed to merge in order until it returns true. If a batch panics, the
+// other workers are stopped and the panic is returned as a samplingPanic.
+// All workers have stopped when runBatches returns.
+func runBatches(ctx context.Context, n int, opts []Option, draw func(r *rand.Rand, b, lo, hi int), merge func(b int) bool) error {
+	nBatches := (n + evalBatchSize - 1) / evalBatchSize
+	if nBatches == 0 {
+		return nil
+	}
+	workers := getWorkers(opts, 0)
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > nBatches {
+		workers = nBatches
+	}
+	seed := newSeed(opts)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	next := make(chan int)
+	go func() {
+		defer close(next)
+		for b := 0; b < nBatches && runCtx.Err() == nil; b++ {
+			select {
+			case next <- b:
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+
+	finished := make(chan int, workers)
+	var mu sync.Mutex
+	var failure error
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for b := range next {
+				if runCtx.Err() != nil {
+					return
+				}
+				lo := b * evalBatchSize
+				hi := lo + evalBatchSize
+				if hi > n {
+					hi = n
+				}
+				if err := drawBatch(draw, rand.New(rand.NewSource(streamSeed(seed, b+1))), b, lo, hi); err != nil {
+					mu.Lock()
+					if failure == nil {
+						failure = err
+					}
+					mu.Unlock()
+					cancel()
+					return
+				}
+				select {
+				case finished <- b:
+				case <-runCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+
+	// Batches finish in any order but are merged in order
+	ready := make([]bool, nBatches)
+	merged := 0
+	for b := range finished {
+		ready[b] = true
+		for merged < nBatches && ready[merged] {
+			stop := merge(merged)
+			merged++
+			if stop {
+				cancel()
+				for range finished {
+				}
+				return nil
+			}
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if failure != nil {
+		return failure
+	}
+	if merged < nBatches {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// materializeParallel is Materialize on parallel workers
+func materializeParallel(u Uncertain, n int, opts []Option) *Samples {
+	samples := make([]*sample, n)
+	count := 0
+	err := runBatches(getContext(opts), n, opts, func(r *rand.Rand, b, lo, hi int) {
+		d := newDraw(r)
+		for i := lo; i < hi; i++ {
+			samples[i] = u.sampleWithTrace(d.next())
+		}
+	}, func(b int) bool {
+		count = (b + 1) * evalBatchSize
+		if count > n {
+			count = n
+		}
+		return false
+	})
+	repanic(err)
+	return &Samples{
+		Samples: samples[:count],
+		i:       newID(),
+	}
+}
+
+// parallelProbTrueAtLeast is ProbTrueAtLeastResult on parallel workers.
+// The SPRT is checked after every batch.
+func parallelProbTrueAtLeast(b UncertainBool, prob float64, method TestMethod, opts []Option) PrResult {
+	var test sprt
+	if method != FixedN {
+		test = newSPRT(prob, opts)
+	}
+	var out PrResult
+	res := evaluate(getContext(opts), b, getSampleSize(opts, 10_000), opts, func(p PartialResult) bool {
+		if method == FixedN {
+			return false
+		}
+		out.Decided, out.Value = test.decide(int(math.Round(p.Mean*float64(p.N))), p.N)
+		return out.Decided
+	})
+	repanic(res.Err)
+	if method == FixedN {
+		out.Value = res.Mean >= prob
+		out.Decided = res.Err == nil
+	}
+	out.Samples = res.N
+	out.Probability = res.Mean
+	return out
+}
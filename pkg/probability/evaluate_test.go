@@ -0,0 +1,178 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestEvaluate(t *testing.T) {
+	x := NewGaussian(10, 2)
+
+	var last PartialResult
+	n := 0
+	for p := range Evaluate(context.Background(), x, SampleSize(50_000), Workers(4), Seed(1)) {
+		if p.N <= n {
+			t.Errorf("Partial results not increasing: %d after %d", p.N, n)
+		}
+		n = p.N
+		last = p
+	}
+	if last.Err != nil || last.N != 50_000 {
+		t.Fatalf("Evaluate ended with %v after %d samples", last.Err, last.N)
+	}
+	if !Within(last.Mean, 10, 0.05) || !Within(last.CI, 1.96*2/223.6068, 0.001) {
+		t.Errorf("Evaluate gave %v", last.MeanAndConfidenceInterval)
+	}
+
+	// The result does not depend on the number of workers
+	for _, w := range []int{1, 3, 8} {
+		var p PartialResult
+		for p = range Evaluate(context.Background(), x, SampleSize(50_000), Workers(w), Seed(1)) {
+		}
+		if p != last {
+			t.Errorf("Evaluate with %d workers gave %v, want %v", w, p.MeanAndConfidenceInterval, last.MeanAndConfidenceInterval)
+		}
+	}
+}
+
+func TestEvaluateSamples(t *testing.T) {
+	// Samples in a graph are picked by the seed, not by the workers
+	x := Add(Materialize(NewGaussian(0, 1), 1000, Seed(4)), NewUniform(0, 1))
+	var first PartialResult
+	for _, w := range []int{1, 4, 8} {
+		var p PartialResult
+		for p = range Evaluate(context.Background(), x, SampleSize(20_000), Workers(w), Seed(5)) {
+		}
+		if w == 1 {
+			first = p
+		} else if p != first {
+			t.Errorf("Evaluate with %d workers gave %v, want %v", w, p.MeanAndConfidenceInterval, first.MeanAndConfidenceInterval)
+		}
+	}
+}
+
+func TestEvaluatePercentError(t *testing.T) {
+	var last PartialResult
+	for last = range Evaluate(context.Background(), NewGaussian(10, 2), PercentError(0.01), Seed(2)) {
+	}
+	if last.Err != nil || last.N >= 100_000 || last.CI > 0.1 {
+		t.Errorf("Evaluate did not stop early: %v after %d samples", last.MeanAndConfidenceInterval, last.N)
+	}
+}
+
+func TestEvaluateCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Evaluate(ctx, NewUniform(0, 1), SampleSize(10_000_000), Workers(2))
+	<-ch
+	cancel()
+	var last PartialResult
+	for last = range ch {
+	}
+	if last.Err != context.Canceled || last.N >= 10_000_000 {
+		t.Errorf("Cancelled evaluation ended with %v after %d samples", last.Err, last.N)
+	}
+}
+
+func TestEvaluateAbandoned(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for i := 0; i < 10; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := Evaluate(ctx, NewUniform(0, 1), SampleSize(10_000_000), Workers(2))
+		<-ch
+		cancel()
+	}
+
+	// The evaluations stop without anyone reading their channels
+	for wait := 0; runtime.NumGoroutine() > before; wait++ {
+		if wait == 100 {
+			t.Fatalf("%d goroutines left running", runtime.NumGoroutine()-before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestParallelMaterialize(t *testing.T) {
+	x := Add(NewGaussian(0, 1), NewUniform(0, 1))
+
+	a := sampleValues(Materialize(x, 5000, Seed(3), Workers(1)))
+	b := sampleValues(Materialize(x, 5000, Seed(3), Workers(6)))
+	if len(a) != 5000 || !equalValues(a, b) {
+		t.Error("Parallel Materialize depends on the number of workers")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if s := Materialize(x, 5000, Context(ctx), Workers(2)); len(s.Samples) == 5000 {
+		t.Error("Materialize ignored the cancelled context")
+	}
+
+	m := ExpectedValueWithConfidence(x, SampleSize(100_000), Seed(3), Workers(4))
+	if !Within(m.Mean, 0.5, 0.02) {
+		t.Errorf("ExpectedValueWithConfidence gave %v", m)
+	}
+}
+
+func TestParallelPr(t *testing.T) {
+	x := montyHall()
+	if !x.Pr(Workers(4)) {
+		t.Error("Monty Hall switching should win more often than not")
+	}
+	if ProbTrueAtLeast(x, 0.8, Workers(4)) {
+		t.Error("Monty Hall switching should not win 80% of the time")
+	}
+	if Pr(x, Seed(4), Workers(2)) != Pr(x, Seed(4), Workers(7)) {
+		t.Error("Pr depends on the number of workers")
+	}
+}
+
+func TestEvaluateMaxAttempts(t *testing.T) {
+	x := NewGaussian(0, 1)
+	impossible := ProbGivenCondition(x, GreaterThan(x, NewConstant(100)), MaxAttempts(1000))
+
+	var last PartialResult
+	for last = range Evaluate(context.Background(), impossible, Workers(4), Seed(1)) {
+	}
+	if !errors.Is(last.Err, ErrMaxAttempts) {
+		t.Errorf("Evaluate of an impossible condition ended with %v", last.Err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != ErrMaxAttempts {
+				t.Errorf("Parallel Materialize panicked with %v", r)
+			}
+		}()
+		Materialize(impossible, 5000, Workers(4), Seed(1))
+	}()
+
+	// Without MaxAttempts the default budget still ends the sampling
+	defer func() {
+		if r := recover(); r != ErrMaxAttempts {
+			t.Errorf("ProbGivenCondition panicked with %v", r)
+		}
+	}()
+	ProbGivenCondition(x, GreaterThan(x, NewConstant(100))).sample(newDraw(newRand([]Option{Seed(1)})))
+}
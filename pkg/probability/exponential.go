@@ -0,0 +1,89 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+type Exponential struct {
+	rate float64
+	i    int
+}
+
+var _ Uncertain = &Exponential{}
+
+// NewExponential creates an exponential distribution with the given rate,
+// i.e. a mean of 1/rate.
+func NewExponential(rate float64) *Exponential {
+	e, err := TryNewExponential(rate)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// TryNewExponential is NewExponential returning an error for an invalid
+// rate.
+func TryNewExponential(rate float64) (*Exponential, error) {
+	if err := checkExponential(rate); err != nil {
+		return nil, err
+	}
+	return &Exponential{
+		rate: rate,
+		i:    newID(),
+	}, nil
+}
+
+func checkExponential(rate float64) error {
+	if !isPositive(rate) {
+		return invalidParameter("rate of an exponential distribution must be positive, got %0.7f", rate)
+	}
+	return nil
+}
+
+func (e *Exponential) validate() error {
+	return checkExponential(e.rate)
+}
+
+func (e *Exponential) sample(r *draw) float64 {
+	if v, ok := r.lookup(e.i); ok {
+		return v
+	}
+	return r.store(e.i, r.ExpFloat64()/e.rate)
+}
+
+func (e *Exponential) sampleWithTrace(r *draw) *sample {
+	val := e.sample(r)
+	s := newSample(val)
+	s.addTrace(e.i, val)
+	return s
+}
+
+func (e *Exponential) id() int {
+	return e.i
+}
+
+func (e *Exponential) quantile(p float64) float64 {
+	return -math.Log1p(-p) / e.rate
+}
+
+func (e *Exponential) support() interval {
+	return interval{lo: 0, hi: math.Inf(1)}
+}
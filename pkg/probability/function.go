@@ -0,0 +1,192 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+type functionOperation struct {
+	args []Uncertain
+	i    int
+	name string
+	fn   func(xs ...float64) float64
+}
+
+// Map applies fn to samples of args. Like the arithmetic operators, it
+// samples each argument once per sample, so that the result can be
+// conditioned on its arguments.
+func Map(fn func(xs ...float64) float64, args ...Uncertain) Uncertain {
+	return newFunction("", fn, args...)
+}
+
+// Exp returns e**a.
+func Exp(a Uncertain) Uncertain {
+	return newFunction("exp", func(xs ...float64) float64 {
+		return math.Exp(xs[0])
+	}, a)
+}
+
+// Log returns the natural logarithm of a.
+func Log(a Uncertain) Uncertain {
+	return newFunction("log", func(xs ...float64) float64 {
+		return math.Log(xs[0])
+	}, a)
+}
+
+// Sqrt returns the square root of a.
+func Sqrt(a Uncertain) Uncertain {
+	return newFunction("sqrt", func(xs ...float64) float64 {
+		return math.Sqrt(xs[0])
+	}, a)
+}
+
+// Abs returns the absolute value of a.
+func Abs(a Uncertain) Uncertain {
+	return newFunction("abs", func(xs ...float64) float64 {
+		return math.Abs(xs[0])
+	}, a)
+}
+
+// Pow returns a**b.
+func Pow(a, b Uncertain) Uncertain {
+	return newFunction("pow", func(xs ...float64) float64 {
+		return math.Pow(xs[0], xs[1])
+	}, a, b)
+}
+
+// Min returns the smallest of the args. It panics if there are none.
+func Min(args ...Uncertain) Uncertain {
+	if len(args) == 0 {
+		panic(invalidParameter("min of no values"))
+	}
+	return newFunction("min", func(xs ...float64) float64 {
+		m := xs[0]
+		for _, x := range xs[1:] {
+			m = math.Min(m, x)
+		}
+		return m
+	}, args...)
+}
+
+// Max returns the largest of the args. It panics if there are none.
+func Max(args ...Uncertain) Uncertain {
+	if len(args) == 0 {
+		panic(invalidParameter("max of no values"))
+	}
+	return newFunction("max", func(xs ...float64) float64 {
+		m := xs[0]
+		for _, x := range xs[1:] {
+			m = math.Max(m, x)
+		}
+		return m
+	}, args...)
+}
+
+// Sum returns the sum of the args, which is 0 if there are none.
+func Sum(args ...Uncertain) Uncertain {
+	return newFunction("sum", func(xs ...float64) float64 {
+		sum := 0.0
+		for _, x := range xs {
+			sum += x
+		}
+		return sum
+	}, args...)
+}
+
+func newFunction(name string, fn func(xs ...float64) float64, args ...Uncertain) *functionOperation {
+	return &functionOperation{
+		args: append([]Uncertain(nil), args...),
+		i:    newID(),
+		name: name,
+		fn:   fn,
+	}
+}
+
+func (f *functionOperation) sampleWithTrace(r *draw) *sample {
+	xs := make([]float64, len(f.args))
+	s := newSample(0)
+	for k, arg := range f.args {
+		as := arg.sampleWithTrace(r)
+		xs[k] = as.value
+		s = s.combine(as)
+	}
+	v := f.fn(xs...)
+	s.value = v
+	s.addTrace(f.i, v)
+	return s
+}
+
+func (f *functionOperation) sample(r *draw) float64 {
+	xs := make([]float64, len(f.args))
+	for k, arg := range f.args {
+		xs[k] = arg.sample(r)
+	}
+	return f.fn(xs...)
+}
+
+func (f *functionOperation) id() int {
+	return f.i
+}
+
+func (f *functionOperation) inputs() []Uncertain {
+	return f.args
+}
+
+// functionSupport bounds the values of the named functions from the
+// supports of their arguments. Exp is never zero, so its interval is open.
+func functionSupport(name string, args []interval) interval {
+	switch name {
+	case "exp":
+		return interval{lo: math.Exp(args[0].lo), hi: math.Exp(args[0].hi), open: true}
+	case "log":
+		if args[0].lo < 0 {
+			return unbounded
+		}
+		return interval{lo: math.Log(args[0].lo), hi: math.Log(args[0].hi)}
+	case "sqrt":
+		if args[0].lo < 0 {
+			return unbounded
+		}
+		return interval{lo: math.Sqrt(args[0].lo), hi: math.Sqrt(args[0].hi), open: args[0].open}
+	case "abs":
+		a := args[0]
+		if a.lo <= 0 && a.hi >= 0 {
+			return interval{lo: 0, hi: math.Max(-a.lo, a.hi)}
+		}
+		return interval{lo: math.Min(math.Abs(a.lo), math.Abs(a.hi)), hi: math.Max(math.Abs(a.lo), math.Abs(a.hi)), open: a.open}
+	case "min", "max":
+		s := args[0]
+		for _, a := range args[1:] {
+			if name == "min" {
+				s = interval{lo: math.Min(s.lo, a.lo), hi: math.Min(s.hi, a.hi)}
+			} else {
+				s = interval{lo: math.Max(s.lo, a.lo), hi: math.Max(s.hi, a.hi)}
+			}
+		}
+		return s
+	case "sum":
+		var s interval
+		for _, a := range args {
+			s = interval{lo: s.lo + a.lo, hi: s.hi + a.hi}
+		}
+		return s
+	}
+	return unbounded
+}
@@ -0,0 +1,132 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFunctions(t *testing.T) {
+	for name, tc := range map[string]struct {
+		u    Uncertain
+		want float64
+	}{
+		"exp":  {Exp(NewConstant(1)), math.E},
+		"log":  {Log(NewConstant(math.E)), 1},
+		"sqrt": {Sqrt(NewConstant(9)), 3},
+		"abs":  {Abs(NewConstant(-2)), 2},
+		"pow":  {Pow(NewConstant(2), NewConstant(10)), 1024},
+		"min":  {Min(NewConstant(3), NewConstant(-1), NewConstant(2)), -1},
+		"max":  {Max(NewConstant(3), NewConstant(-1), NewConstant(2)), 3},
+		"sum":  {Sum(NewConstant(3), NewConstant(-1), NewConstant(2)), 4},
+		"none": {Sum(), 0},
+	} {
+		if v := Materialize(tc.u, 1, Seed(1)).Values()[0]; !Within(v, tc.want, epsilon) {
+			t.Errorf("%s gave %f, want %f", name, v, tc.want)
+		}
+	}
+
+	// Compound interest on an uncertain rate
+	rate := NewUniform(0.01, 0.03)
+	balance := Mul(NewConstant(100), Pow(Add(NewConstant(1), rate), NewConstant(10)))
+	if m := ExpectedValueWithConfidence(balance, SampleSize(50_000), Seed(1)); !Within(m.Mean, 122.08, 0.15) {
+		t.Errorf("Expected balance %f", m.Mean)
+	}
+
+	// The largest of n uniforms has mean n/(n+1)
+	failures := make([]Uncertain, 9)
+	for i := range failures {
+		failures[i] = NewUniform(0, 1)
+	}
+	if m := ExpectedValueWithConfidence(Max(failures...), SampleSize(50_000), Seed(1)); !Within(m.Mean, 0.9, 0.01) {
+		t.Errorf("Expected max %f", m.Mean)
+	}
+	if v := Materialize(Sum(failures...), 50_000, Seed(1)).Variance(); !Within(v, 9.0/12, 0.03) {
+		t.Errorf("Variance of the sum %f", v)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("Min of nothing panicked with %v", err)
+		}
+	}()
+	Min()
+}
+
+func TestMap(t *testing.T) {
+	x := NewGaussian(0, 1)
+	y := Map(func(xs ...float64) float64 {
+		return xs[0]*xs[0] + xs[1]
+	}, x, NewConstant(1))
+
+	// The samples of x are shared with the ones of y
+	if !Pr(LessThan(Mul(x, x), y), Seed(1)) {
+		t.Errorf("y was not conditioned on x")
+	}
+	ys, xs := pairs(y, x, 1000, Seed(1))
+	for i := range xs {
+		if !Within(ys[i], xs[i]*xs[i]+1, epsilon) {
+			t.Fatalf("Sample %d of y is %f for x %f", i, ys[i], xs[i])
+		}
+	}
+	if m := ExpectedValueWithConfidence(y, SampleSize(50_000), Seed(1), Workers(4)); !Within(m.Mean, 2, 0.02) {
+		t.Errorf("Expected value %f", m.Mean)
+	}
+
+	if _, err := MarshalGraph(y); err == nil {
+		t.Errorf("MarshalGraph of Map returned no error")
+	}
+}
+
+func TestFunctionGraph(t *testing.T) {
+	x := NewGaussian(0, 1)
+	if err := Validate(Div(NewConstant(1), Exp(x)), Seed(1)); err != nil {
+		t.Errorf("Validate of a division by exp returned %v", err)
+	}
+	var verr *ValidationError
+	if err := Validate(Div(NewConstant(1), Abs(x)), Seed(1)); !errors.As(err, &verr) || !errors.Is(verr.Issues[0].Err, ErrMayDivideByZero) {
+		t.Errorf("Validate of a division by abs returned %v", err)
+	}
+	if err := Validate(Log(x), Seed(1)); !errors.As(err, &verr) || verr.Issues[0].Kind != NonFinite {
+		t.Errorf("Validate of the log of a gaussian returned %v", err)
+	}
+
+	u := Sum(Exp(x), Log(NewUniform(1, 2)), Sqrt(Abs(x)), Pow(x, NewConstant(2)), Min(x, NewConstant(0)), Max(x, NewConstant(0)))
+	data, err := MarshalGraph(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := UnmarshalGraph(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalValues(sampleValues(Materialize(u, 100, Seed(1))), sampleValues(Materialize(g.Roots[0], 100, Seed(1)))) {
+		t.Errorf("Decoded graph gave different samples")
+	}
+
+	var out graphJSON
+	if err := json.Unmarshal(data, &out); err != nil || out.Nodes[len(out.Nodes)-1].Op != "sum" {
+		t.Errorf("Encoded %s", data)
+	}
+}
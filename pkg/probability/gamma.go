@@ -0,0 +1,112 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"math/rand"
+)
+
+type Gamma struct {
+	shape, scale float64
+	i            int
+}
+
+var _ Uncertain = &Gamma{}
+
+// NewGamma creates a gamma distribution with the given shape and scale,
+// i.e. a mean of shape*scale.
+func NewGamma(shape, scale float64) *Gamma {
+	g, err := TryNewGamma(shape, scale)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// TryNewGamma is NewGamma returning an error for invalid parameters.
+func TryNewGamma(shape, scale float64) (*Gamma, error) {
+	if err := checkGamma(shape, scale); err != nil {
+		return nil, err
+	}
+	return &Gamma{
+		shape: shape,
+		scale: scale,
+		i:     newID(),
+	}, nil
+}
+
+func checkGamma(shape, scale float64) error {
+	if !isPositive(shape) || !isPositive(scale) {
+		return invalidParameter("shape and scale of a gamma distribution must be positive, got %0.7f, %0.7f", shape, scale)
+	}
+	return nil
+}
+
+func (g *Gamma) validate() error {
+	return checkGamma(g.shape, g.scale)
+}
+
+func (g *Gamma) sample(r *draw) float64 {
+	if v, ok := r.lookup(g.i); ok {
+		return v
+	}
+	return r.store(g.i, randGamma(r.Rand, g.shape)*g.scale)
+}
+
+func (g *Gamma) sampleWithTrace(r *draw) *sample {
+	val := g.sample(r)
+	s := newSample(val)
+	s.addTrace(g.i, val)
+	return s
+}
+
+func (g *Gamma) id() int {
+	return g.i
+}
+
+// randGamma draws a gamma distributed value with shape a and scale 1
+// using the method of Marsaglia and Tsang.
+func randGamma(r *rand.Rand, a float64) float64 {
+	if a < 1 {
+		// Boost the shape and correct by a uniform power
+		return randGamma(r, a+1) * math.Pow(r.Float64(), 1/a)
+	}
+
+	d := a - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		var x, v float64
+		for v <= 0 {
+			x = r.NormFloat64()
+			v = 1 + c*x
+		}
+		v = v * v * v
+		u := r.Float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+func (g *Gamma) support() interval {
+	return interval{lo: 0, hi: math.Inf(1)}
+}
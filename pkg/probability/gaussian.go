@@ -20,6 +20,12 @@ package probability
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
+import (
+	"math"
+
+	"github.com/bhojpur/mathematics/pkg/statistics"
+)
+
 type Gaussian struct {
 	mean   float64
 	stddev float64
@@ -40,13 +46,36 @@ func NewNormal(mean, stddev float64) *Gaussian {
 	return NewGaussian(mean, stddev)
 }
 
-func (g *Gaussian) sample() float64 {
-	r := randNormalFloat64()
-	return (r * g.stddev) + g.mean
+// TryNewGaussian is NewGaussian returning an error for a negative
+// standard deviation or parameters that are not finite, which NewGaussian
+// accepts.
+func TryNewGaussian(mean, stddev float64) (*Gaussian, error) {
+	if err := checkGaussian(mean, stddev); err != nil {
+		return nil, err
+	}
+	return NewGaussian(mean, stddev), nil
+}
+
+func checkGaussian(mean, stddev float64) error {
+	if !isFinite(mean) || !isFinite(stddev) || stddev < 0 {
+		return invalidParameter("mean of a gaussian distribution must be finite and its standard deviation must not be negative, got %0.7f, %0.7f", mean, stddev)
+	}
+	return nil
+}
+
+func (g *Gaussian) validate() error {
+	return checkGaussian(g.mean, g.stddev)
 }
 
-func (g *Gaussian) sampleWithTrace() *sample {
-	val := g.sample()
+func (g *Gaussian) sample(r *draw) float64 {
+	if v, ok := r.lookup(g.i); ok {
+		return v
+	}
+	return r.store(g.i, (r.NormFloat64()*g.stddev)+g.mean)
+}
+
+func (g *Gaussian) sampleWithTrace(r *draw) *sample {
+	val := g.sample(r)
 	s := newSample(val)
 	s.addTrace(g.i, val)
 	return s
@@ -55,3 +84,14 @@ func (g *Gaussian) sampleWithTrace() *sample {
 func (g *Gaussian) id() int {
 	return g.i
 }
+
+func (g *Gaussian) quantile(p float64) float64 {
+	return statistics.NormPpf(p, g.mean, g.stddev)
+}
+
+func (g *Gaussian) support() interval {
+	if g.stddev == 0 {
+		return interval{lo: g.mean, hi: g.mean}
+	}
+	return interval{lo: math.Inf(-1), hi: math.Inf(1)}
+}
@@ -0,0 +1,587 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// GraphVersion is the version of the schema written by MarshalGraph.
+const GraphVersion = 1
+
+// ErrInvalidGraph is wrapped by the errors of UnmarshalGraph.
+var ErrInvalidGraph = errors.New("probability: invalid graph")
+
+// graphJSON is the schema of an encoded graph. The nodes are numbered
+// from 1 in an order where the inputs of a node come before it.
+type graphJSON struct {
+	Version int        `json:"version"`
+	Roots   []int      `json:"roots"`
+	Nodes   []nodeJSON `json:"nodes"`
+}
+
+type nodeJSON struct {
+	ID     int         `json:"id"`
+	Type   string      `json:"type"`
+	Op     string      `json:"op,omitempty"`
+	Params []float64   `json:"params,omitempty"`
+	Values []float64   `json:"values,omitempty"`
+	Matrix [][]float64 `json:"matrix,omitempty"`
+	Tables [][]float64 `json:"tables,omitempty"`
+	Inputs []int       `json:"inputs,omitempty"`
+}
+
+// Graph is a decoded graph.
+type Graph struct {
+	// Roots are the nodes passed to MarshalGraph, in order.
+	Roots []Uncertain
+	// Nodes are the nodes of the graph by their id in the encoding.
+	Nodes map[int]Uncertain
+}
+
+// MarshalGraph encodes the graphs of the roots to JSON. Nodes shared by
+// several roots are encoded once, and a graph always encodes to the same
+// ids. Samples keep their values but not their traces. The decoded graph
+// gives the same samples for the same Seed, as long as the Samples of the
+// graph have not been sampled yet.
+func MarshalGraph(roots ...Uncertain) ([]byte, error) {
+	e := &graphEncoder{
+		ids:    map[int]int{},
+		joints: map[*joint]int{},
+	}
+	out := graphJSON{
+		Version: GraphVersion,
+		Roots:   make([]int, len(roots)),
+	}
+	for i, root := range roots {
+		id, err := e.encode(root)
+		if err != nil {
+			return nil, err
+		}
+		out.Roots[i] = id
+	}
+	out.Nodes = e.nodes
+	return json.Marshal(out)
+}
+
+type graphEncoder struct {
+	ids    map[int]int
+	joints map[*joint]int
+	nodes  []nodeJSON
+}
+
+func (e *graphEncoder) add(n nodeJSON) int {
+	n.ID = len(e.nodes) + 1
+	e.nodes = append(e.nodes, n)
+	return n.ID
+}
+
+func (e *graphEncoder) encodeAll(us ...Uncertain) ([]int, error) {
+	out := make([]int, len(us))
+	for i, u := range us {
+		id, err := e.encode(u)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = id
+	}
+	return out, nil
+}
+
+func (e *graphEncoder) encode(u Uncertain) (int, error) {
+	if id, ok := e.ids[u.id()]; ok {
+		return id, nil
+	}
+
+	var n nodeJSON
+	switch x := u.(type) {
+	case *Bernoulli:
+		n = nodeJSON{Type: "bernoulli", Params: []float64{x.probability}}
+	case *Beta:
+		n = nodeJSON{Type: "beta", Params: []float64{x.alpha, x.beta}}
+	case *Binomial:
+		n = nodeJSON{Type: "binomial", Params: []float64{float64(x.n), x.probability}}
+	case *Constant:
+		n = nodeJSON{Type: "constant", Params: []float64{x.val}}
+	case *Empirical:
+		n = nodeJSON{Type: "empirical", Values: x.data}
+	case *Exponential:
+		n = nodeJSON{Type: "exponential", Params: []float64{x.rate}}
+	case *Gamma:
+		n = nodeJSON{Type: "gamma", Params: []float64{x.shape, x.scale}}
+	case *Gaussian:
+		n = nodeJSON{Type: "gaussian", Params: []float64{x.mean, x.stddev}}
+	case *LogNormal:
+		n = nodeJSON{Type: "lognormal", Params: []float64{x.mu, x.sigma}}
+	case *Multinomial:
+		n = nodeJSON{Type: "multinomial", Values: x.values, Params: x.cutoffs}
+	case *Poisson:
+		n = nodeJSON{Type: "poisson", Params: []float64{x.lambda}}
+	case *StudentT:
+		n = nodeJSON{Type: "studentt", Params: []float64{x.df, x.loc, x.scale}}
+	case *Triangular:
+		n = nodeJSON{Type: "triangular", Params: []float64{x.lo, x.mode, x.hi}}
+	case *Uniform:
+		n = nodeJSON{Type: "uniform", Params: []float64{x.lo, x.size}}
+	case *Samples:
+		n = nodeJSON{Type: "samples", Values: x.Values()}
+	case *Posterior:
+		n = nodeJSON{Type: "samples", Values: x.Values()}
+	case *component:
+		jid, err := e.encodeJoint(x.j)
+		if err != nil {
+			return 0, err
+		}
+		n = nodeJSON{Type: "component", Params: []float64{float64(x.k)}, Inputs: []int{jid}}
+	default:
+		c, ok := u.(composite)
+		if !ok {
+			return 0, fmt.Errorf("probability: can not encode node of type %T", u)
+		}
+		inputs, err := e.encodeAll(c.inputs()...)
+		if err != nil {
+			return 0, err
+		}
+		n = nodeJSON{Inputs: inputs}
+		switch x := u.(type) {
+		case *arithmeticOperation:
+			n.Type, n.Op = "arithmetic", x.op
+		case *comparisonOperation:
+			n.Type, n.Op = "comparison", x.op
+		case *logicOperation:
+			n.Type, n.Op = "logic", x.name
+		case *notOperation:
+			n.Type = "not"
+		case *functionOperation:
+			if x.name == "" {
+				return 0, errors.New("probability: can not encode the function of Map")
+			}
+			n.Type, n.Op = "function", x.name
+		case *ConditionalDistribution:
+			n.Type, n.Params = "conditional", []float64{float64(x.maxAttempts)}
+		case *IfElseDistribution:
+			n.Type = "ifelse"
+		default:
+			return 0, fmt.Errorf("probability: can not encode node of type %T", u)
+		}
+	}
+
+	id := e.add(n)
+	e.ids[u.id()] = id
+	return id, nil
+}
+
+func (e *graphEncoder) encodeJoint(j *joint) (int, error) {
+	if id, ok := e.joints[j]; ok {
+		return id, nil
+	}
+
+	var n nodeJSON
+	switch x := j.owner.(type) {
+	case *MultivariateNormal:
+		n = nodeJSON{Type: "multivariate_normal", Values: x.mean, Matrix: x.covariance}
+	case *GaussianCopula:
+		inputs, err := e.encodeAll(x.marginals...)
+		if err != nil {
+			return 0, err
+		}
+		n = nodeJSON{Type: "gaussian_copula", Inputs: inputs, Matrix: x.rankCorrelation, Tables: x.tables}
+	default:
+		return 0, fmt.Errorf("probability: can not encode joint distribution of type %T", j.owner)
+	}
+
+	id := e.add(n)
+	e.joints[j] = id
+	return id, nil
+}
+
+// UnmarshalGraph decodes a graph encoded by MarshalGraph. It checks the
+// parameters of the nodes like the TryNew constructors, and returns an
+// error wrapping ErrInvalidGraph for graphs it can not rebuild.
+func UnmarshalGraph(data []byte) (*Graph, error) {
+	var in graphJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGraph, err)
+	}
+	if in.Version != GraphVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidGraph, in.Version)
+	}
+
+	d := &graphDecoder{
+		nodes:  map[int]Uncertain{},
+		joints: map[int]*joint{},
+	}
+	for _, n := range in.Nodes {
+		if err := d.decode(n); err != nil {
+			return nil, fmt.Errorf("%w: node %d: %v", ErrInvalidGraph, n.ID, err)
+		}
+	}
+
+	out := &Graph{
+		Roots: make([]Uncertain, len(in.Roots)),
+		Nodes: d.nodes,
+	}
+	for i, id := range in.Roots {
+		u, ok := d.nodes[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown root %d", ErrInvalidGraph, id)
+		}
+		out.Roots[i] = u
+	}
+	return out, nil
+}
+
+type graphDecoder struct {
+	nodes  map[int]Uncertain
+	joints map[int]*joint
+}
+
+var (
+	arithmeticOps = map[string]func(a, b Uncertain) Uncertain{
+		"+": Add,
+		"-": Sub,
+		"*": Mul,
+		"/": Div,
+	}
+	comparisonOps = map[string]func(a, b Uncertain) UncertainBool{
+		"<":  LessThan,
+		">":  GreaterThan,
+		"!=": NotEquals,
+		"==": Equals,
+	}
+	unaryFunctions = map[string]func(a Uncertain) Uncertain{
+		"exp":  Exp,
+		"log":  Log,
+		"sqrt": Sqrt,
+		"abs":  Abs,
+	}
+	logicOps = map[string]func(a, b UncertainBool) UncertainBool{
+		"and": And,
+		"or":  Or,
+	}
+)
+
+// params checks the number of parameters of n
+func params(n nodeJSON, count int) ([]float64, error) {
+	if len(n.Params) != count {
+		return nil, fmt.Errorf("%s needs %d parameters, got %d", n.Type, count, len(n.Params))
+	}
+	return n.Params, nil
+}
+
+// inputs returns the nodes of the count inputs of n, which must have been
+// decoded before it.
+func (d *graphDecoder) inputs(n nodeJSON, count int) ([]Uncertain, error) {
+	if count >= 0 && len(n.Inputs) != count {
+		return nil, fmt.Errorf("%s needs %d inputs, got %d", n.Type, count, len(n.Inputs))
+	}
+	out := make([]Uncertain, len(n.Inputs))
+	for i, id := range n.Inputs {
+		u, ok := d.nodes[id]
+		if !ok || id >= n.ID {
+			return nil, fmt.Errorf("unknown input %d", id)
+		}
+		out[i] = u
+	}
+	return out, nil
+}
+
+func (d *graphDecoder) boolInputs(n nodeJSON, count int) ([]UncertainBool, error) {
+	in, err := d.inputs(n, count)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]UncertainBool, len(in))
+	for i, u := range in {
+		b, ok := u.(UncertainBool)
+		if !ok {
+			return nil, fmt.Errorf("input %d of %s is not boolean", n.Inputs[i], n.Type)
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+func (d *graphDecoder) decode(n nodeJSON) error {
+	if _, ok := d.nodes[n.ID]; ok || d.joints[n.ID] != nil {
+		return errors.New("duplicate id")
+	}
+
+	var u Uncertain
+	var err error
+	switch n.Type {
+	case "multivariate_normal", "gaussian_copula":
+		j, err := d.decodeJoint(n)
+		if err != nil {
+			return err
+		}
+		d.joints[n.ID] = j
+		return nil
+	case "component":
+		var p []float64
+		if p, err = params(n, 1); err != nil {
+			return err
+		}
+		if len(n.Inputs) != 1 || d.joints[n.Inputs[0]] == nil {
+			return errors.New("component needs a joint distribution as input")
+		}
+		j, k := d.joints[n.Inputs[0]], int(p[0])
+		if float64(k) != p[0] || k < 0 || k >= len(j.components) {
+			return fmt.Errorf("invalid component %0.7f", p[0])
+		}
+		u = j.components[k]
+	case "arithmetic", "comparison", "not", "conditional", "ifelse", "logic", "function":
+		u, err = d.decodeComposite(n)
+	default:
+		u, err = decodeLeaf(n)
+	}
+	if err != nil {
+		return err
+	}
+	if v, ok := u.(validator); ok {
+		if err := v.validate(); err != nil {
+			return err
+		}
+	}
+	d.nodes[n.ID] = u
+	return nil
+}
+
+func decodeLeaf(n nodeJSON) (Uncertain, error) {
+	switch n.Type {
+	case "empirical":
+		return TryNewEmpirical(n.Values)
+	case "samples":
+		samples := make([]*sample, len(n.Values))
+		for i, v := range n.Values {
+			samples[i] = newSample(v)
+		}
+		return FromSamples(samples), nil
+	case "multinomial":
+		if len(n.Values) == 0 || len(n.Params) != len(n.Values)-1 {
+			return nil, errors.New("multinomial needs one cutoff less than values")
+		}
+		for i, c := range n.Params {
+			if !(c >= 0 && c <= 1) || i > 0 && c < n.Params[i-1] {
+				return nil, fmt.Errorf("invalid multinomial cutoff %0.7f", c)
+			}
+		}
+		return &Multinomial{
+			values:  n.Values,
+			cutoffs: n.Params,
+			i:       newID(),
+		}, nil
+	}
+
+	counts := map[string]int{
+		"bernoulli":   1,
+		"beta":        2,
+		"binomial":    2,
+		"constant":    1,
+		"exponential": 1,
+		"gamma":       2,
+		"gaussian":    2,
+		"lognormal":   2,
+		"poisson":     1,
+		"studentt":    3,
+		"triangular":  3,
+		"uniform":     2,
+	}
+	count, ok := counts[n.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown node type %q", n.Type)
+	}
+	p, err := params(n, count)
+	if err != nil {
+		return nil, err
+	}
+
+	// The nodes are rebuilt from their fields, so that they sample exactly
+	// like the encoded ones, and then validated.
+	id := newID()
+	switch n.Type {
+	case "bernoulli":
+		return &Bernoulli{probability: p[0], i: id}, nil
+	case "beta":
+		return &Beta{alpha: p[0], beta: p[1], i: id}, nil
+	case "binomial":
+		if p[0] != math.Trunc(p[0]) || math.Abs(p[0]) > math.MaxInt32 {
+			return nil, fmt.Errorf("number of trials of a binomial must be an integer, got %0.7f", p[0])
+		}
+		return &Binomial{n: int(p[0]), probability: p[1], i: id}, nil
+	case "constant":
+		return &Constant{val: p[0], i: id}, nil
+	case "exponential":
+		return &Exponential{rate: p[0], i: id}, nil
+	case "gamma":
+		return &Gamma{shape: p[0], scale: p[1], i: id}, nil
+	case "gaussian":
+		return &Gaussian{mean: p[0], stddev: p[1], i: id}, nil
+	case "lognormal":
+		return &LogNormal{mu: p[0], sigma: p[1], i: id}, nil
+	case "poisson":
+		return &Poisson{lambda: p[0], i: id}, nil
+	case "studentt":
+		return &StudentT{df: p[0], loc: p[1], scale: p[2], i: id}, nil
+	case "triangular":
+		return &Triangular{lo: p[0], mode: p[1], hi: p[2], i: id}, nil
+	default:
+		return &Uniform{lo: p[0], size: p[1], i: id}, nil
+	}
+}
+
+func (d *graphDecoder) decodeComposite(n nodeJSON) (Uncertain, error) {
+	switch n.Type {
+	case "arithmetic":
+		op, ok := arithmeticOps[n.Op]
+		if !ok {
+			return nil, fmt.Errorf("unknown arithmetic operation %q", n.Op)
+		}
+		in, err := d.inputs(n, 2)
+		if err != nil {
+			return nil, err
+		}
+		return op(in[0], in[1]), nil
+	case "comparison":
+		op, ok := comparisonOps[n.Op]
+		if !ok {
+			return nil, fmt.Errorf("unknown comparison %q", n.Op)
+		}
+		in, err := d.inputs(n, 2)
+		if err != nil {
+			return nil, err
+		}
+		return op(in[0], in[1]), nil
+	case "logic":
+		op, ok := logicOps[n.Op]
+		if !ok {
+			return nil, fmt.Errorf("unknown logic operation %q", n.Op)
+		}
+		in, err := d.boolInputs(n, 2)
+		if err != nil {
+			return nil, err
+		}
+		return op(in[0], in[1]), nil
+	case "function":
+		return d.decodeFunction(n)
+	case "not":
+		in, err := d.boolInputs(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		return Not(in[0]), nil
+	case "conditional":
+		p, err := params(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(n.Inputs) != 2 {
+			return nil, fmt.Errorf("conditional needs 2 inputs, got %d", len(n.Inputs))
+		}
+		cond, err := d.boolInputs(nodeJSON{ID: n.ID, Type: n.Type, Inputs: n.Inputs[:1]}, 1)
+		if err != nil {
+			return nil, err
+		}
+		in, err := d.inputs(n, 2)
+		if err != nil {
+			return nil, err
+		}
+		return ProbGivenCondition(in[1], cond[0], MaxAttempts(int(p[0]))), nil
+	default:
+		in, err := d.inputs(n, 3)
+		if err != nil {
+			return nil, err
+		}
+		test, ok := in[0].(UncertainBool)
+		if !ok {
+			return nil, fmt.Errorf("input %d of ifelse is not boolean", n.Inputs[0])
+		}
+		return IfElse(test, in[1], in[2]), nil
+	}
+}
+
+func (d *graphDecoder) decodeFunction(n nodeJSON) (Uncertain, error) {
+	if f, ok := unaryFunctions[n.Op]; ok {
+		in, err := d.inputs(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		return f(in[0]), nil
+	}
+	count := -1
+	if n.Op == "pow" {
+		count = 2
+	}
+	in, err := d.inputs(n, count)
+	if err != nil {
+		return nil, err
+	}
+	switch n.Op {
+	case "pow":
+		return Pow(in[0], in[1]), nil
+	case "sum":
+		return Sum(in...), nil
+	case "min", "max":
+		if len(in) == 0 {
+			return nil, fmt.Errorf("%s needs inputs", n.Op)
+		}
+		if n.Op == "min" {
+			return Min(in...), nil
+		}
+		return Max(in...), nil
+	}
+	return nil, fmt.Errorf("unknown function %q", n.Op)
+}
+
+func (d *graphDecoder) decodeJoint(n nodeJSON) (*joint, error) {
+	if n.Type == "multivariate_normal" {
+		m, err := TryNewMultivariateNormal(n.Values, n.Matrix)
+		if err != nil {
+			return nil, err
+		}
+		return m.joint, nil
+	}
+
+	marginals, err := d.inputs(n, -1)
+	if err != nil {
+		return nil, err
+	}
+	l, err := copulaCholesky(len(marginals), n.Matrix)
+	if err != nil {
+		return nil, err
+	}
+	tables := n.Tables
+	if tables == nil {
+		tables = make([][]float64, len(marginals))
+	}
+	if len(tables) != len(marginals) {
+		return nil, fmt.Errorf("gaussian copula needs a table for each marginal, got %d", len(tables))
+	}
+	for k, m := range marginals {
+		if _, ok := m.(quantiler); !ok && len(tables[k]) == 0 {
+			return nil, fmt.Errorf("marginal %d of gaussian copula needs a table", k)
+		}
+	}
+	return newGaussianCopula(marginals, n.Matrix, tables, l).joint, nil
+}
@@ -0,0 +1,97 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMarshalGraph(t *testing.T) {
+	mvn := NewMultivariateNormal([]float64{0, 1}, [][]float64{{1, 0.5}, {0.5, 2}})
+	copula := NewGaussianCopula([]Uncertain{NewExponential(1), NewPoisson(3)}, [][]float64{{1, 0.7}, {0.7, 1}})
+	x := Add(Mul(NewGaussian(1, 2), NewUniform(0, 3)), Sub(mvn.Component(0), mvn.Component(1)))
+	cond := And(GreaterThan(x, NewConstant(0)), Not(LessThan(NewBeta(2, 3), NewConstant(0.1))))
+	y := IfElse(
+		Or(NewBernoulli(0.3), Equals(NewDice(6), NewConstant(6))),
+		Div(copula.Component(0), NewTriangular(1, 2, 4)),
+		Add(copula.Component(1), NewBinomial(10, 0.4)),
+	)
+	z := ProbGivenCondition(x, cond, MaxAttempts(1000))
+	w := Add(Add(NewGamma(2, 1), NewStudentT(3, 0, 1)), Add(NewLogNormal(0, 0.5), NewEmpirical([]float64{1, 2, 3})))
+	v := Add(Materialize(NewGaussian(0, 1), 100, Seed(3)), NotEquals(NewConstant(1), NewConstant(2)).(Uncertain))
+
+	roots := []Uncertain{x, cond.(Uncertain), y, z, w, v}
+	data, err := MarshalGraph(roots...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := MarshalGraph(roots...)
+	if err != nil || string(again) != string(data) {
+		t.Errorf("encoding is not stable")
+	}
+
+	g, err := UnmarshalGraph(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Roots) != len(roots) {
+		t.Fatalf("decoded %d roots", len(g.Roots))
+	}
+	for i, root := range roots {
+		want := sampleValues(Materialize(root, 200, Seed(int64(i))))
+		got := sampleValues(Materialize(g.Roots[i], 200, Seed(int64(i))))
+		if !equalValues(want, got) {
+			t.Errorf("root %d samples %v, want %v", i, got[:5], want[:5])
+		}
+	}
+
+	// The shared components of the normal are encoded once.
+	redecoded, err := MarshalGraph(g.Roots...)
+	if err != nil || string(redecoded) != string(data) {
+		t.Errorf("decoded graph encodes differently")
+	}
+}
+
+func TestUnmarshalGraphErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"version":     `{"version":2,"roots":[],"nodes":[]}`,
+		"type":        `{"version":1,"roots":[1],"nodes":[{"id":1,"type":"cauchy","params":[0,1]}]}`,
+		"parameters":  `{"version":1,"roots":[1],"nodes":[{"id":1,"type":"gaussian","params":[0,-1]}]}`,
+		"count":       `{"version":1,"roots":[1],"nodes":[{"id":1,"type":"uniform","params":[0]}]}`,
+		"input":       `{"version":1,"roots":[1],"nodes":[{"id":1,"type":"arithmetic","op":"+","inputs":[1,2]}]}`,
+		"operation":   `{"version":1,"roots":[3],"nodes":[{"id":1,"type":"constant","params":[1]},{"id":2,"type":"constant","params":[2]},{"id":3,"type":"arithmetic","op":"%","inputs":[1,2]}]}`,
+		"not boolean": `{"version":1,"roots":[2],"nodes":[{"id":1,"type":"constant","params":[1]},{"id":2,"type":"not","inputs":[1]}]}`,
+		"root":        `{"version":1,"roots":[2],"nodes":[{"id":1,"type":"constant","params":[1]}]}`,
+		"json":        `{"version":1,`,
+	} {
+		if _, err := UnmarshalGraph([]byte(data)); !errors.Is(err, ErrInvalidGraph) {
+			t.Errorf("%s returned %v", name, err)
+		}
+	}
+
+	var out graphJSON
+	data, _ := MarshalGraph(NewUniform(1, 2))
+	if err := json.Unmarshal(data, &out); err != nil || out.Version != GraphVersion {
+		t.Errorf("encoded version %d, %v", out.Version, err)
+	}
+}
@@ -0,0 +1,368 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+var (
+	// ErrMaxAttempts is returned when rejection sampling cannot satisfy
+	// the condition within MaxAttempts draws.
+	ErrMaxAttempts = errors.New("probability: rejection sampling exceeded the maximum number of attempts")
+	// ErrNoEvidence is returned when no sample is consistent with the
+	// observations.
+	ErrNoEvidence = errors.New("probability: no sample is consistent with the observations")
+	// ErrNotInTrace is returned when the input is not part of the trace
+	// of the observations, so they cannot inform it.
+	ErrNotInTrace = errors.New("probability: input is not in the trace of the observations")
+)
+
+// Observation is evidence about a node of the graph. It weighs every
+// joint sample by the log likelihood of the value of the node.
+type Observation struct {
+	node          Uncertain
+	logLikelihood func(value float64) float64
+}
+
+// Observe returns soft evidence about node, given by the log likelihood of
+// the observed data for a value of the node.
+func Observe(node Uncertain, logLikelihood func(value float64) float64) Observation {
+	return Observation{
+		node:          node,
+		logLikelihood: logLikelihood,
+	}
+}
+
+// ObserveTrue returns the hard evidence that condition is true.
+func ObserveTrue(condition UncertainBool) Observation {
+	return Observe(condition, func(value float64) float64 {
+		if convertFloatSampleToBool(value) {
+			return 0
+		}
+		return math.Inf(-1)
+	})
+}
+
+// Posterior is a sample of the distribution of an input given
+// observations. Like Samples, it can be used in further graphs.
+type Posterior struct {
+	*Samples
+	// Mean is the posterior mean. Importance sampling estimates it from
+	// the weighted draws, the other methods from the samples.
+	Mean float64
+	// ESS is the effective sample size of the samples.
+	ESS float64
+	// AcceptanceRate is the fraction of accepted proposals of
+	// Metropolis-Hastings, and of draws satisfying the condition in
+	// rejection sampling.
+	AcceptanceRate float64
+}
+
+// model is the joint evaluation of an input and observations
+type model struct {
+	input        Uncertain
+	observations []Observation
+}
+
+// run draws the observed nodes in one draw of the graph, so that nodes
+// they share have one value, and returns the value of the input with its
+// log weight. It stops early once the weight is zero, and then returns a
+// nil sample.
+func (m model) run(r *draw) (*sample, float64, error) {
+	s := newSample(0)
+	logWeight := 0.0
+	found := false
+	for _, o := range m.observations {
+		os := o.node.sampleWithTrace(r)
+		for k, v := range os.trace {
+			s.addTrace(k, v)
+		}
+		if o.node == m.input {
+			s.value = os.value
+			found = true
+		}
+		logWeight += o.logLikelihood(os.value)
+		if math.IsInf(logWeight, -1) || math.IsNaN(logWeight) {
+			return nil, math.Inf(-1), nil
+		}
+	}
+	if !found {
+		v, ok := s.trace[m.input.id()]
+		if !ok {
+			return nil, 0, ErrNotInTrace
+		}
+		s.value = v
+	}
+	return s, logWeight, nil
+}
+
+// posteriorMean is the mean of the values of samples
+func posteriorMean(samples []*sample) float64 {
+	mean := 0.0
+	for i, s := range samples {
+		mean += (s.value - mean) / float64(i+1)
+	}
+	return mean
+}
+
+// RejectionSampling draws SampleSize samples (1000 by default) of input
+// given that condition is true. It returns ErrMaxAttempts if a sample
+// takes more than MaxAttempts draws (100,000 by default).
+func RejectionSampling(input Uncertain, condition UncertainBool, opts ...Option) (*Posterior, error) {
+	r := newDraw(newRand(opts))
+	n := getSampleSize(opts, 1000)
+	maxAttempts := getMaxAttempts(opts, 100_000)
+	m := model{
+		input:        input,
+		observations: []Observation{ObserveTrue(condition)},
+	}
+
+	samples := make([]*sample, 0, n)
+	draws := 0
+	for len(samples) < n {
+		for attempts := 0; ; attempts++ {
+			if attempts == maxAttempts {
+				return nil, ErrMaxAttempts
+			}
+			s, _, err := m.run(r.next())
+			draws++
+			if err != nil {
+				return nil, err
+			}
+			if s != nil {
+				samples = append(samples, s)
+				break
+			}
+		}
+	}
+	return &Posterior{
+		Samples:        FromSamples(samples),
+		Mean:           posteriorMean(samples),
+		ESS:            float64(n),
+		AcceptanceRate: float64(n) / float64(draws),
+	}, nil
+}
+
+// ImportanceSampling samples input given the observations by likelihood
+// weighting. It draws SampleSize joint samples (1000 by default) from the
+// prior, weighs them by the likelihood of the observations and resamples
+// them systematically. The ESS is that of the weights. It returns
+// ErrNoEvidence if all weights are zero.
+func ImportanceSampling(input Uncertain, observations []Observation, opts ...Option) (*Posterior, error) {
+	r := newDraw(newRand(opts))
+	n := getSampleSize(opts, 1000)
+	m := model{
+		input:        input,
+		observations: observations,
+	}
+
+	draws := make([]*sample, n)
+	weights := make([]float64, n)
+	maxLogWeight := math.Inf(-1)
+	for i := range draws {
+		s, logWeight, err := m.run(r.next())
+		if err != nil {
+			return nil, err
+		}
+		draws[i] = s
+		weights[i] = logWeight
+		maxLogWeight = math.Max(maxLogWeight, logWeight)
+	}
+	if math.IsInf(maxLogWeight, -1) {
+		return nil, ErrNoEvidence
+	}
+
+	total := 0.0
+	for i, w := range weights {
+		weights[i] = math.Exp(w - maxLogWeight)
+		total += weights[i]
+	}
+	mean, sumSquares := 0.0, 0.0
+	for i, w := range weights {
+		weights[i] = w / total
+		sumSquares += weights[i] * weights[i]
+		if weights[i] > 0 {
+			mean += weights[i] * draws[i].value
+		}
+	}
+
+	// Systematic resampling takes the draw under each of n evenly spaced
+	// points of the cumulative weights.
+	samples := make([]*sample, n)
+	u := r.Float64() / float64(n)
+	last := 0
+	cumulative := weights[0]
+	for j := range samples {
+		point := u + float64(j)/float64(n)
+		for (cumulative < point || weights[last] == 0) && last < n-1 {
+			last++
+			cumulative += weights[last]
+		}
+		for weights[last] == 0 {
+			last--
+		}
+		samples[j] = draws[last]
+	}
+
+	return &Posterior{
+		Samples: FromSamples(samples),
+		Mean:    mean,
+		ESS:     1 / sumSquares,
+	}, nil
+}
+
+// tapeSource records the random numbers consumed by a run of a model and
+// replays them, so a run can be repeated with some of them changed.
+type tapeSource struct {
+	tape []int64
+	pos  int
+	src  *rand.Rand
+}
+
+func (t *tapeSource) Int63() int64 {
+	if t.pos == len(t.tape) {
+		t.tape = append(t.tape, t.src.Int63())
+	}
+	v := t.tape[t.pos]
+	t.pos++
+	return v
+}
+
+func (t *tapeSource) Seed(seed int64) {}
+
+// replay runs the model on the tape from the start and truncates the tape
+// to the numbers it used.
+func (t *tapeSource) replay(m model) (*sample, float64, error) {
+	t.pos = 0
+	s, logWeight, err := m.run(newDraw(rand.New(t)))
+	t.tape = t.tape[:t.pos]
+	return s, logWeight, err
+}
+
+// MetropolisHastings samples input given the observations with a Markov
+// chain over the random numbers consumed by a run of the graph. Every
+// step redraws one of them and accepts the new run by its likelihood
+// ratio, corrected for the change in the count of numbers used. The
+// chain starts from a prior sample consistent with the observations,
+// found within MaxAttempts draws (100,000 by default), takes BurnIn steps
+// (SampleSize/10 by default) and then keeps SampleSize samples (1000 by
+// default). The ESS accounts for the autocorrelation of the chain.
+func MetropolisHastings(input Uncertain, observations []Observation, opts ...Option) (*Posterior, error) {
+	r := newRand(opts)
+	n := getSampleSize(opts, 1000)
+	burnIn := getBurnIn(opts, n/10)
+	maxAttempts := getMaxAttempts(opts, 100_000)
+	m := model{
+		input:        input,
+		observations: observations,
+	}
+
+	current := &tapeSource{src: r}
+	var s *sample
+	var logWeight float64
+	for attempts := 0; s == nil; attempts++ {
+		if attempts == maxAttempts {
+			return nil, ErrMaxAttempts
+		}
+		current.tape = current.tape[:0]
+		var err error
+		s, logWeight, err = current.replay(m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	proposal := &tapeSource{src: r}
+	samples := make([]*sample, 0, n)
+	accepted := 0
+	for step := 0; step < burnIn+n; step++ {
+		if used := len(current.tape); used > 0 {
+			proposal.tape = append(proposal.tape[:0], current.tape...)
+			proposal.tape[r.Intn(used)] = r.Int63()
+			ps, pLogWeight, err := proposal.replay(m)
+			if err != nil {
+				return nil, err
+			}
+			if ps != nil {
+				logAccept := pLogWeight - logWeight + math.Log(float64(used)) - math.Log(float64(len(proposal.tape)))
+				if math.Log(r.Float64()) < logAccept {
+					current, proposal = proposal, current
+					s, logWeight = ps, pLogWeight
+					accepted++
+				}
+			}
+		}
+		if step >= burnIn {
+			samples = append(samples, s)
+		}
+	}
+
+	values := make([]float64, n)
+	for i, v := range samples {
+		values[i] = v.value
+	}
+	return &Posterior{
+		Samples:        FromSamples(samples),
+		Mean:           posteriorMean(samples),
+		ESS:            chainESS(values),
+		AcceptanceRate: float64(accepted) / float64(burnIn+n),
+	}, nil
+}
+
+// chainESS estimates the effective sample size of a Markov chain with
+// Geyer's initial positive sequence of autocorrelations.
+func chainESS(x []float64) float64 {
+	n := len(x)
+	if n < 2 {
+		return float64(n)
+	}
+	mean := 0.0
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(n)
+
+	autocovariance := func(lag int) float64 {
+		sum := 0.0
+		for i := 0; i+lag < n; i++ {
+			sum += (x[i] - mean) * (x[i+lag] - mean)
+		}
+		return sum / float64(n)
+	}
+	c0 := autocovariance(0)
+	if c0 == 0 {
+		// The chain never moved
+		return 1
+	}
+
+	tau := -1.0
+	for lag := 0; lag+1 < n; lag += 2 {
+		pair := (autocovariance(lag) + autocovariance(lag+1)) / c0
+		if pair <= 0 {
+			break
+		}
+		tau += 2 * pair
+	}
+	return float64(n) / tau
+}
@@ -0,0 +1,200 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+)
+
+// normalPosterior is a normal prior on the mean of normal observations
+// with unit variance. The posterior has mean 5.5/5.25 and variance 1/5.25.
+func normalPosterior() (Uncertain, []Observation) {
+	mu := NewGaussian(0, 2)
+	data := []float64{1.2, 0.8, 1.5, 1.1, 0.9}
+	return mu, []Observation{
+		Observe(mu, func(m float64) float64 {
+			ll := 0.0
+			for _, x := range data {
+				ll -= (x - m) * (x - m) / 2
+			}
+			return ll
+		}),
+	}
+}
+
+func posteriorVariance(p *Posterior) float64 {
+	variance := 0.0
+	for _, s := range p.Samples.Samples {
+		variance += (s.value - p.Mean) * (s.value - p.Mean)
+	}
+	return variance / float64(len(p.Samples.Samples))
+}
+
+func TestImportanceSampling(t *testing.T) {
+	mu, observations := normalPosterior()
+	p, err := ImportanceSampling(mu, observations, SampleSize(50_000), Seed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Samples.Samples) != 50_000 || !Within(p.Mean, 5.5/5.25, 0.02) || !Within(p.Average(), p.Mean, 0.02) {
+		t.Errorf("Posterior mean %f, resampled %f", p.Mean, p.Average())
+	}
+	if !Within(posteriorVariance(p), 1/5.25, 0.02) {
+		t.Errorf("Posterior variance %f", posteriorVariance(p))
+	}
+	// The prior is far wider than the posterior, so most weights are small
+	if p.ESS < 1000 || p.ESS > 25_000 {
+		t.Errorf("ESS %f", p.ESS)
+	}
+
+	q, _ := ImportanceSampling(mu, observations, SampleSize(50_000), Seed(1))
+	if q.Mean != p.Mean || !equalValues(sampleValues(q.Samples), sampleValues(p.Samples)) {
+		t.Error("ImportanceSampling with the same seed gave different results")
+	}
+}
+
+func TestMetropolisHastings(t *testing.T) {
+	mu, observations := normalPosterior()
+	p, err := MetropolisHastings(mu, observations, SampleSize(50_000), Seed(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Samples.Samples) != 50_000 || !Within(p.Mean, 5.5/5.25, 0.03) {
+		t.Errorf("Posterior mean %f", p.Mean)
+	}
+	if !Within(posteriorVariance(p), 1/5.25, 0.02) {
+		t.Errorf("Posterior variance %f", posteriorVariance(p))
+	}
+	if p.AcceptanceRate <= 0 || p.AcceptanceRate >= 1 || p.ESS < 100 || p.ESS >= 50_000 {
+		t.Errorf("Acceptance rate %f, ESS %f", p.AcceptanceRate, p.ESS)
+	}
+
+	// The posterior can be used in further graphs
+	m := ExpectedValueWithConfidence(Add(p, NewConstant(1)), Seed(2))
+	if !Within(m.Mean, 1+5.5/5.25, 0.1) {
+		t.Errorf("Posterior in a graph gave %v", m)
+	}
+}
+
+func TestSeveralObservations(t *testing.T) {
+	// Two observations of 1 with standard deviation 0.5 on a standard
+	// normal prior give a posterior with mean 8/9 and variance 1/9. The
+	// observations share mu, so it must have one value per run.
+	mu := NewGaussian(0, 1)
+	observe := func() Observation {
+		return Observe(mu, func(m float64) float64 {
+			return -(1 - m) * (1 - m) / (2 * 0.25)
+		})
+	}
+	observations := []Observation{observe(), observe()}
+
+	importance, err := ImportanceSampling(mu, observations, SampleSize(50_000), Seed(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mh, err := MetropolisHastings(mu, observations, SampleSize(50_000), Seed(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, p := range map[string]*Posterior{"importance": importance, "mh": mh} {
+		if !Within(p.Mean, 8.0/9, 0.02) {
+			t.Errorf("%s sampling gave mean %f, want %f", name, p.Mean, 8.0/9)
+		}
+		if !Within(posteriorVariance(p), 1.0/9, 0.01) {
+			t.Errorf("%s sampling gave variance %f, want %f", name, posteriorVariance(p), 1.0/9)
+		}
+	}
+}
+
+func TestBurglaryPosterior(t *testing.T) {
+	earthquake := Flip(0.001)
+	burglary := Flip(0.01)
+	alarm := Or(earthquake, burglary)
+	maryWakes := IfElse(alarm, Flip(0.6), Flip(0.2)).ToBool()
+
+	// Mary wakes with probability 0.6 after a burglary, so by Bayes' rule
+	// P(burglary | maryWakes) = 0.01*0.6 / P(maryWakes)
+	pAlarm := 1 - 0.999*0.99
+	pBurglary := 0.01 * 0.6 / (pAlarm*0.6 + (1-pAlarm)*0.2)
+
+	rejection, err := RejectionSampling(burglary, maryWakes, SampleSize(20_000), Seed(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	importance, err := ImportanceSampling(burglary, []Observation{ObserveTrue(maryWakes)}, SampleSize(100_000), Seed(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mh, err := MetropolisHastings(burglary, []Observation{ObserveTrue(maryWakes)}, SampleSize(100_000), Seed(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, p := range map[string]*Posterior{"rejection": rejection, "importance": importance, "mh": mh} {
+		if !Within(p.Mean, pBurglary, 0.01) {
+			t.Errorf("%s sampling gave %f, want %f", name, p.Mean, pBurglary)
+		}
+	}
+	if !Within(rejection.AcceptanceRate, pAlarm*0.6+(1-pAlarm)*0.2, 0.01) {
+		t.Errorf("Rejection acceptance rate %f", rejection.AcceptanceRate)
+	}
+}
+
+func TestRejectionBudget(t *testing.T) {
+	x := NewGaussian(0, 1)
+	rare := GreaterThan(x, NewConstant(8))
+
+	if _, err := RejectionSampling(x, rare, MaxAttempts(1000), Seed(4)); err != ErrMaxAttempts {
+		t.Errorf("RejectionSampling returned %v", err)
+	}
+	if _, err := MetropolisHastings(x, []Observation{ObserveTrue(rare)}, MaxAttempts(1000), Seed(4)); err != ErrMaxAttempts {
+		t.Errorf("MetropolisHastings returned %v", err)
+	}
+	if _, err := ImportanceSampling(x, []Observation{ObserveTrue(rare)}, Seed(4)); err != ErrNoEvidence {
+		t.Errorf("ImportanceSampling returned %v", err)
+	}
+	if _, err := ImportanceSampling(NewUniform(0, 1), []Observation{ObserveTrue(Not(rare))}, Seed(4)); err != ErrNotInTrace {
+		t.Errorf("ImportanceSampling of an unrelated input returned %v", err)
+	}
+
+	defer func() {
+		if r := recover(); r != ErrMaxAttempts {
+			t.Errorf("ProbGivenCondition panicked with %v", r)
+		}
+	}()
+	ProbGivenCondition(x, rare, MaxAttempts(1000)).sample(newDraw(newRand([]Option{Seed(4)})))
+}
+
+func TestChainESS(t *testing.T) {
+	x := sampleValues(Materialize(NewGaussian(0, 1), 10_000, Seed(5)))
+	if ess := chainESS(x); !Within(ess, 10_000, 2000) {
+		t.Errorf("ESS of independent samples %f", ess)
+	}
+
+	// An AR(1) chain with coefficient 0.9 has an ESS of n*0.1/1.9
+	ar := make([]float64, len(x))
+	for i := 1; i < len(x); i++ {
+		ar[i] = 0.9*ar[i-1] + math.Sqrt(1-0.81)*x[i]
+	}
+	if ess := chainESS(ar); !Within(ess, 10_000*0.1/1.9, 200) {
+		t.Errorf("ESS of an AR(1) chain %f", ess)
+	}
+}
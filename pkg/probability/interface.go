@@ -22,19 +22,56 @@ package probability
 
 import (
 	"fmt"
+	"math/rand"
 	"sync"
 )
 
 type Uncertain interface {
-	sample() float64
-	sampleWithTrace() *sample
+	sample(r *draw) float64
+	sampleWithTrace(r *draw) *sample
 	id() int
 }
 
 type UncertainBool interface {
 	Uncertain
-	sampleBool() bool
-	Pr() bool
+	sampleBool(r *draw) bool
+	Pr(opts ...Option) bool
+}
+
+// draw is one evaluation of a graph: its random number generator and the
+// values of the random nodes drawn so far. A node reached on several paths
+// of the graph, e.g. x in Sub(x, x), has one value per draw.
+type draw struct {
+	*rand.Rand
+	values map[int]float64
+}
+
+func newDraw(r *rand.Rand) *draw {
+	return &draw{
+		Rand:   r,
+		values: make(map[int]float64),
+	}
+}
+
+// next forgets the values of the draw to start the next one on the same
+// generator.
+func (d *draw) next() *draw {
+	for id := range d.values {
+		delete(d.values, id)
+	}
+	return d
+}
+
+// lookup returns the value of node id if it was drawn already
+func (d *draw) lookup(id int) (float64, bool) {
+	v, ok := d.values[id]
+	return v, ok
+}
+
+// store records the value of node id and returns it
+func (d *draw) store(id int, v float64) float64 {
+	d.values[id] = v
+	return v
 }
 
 type sample struct {
@@ -64,6 +101,14 @@ func (s *sample) combine(other *sample) *sample {
 	return out
 }
 
+func (s *sample) clone() *sample {
+	out := newSample(s.value)
+	for k, v := range s.trace {
+		out.addTrace(k, v)
+	}
+	return out
+}
+
 func (s *sample) String() string {
 	return fmt.Sprintf("%0.4f : %#v", s.value, s.trace)
 }
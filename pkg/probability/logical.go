@@ -29,20 +29,20 @@ func Not(booldist UncertainBool) UncertainBool {
 	return &notOperation{booldist, newID()}
 }
 
-func (not *notOperation) Pr() bool {
-	return Pr(not)
+func (not *notOperation) Pr(opts ...Option) bool {
+	return Pr(not, opts...)
 }
 
-func (not *notOperation) sampleBool() bool {
-	return !not.b.sampleBool()
+func (not *notOperation) sampleBool(r *draw) bool {
+	return !not.b.sampleBool(r)
 }
 
-func (not *notOperation) sample() float64 {
-	return convertBoolSampleToFloat(not.sampleBool())
+func (not *notOperation) sample(r *draw) float64 {
+	return convertBoolSampleToFloat(not.sampleBool(r))
 }
 
-func (not *notOperation) sampleWithTrace() *sample {
-	s := not.b.sampleWithTrace()
+func (not *notOperation) sampleWithTrace(r *draw) *sample {
+	s := not.b.sampleWithTrace(r)
 	s.value = 1.0 - s.value
 	s.trace[not.i] = s.value
 	return s
@@ -55,40 +55,42 @@ func (not *notOperation) id() int {
 type logicOperation struct {
 	a, b UncertainBool
 	i    int
+	name string
 	op   func(a, b bool) bool
 }
 
 func Or(a, b UncertainBool) UncertainBool {
-	return newLogicOperation(a, b, func(a, b bool) bool {
+	return newLogicOperation(a, b, "or", func(a, b bool) bool {
 		return a || b
 	})
 }
 
 func And(a, b UncertainBool) UncertainBool {
-	return newLogicOperation(a, b, func(a, b bool) bool {
+	return newLogicOperation(a, b, "and", func(a, b bool) bool {
 		return a && b
 	})
 }
 
-func newLogicOperation(a, b UncertainBool, f func(a, b bool) bool) *logicOperation {
+func newLogicOperation(a, b UncertainBool, name string, f func(a, b bool) bool) *logicOperation {
 	return &logicOperation{
-		a:  a,
-		b:  b,
-		i:  newID(),
-		op: f,
+		a:    a,
+		b:    b,
+		i:    newID(),
+		name: name,
+		op:   f,
 	}
 }
 
-func (l *logicOperation) sampleBool() bool {
-	return convertFloatSampleToBool(l.sample())
+func (l *logicOperation) sampleBool(r *draw) bool {
+	return convertFloatSampleToBool(l.sample(r))
 }
 
-func (l *logicOperation) sample() float64 {
-	return l.sampleWithTrace().value
+func (l *logicOperation) sample(r *draw) float64 {
+	return l.sampleWithTrace(r).value
 }
 
-func (l *logicOperation) sampleWithTrace() *sample {
-	atrace := l.a.sampleWithTrace()
+func (l *logicOperation) sampleWithTrace(r *draw) *sample {
+	atrace := l.a.sampleWithTrace(r)
 	if v, ok := atrace.trace[l.b.id()]; ok {
 		s := l.op(
 			convertFloatSampleToBool(atrace.value),
@@ -98,11 +100,17 @@ func (l *logicOperation) sampleWithTrace() *sample {
 		atrace.addTrace(l.i, atrace.value)
 		return atrace
 	}
-	btrace := l.b.sampleWithTrace()
-	if _, ok := btrace.trace[l.a.id()]; ok {
-		// We're dependent in the other direction. Let's optimize
-		l.a, l.b = l.b, l.a
-		return l.sampleWithTrace()
+	btrace := l.b.sampleWithTrace(r)
+	if v, ok := btrace.trace[l.a.id()]; ok {
+		// We're dependent in the other direction, so a takes its value
+		// from the sample of b.
+		s := l.op(
+			convertFloatSampleToBool(v),
+			convertFloatSampleToBool(btrace.value),
+		)
+		btrace.value = convertBoolSampleToFloat(s)
+		btrace.addTrace(l.i, btrace.value)
+		return btrace
 	}
 	combined := atrace.combine(btrace)
 	s := l.op(
@@ -114,10 +122,18 @@ func (l *logicOperation) sampleWithTrace() *sample {
 	return combined
 }
 
-func (l *logicOperation) Pr() bool {
-	return Pr(l)
+func (l *logicOperation) Pr(opts ...Option) bool {
+	return Pr(l, opts...)
 }
 
 func (l *logicOperation) id() int {
 	return l.i
 }
+
+func (not *notOperation) inputs() []Uncertain {
+	return []Uncertain{not.b}
+}
+
+func (l *logicOperation) inputs() []Uncertain {
+	return []Uncertain{l.a, l.b}
+}
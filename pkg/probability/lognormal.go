@@ -0,0 +1,94 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+
+	"github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+type LogNormal struct {
+	mu, sigma float64
+	i         int
+}
+
+var _ Uncertain = &LogNormal{}
+
+// NewLogNormal creates a log-normal distribution, whose logarithm is
+// normally distributed with mean mu and standard deviation sigma.
+func NewLogNormal(mu, sigma float64) *LogNormal {
+	l, err := TryNewLogNormal(mu, sigma)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// TryNewLogNormal is NewLogNormal returning an error for invalid
+// parameters.
+func TryNewLogNormal(mu, sigma float64) (*LogNormal, error) {
+	if err := checkLogNormal(mu, sigma); err != nil {
+		return nil, err
+	}
+	return &LogNormal{
+		mu:    mu,
+		sigma: sigma,
+		i:     newID(),
+	}, nil
+}
+
+func checkLogNormal(mu, sigma float64) error {
+	if !isFinite(mu) || !isFinite(sigma) || sigma < 0 {
+		return invalidParameter("standard deviation of a log-normal distribution must not be negative, got %0.7f, %0.7f", mu, sigma)
+	}
+	return nil
+}
+
+func (l *LogNormal) validate() error {
+	return checkLogNormal(l.mu, l.sigma)
+}
+
+func (l *LogNormal) sample(r *draw) float64 {
+	if v, ok := r.lookup(l.i); ok {
+		return v
+	}
+	return r.store(l.i, math.Exp(l.mu+l.sigma*r.NormFloat64()))
+}
+
+func (l *LogNormal) sampleWithTrace(r *draw) *sample {
+	val := l.sample(r)
+	s := newSample(val)
+	s.addTrace(l.i, val)
+	return s
+}
+
+func (l *LogNormal) id() int {
+	return l.i
+}
+
+func (l *LogNormal) quantile(p float64) float64 {
+	return math.Exp(l.mu + l.sigma*statistics.NormPpf(p, 0, 1))
+}
+
+func (l *LogNormal) support() interval {
+	return interval{lo: 0, hi: math.Inf(1), open: true}
+}
@@ -29,14 +29,31 @@ type Multinomial struct {
 var _ Uncertain = &Multinomial{}
 
 func NewMultinomial(values []float64, probabilities []float64) *Multinomial {
+	m, err := TryNewMultinomial(values, probabilities)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryNewMultinomial is NewMultinomial returning an error if the values
+// and probabilities differ in length or the probabilities do not add up
+// to 1.0.
+func TryNewMultinomial(values []float64, probabilities []float64) (*Multinomial, error) {
+	if len(values) == 0 || len(values) != len(probabilities) {
+		return nil, invalidParameter("multinomial needs as many probabilities as values, got %d values and %d probabilities", len(values), len(probabilities))
+	}
 	multinomialEpsilon := 0.0001
 	tally := 0.0
 	cutoffs := make([]float64, len(values)-1)
 	for i, p := range probabilities {
+		if err := checkProbability("multinomial", p); err != nil {
+			return nil, err
+		}
 		tally += p
 		if i == len(values)-1 {
 			if !Within(tally, 1.0, multinomialEpsilon) {
-				panic("Sum of probabilities for this multinomial do not add up to 1.0")
+				return nil, invalidParameter("sum of probabilities for this multinomial do not add up to 1.0, got %0.7f", tally)
 			}
 		} else {
 			cutoffs[i] = tally
@@ -46,7 +63,7 @@ func NewMultinomial(values []float64, probabilities []float64) *Multinomial {
 		values:  values,
 		cutoffs: cutoffs,
 		i:       newID(),
-	}
+	}, nil
 }
 
 func NewEvenMultinomial(values []float64) *Multinomial {
@@ -65,23 +82,30 @@ func NewDice(sides int) *Multinomial {
 	return NewEvenMultinomial(vals)
 }
 
-func (m *Multinomial) sample() float64 {
-	r := randFloat64()
+func (m *Multinomial) sample(r *draw) float64 {
+	if v, ok := r.lookup(m.i); ok {
+		return v
+	}
+	u := r.Float64()
 	for i, v := range m.cutoffs {
-		if r < v {
-			return m.values[i]
+		if u < v {
+			return r.store(m.i, m.values[i])
 		}
 	}
-	return m.values[len(m.values)-1]
+	return r.store(m.i, m.values[len(m.values)-1])
 }
 
 func (m *Multinomial) id() int {
 	return m.i
 }
 
-func (m *Multinomial) sampleWithTrace() *sample {
-	val := m.sample()
+func (m *Multinomial) sampleWithTrace(r *draw) *sample {
+	val := m.sample(r)
 	t := newSample(val)
 	t.addTrace(m.i, val)
 	return t
 }
+
+func (m *Multinomial) atoms() []float64 {
+	return m.values
+}
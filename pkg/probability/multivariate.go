@@ -0,0 +1,332 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+// epsilonUnit is the gap below 1 of the largest float64 less than 1
+const epsilonUnit = 0x1p-53
+
+// joint draws vectors of correlated values for its components. A draw of
+// the graph draws the vector once, when the first component is sampled,
+// and its components keep their values for the rest of the draw, so that
+// e.g. Add(x, y) adds the values of one vector.
+type joint struct {
+	draw       func(r *rand.Rand) []float64
+	components []*component
+	// owner is the *MultivariateNormal or *GaussianCopula of the joint
+	owner interface{}
+}
+
+func newJoint(dim int, draw func(r *rand.Rand) []float64) *joint {
+	j := &joint{
+		draw:       draw,
+		components: make([]*component, dim),
+	}
+	for k := range j.components {
+		j.components[k] = &component{
+			j: j,
+			k: k,
+			i: newID(),
+		}
+	}
+	return j
+}
+
+// component is one dimension of a joint distribution. Its trace holds the
+// values of all components of the draw.
+type component struct {
+	j *joint
+	k int
+	i int
+}
+
+func (c *component) sample(r *draw) float64 {
+	if v, ok := r.lookup(c.i); ok {
+		return v
+	}
+	values := c.j.draw(r.Rand)
+	for k, other := range c.j.components {
+		r.store(other.i, values[k])
+	}
+	return values[c.k]
+}
+
+func (c *component) sampleWithTrace(r *draw) *sample {
+	s := newSample(c.sample(r))
+	for _, other := range c.j.components {
+		v, _ := r.lookup(other.i)
+		s.addTrace(other.i, v)
+	}
+	return s
+}
+
+func (c *component) id() int {
+	return c.i
+}
+
+// cholesky returns the lower triangular L with L*L^T = a, and false if a
+// is not symmetric positive definite.
+func cholesky(a [][]float64) ([][]float64, bool) {
+	n := len(a)
+	l := make([][]float64, n)
+	for i := range l {
+		if len(a[i]) != n {
+			return nil, false
+		}
+		l[i] = make([]float64, i+1)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			if a[i][j] != a[j][i] {
+				return nil, false
+			}
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if !(sum > 0) {
+					return nil, false
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, true
+}
+
+// correlatedNormals draws standard normals correlated by the Cholesky
+// factor l
+func correlatedNormals(r *rand.Rand, l [][]float64) []float64 {
+	z := make([]float64, len(l))
+	for i := range z {
+		z[i] = r.NormFloat64()
+	}
+	out := make([]float64, len(l))
+	for i, row := range l {
+		for k, v := range row {
+			out[i] += v * z[k]
+		}
+	}
+	return out
+}
+
+// MultivariateNormal is a normal distribution of vectors. Its components
+// are correlated Uncertain values.
+type MultivariateNormal struct {
+	mean       []float64
+	covariance [][]float64
+	*joint
+}
+
+// NewMultivariateNormal creates a multivariate normal distribution from
+// the mean and the covariance matrix, which must be symmetric positive
+// definite.
+func NewMultivariateNormal(mean []float64, covariance [][]float64) *MultivariateNormal {
+	m, err := TryNewMultivariateNormal(mean, covariance)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryNewMultivariateNormal is NewMultivariateNormal returning an error
+// for invalid parameters.
+func TryNewMultivariateNormal(mean []float64, covariance [][]float64) (*MultivariateNormal, error) {
+	if len(mean) == 0 || len(covariance) != len(mean) {
+		return nil, invalidParameter("multivariate normal needs as many rows of covariance as means, got %d means and %d rows", len(mean), len(covariance))
+	}
+	for _, v := range mean {
+		if !isFinite(v) {
+			return nil, invalidParameter("means of a multivariate normal must be finite, got %0.7f", v)
+		}
+	}
+	l, ok := cholesky(covariance)
+	if !ok {
+		return nil, invalidParameter("covariance of a multivariate normal must be symmetric positive definite")
+	}
+
+	m := &MultivariateNormal{
+		mean:       append([]float64(nil), mean...),
+		covariance: copyMatrix(covariance),
+	}
+	m.joint = newJoint(len(mean), func(r *rand.Rand) []float64 {
+		out := correlatedNormals(r, l)
+		for i := range out {
+			out[i] += m.mean[i]
+		}
+		return out
+	})
+	m.owner = m
+	return m, nil
+}
+
+// Component returns the k-th dimension of the distribution.
+func (m *MultivariateNormal) Component(k int) Uncertain {
+	return m.components[k]
+}
+
+// Components returns all dimensions of the distribution.
+func (m *MultivariateNormal) Components() []Uncertain {
+	return componentsOf(m.joint)
+}
+
+func copyMatrix(a [][]float64) [][]float64 {
+	out := make([][]float64, len(a))
+	for i, row := range a {
+		out[i] = append([]float64(nil), row...)
+	}
+	return out
+}
+
+func componentsOf(j *joint) []Uncertain {
+	out := make([]Uncertain, len(j.components))
+	for k, c := range j.components {
+		out[k] = c
+	}
+	return out
+}
+
+// quantiler is implemented by the distributions with a quantile function
+type quantiler interface {
+	quantile(p float64) float64
+}
+
+// GaussianCopula joins marginal distributions with the dependence of a
+// multivariate normal distribution.
+type GaussianCopula struct {
+	marginals       []Uncertain
+	rankCorrelation [][]float64
+	// tables are the sorted samples of the marginals without a quantile
+	// function, and nil for the others
+	tables [][]float64
+	*joint
+}
+
+// NewGaussianCopula joins the marginal distributions so that their
+// Spearman rank correlations are given by the matrix rankCorrelation. It
+// must be symmetric with ones on the diagonal and have a positive
+// definite normal equivalent. Marginals without a closed-form quantile
+// function are approximated from SampleSize (10,000 by default) of their
+// samples, drawn with the Seed of the options.
+func NewGaussianCopula(marginals []Uncertain, rankCorrelation [][]float64, opts ...Option) *GaussianCopula {
+	c, err := TryNewGaussianCopula(marginals, rankCorrelation, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// TryNewGaussianCopula is NewGaussianCopula returning an error for invalid
+// parameters.
+func TryNewGaussianCopula(marginals []Uncertain, rankCorrelation [][]float64, opts ...Option) (*GaussianCopula, error) {
+	l, err := copulaCholesky(len(marginals), rankCorrelation)
+	if err != nil {
+		return nil, err
+	}
+	tables := make([][]float64, len(marginals))
+	for k, m := range marginals {
+		if _, ok := m.(quantiler); !ok {
+			tables[k] = Materialize(m, getSampleSize(opts, 10_000), opts...).sorted()
+		}
+	}
+	return newGaussianCopula(marginals, rankCorrelation, tables, l), nil
+}
+
+// copulaCholesky returns the Cholesky factor of the normal correlation
+// with the rank correlation of the n marginals.
+func copulaCholesky(n int, rankCorrelation [][]float64) ([][]float64, error) {
+	if n == 0 || len(rankCorrelation) != n {
+		return nil, invalidParameter("Gaussian copula needs as many rows of correlation as marginals, got %d marginals and %d rows", n, len(rankCorrelation))
+	}
+
+	correlation := make([][]float64, n)
+	for i, row := range rankCorrelation {
+		if len(row) != n || row[i] != 1 {
+			return nil, invalidParameter("row %d of the correlation of a Gaussian copula must have %d entries and a one on the diagonal", i, n)
+		}
+		correlation[i] = make([]float64, n)
+		for k, rho := range row {
+			if !(rho >= -1 && rho <= 1) {
+				return nil, invalidParameter("correlation of a Gaussian copula must be within [-1.0, 1.0], got %0.7f", rho)
+			}
+			correlation[i][k] = 2 * math.Sin(math.Pi*rho/6)
+		}
+	}
+	l, ok := cholesky(correlation)
+	if !ok {
+		return nil, invalidParameter("correlation of a Gaussian copula must be symmetric positive definite")
+	}
+	return l, nil
+}
+
+func newGaussianCopula(marginals []Uncertain, rankCorrelation [][]float64, tables [][]float64, l [][]float64) *GaussianCopula {
+	quantiles := make([]func(p float64) float64, len(marginals))
+	for k, m := range marginals {
+		if q, ok := m.(quantiler); ok {
+			quantiles[k] = q.quantile
+			continue
+		}
+		sorted := tables[k]
+		quantiles[k] = func(p float64) float64 {
+			i := int(p * float64(len(sorted)))
+			if i == len(sorted) {
+				i--
+			}
+			return sorted[i]
+		}
+	}
+
+	c := &GaussianCopula{
+		marginals:       append([]Uncertain(nil), marginals...),
+		rankCorrelation: copyMatrix(rankCorrelation),
+		tables:          tables,
+		joint: newJoint(len(marginals), func(r *rand.Rand) []float64 {
+			out := correlatedNormals(r, l)
+			for k, z := range out {
+				// Keep the tails finite
+				u := math.Min(math.Max(statistics.NormCdf(z, 0, 1), math.SmallestNonzeroFloat64), 1-epsilonUnit)
+				out[k] = quantiles[k](u)
+			}
+			return out
+		}),
+	}
+	c.owner = c
+	return c
+}
+
+// Component returns the marginal k joined by the copula.
+func (c *GaussianCopula) Component(k int) Uncertain {
+	return c.components[k]
+}
+
+// Components returns all marginals joined by the copula.
+func (c *GaussianCopula) Components() []Uncertain {
+	return componentsOf(c.joint)
+}
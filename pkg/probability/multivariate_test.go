@@ -0,0 +1,127 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+// pairs returns the values of x with the values of y from their joint
+// trace
+func pairs(x, y Uncertain, n int, opts ...Option) ([]float64, []float64) {
+	s := Materialize(x, n, opts...)
+	xs, ys := s.Values(), make([]float64, n)
+	for i, v := range s.Samples {
+		ys[i] = v.trace[y.id()]
+	}
+	return xs, ys
+}
+
+func TestMultivariateNormal(t *testing.T) {
+	mvn := NewMultivariateNormal([]float64{1, 2}, [][]float64{{1, 0.8}, {0.8, 2}})
+	x, y := mvn.Component(0), mvn.Component(1)
+
+	if m := Materialize(y, 50_000, Seed(1)); !Within(m.Average(), 2, 0.03) || !Within(m.Variance(), 2, 0.05) {
+		t.Errorf("Marginal mean %f, variance %f", m.Average(), m.Variance())
+	}
+
+	// Arithmetic on the components respects the covariance
+	if v := Materialize(Add(x, y), 50_000, Seed(1)).Variance(); !Within(v, 1+2+2*0.8, 0.1) {
+		t.Errorf("Variance of the sum %f", v)
+	}
+	if v := Materialize(Sub(Mul(x, NewConstant(2)), y), 50_000, Seed(1)).Variance(); !Within(v, 4+2-4*0.8, 0.1) {
+		t.Errorf("Variance of the difference %f", v)
+	}
+	if m := ExpectedValueWithConfidence(Mul(x, y), SampleSize(50_000), Seed(1)); !Within(m.Mean, 0.8+2, 0.05) {
+		t.Errorf("E[xy] %f", m.Mean)
+	}
+	if m := ExpectedValueWithConfidence(Mul(x, y), SampleSize(50_000), Seed(1), Workers(4)); !Within(m.Mean, 0.8+2, 0.05) {
+		t.Errorf("E[xy] evaluated in parallel %f", m.Mean)
+	}
+
+	xs, ys := pairs(x, y, 20_000, Seed(2))
+	if c, _ := statistics.Correlation(xs, ys); !Within(c, 0.8/1.4142, 0.02) {
+		t.Errorf("Correlation in the trace %f", c)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Covariance that is not positive definite did not panic")
+		}
+	}()
+	NewMultivariateNormal([]float64{0, 0}, [][]float64{{1, 2}, {2, 1}})
+}
+
+func TestComponentsUsedTwice(t *testing.T) {
+	mvn := NewMultivariateNormal([]float64{0, 0}, [][]float64{{1, 0.99}, {0.99, 1}})
+	x, y := mvn.Component(0), mvn.Component(1)
+
+	// Both occurrences of a component take the value of the same draw
+	if v := Materialize(Sub(Add(x, y), Mul(NewConstant(2), y)), 50_000, Seed(7)).Variance(); !Within(v, 2-2*0.99, 0.002) {
+		t.Errorf("Var(x + y - 2y) %f", v)
+	}
+	if v := Materialize(Sub(Add(x, x), Add(y, y)), 50_000, Seed(7), Workers(4)).Variance(); !Within(v, 4*(2-2*0.99), 0.008) {
+		t.Errorf("Var(2x - 2y) %f", v)
+	}
+}
+
+func TestGaussianCopula(t *testing.T) {
+	marginals := []Uncertain{
+		NewExponential(1),
+		NewUniform(0, 1),
+		Add(NewUniform(0, 1), NewUniform(0, 1)),
+	}
+	copula := NewGaussianCopula(marginals, [][]float64{
+		{1, 0.6, -0.3},
+		{0.6, 1, 0},
+		{-0.3, 0, 1},
+	}, Seed(3))
+	x, y, z := copula.Component(0), copula.Component(1), copula.Component(2)
+
+	if m := Materialize(x, 50_000, Seed(4)); !Within(m.Average(), 1, 0.03) || !Within(m.Variance(), 1, 0.05) {
+		t.Errorf("Exponential marginal mean %f, variance %f", m.Average(), m.Variance())
+	}
+	if m := Materialize(z, 50_000, Seed(4)); !Within(m.Average(), 1, 0.01) || !Within(m.Variance(), 1.0/6, 0.01) {
+		t.Errorf("Sampled marginal mean %f, variance %f", m.Average(), m.Variance())
+	}
+
+	spearman := func(a, b Uncertain) float64 {
+		as, bs := pairs(a, b, 20_000, Seed(5))
+		ra, _ := statistics.FractionalRank(as)
+		rb, _ := statistics.FractionalRank(bs)
+		c, _ := statistics.Correlation(ra, rb)
+		return c
+	}
+	if c := spearman(x, y); !Within(c, 0.6, 0.02) {
+		t.Errorf("Rank correlation of x and y %f", c)
+	}
+	if c := spearman(x, z); !Within(c, -0.3, 0.02) {
+		t.Errorf("Rank correlation of x and z %f", c)
+	}
+
+	a := sampleValues(Materialize(Add(x, y), 100, Seed(6)))
+	b := sampleValues(Materialize(Add(x, y), 100, Seed(6)))
+	if !equalValues(a, b) {
+		t.Error("Copula with the same seed gave different samples")
+	}
+}
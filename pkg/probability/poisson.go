@@ -0,0 +1,97 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+
+	"github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+type Poisson struct {
+	lambda float64
+	i      int
+}
+
+var _ Uncertain = &Poisson{}
+
+// NewPoisson creates a Poisson distribution of counts with mean lambda.
+func NewPoisson(lambda float64) *Poisson {
+	p, err := TryNewPoisson(lambda)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// TryNewPoisson is NewPoisson returning an error for an invalid mean.
+func TryNewPoisson(lambda float64) (*Poisson, error) {
+	if err := checkPoisson(lambda); err != nil {
+		return nil, err
+	}
+	return &Poisson{
+		lambda: lambda,
+		i:      newID(),
+	}, nil
+}
+
+func checkPoisson(lambda float64) error {
+	if !isFinite(lambda) || lambda < 0 {
+		return invalidParameter("mean of a poisson distribution must not be negative, got %0.7f", lambda)
+	}
+	return nil
+}
+
+func (p *Poisson) validate() error {
+	return checkPoisson(p.lambda)
+}
+
+func (p *Poisson) sample(r *draw) float64 {
+	if v, ok := r.lookup(p.i); ok {
+		return v
+	}
+	if p.lambda >= 30 {
+		return r.store(p.i, statistics.PoissonPpf(openUnit(r.Rand), p.lambda))
+	}
+	k, limit := 0, math.Exp(-p.lambda)
+	for prod := r.Float64(); prod > limit; prod *= r.Float64() {
+		k++
+	}
+	return r.store(p.i, float64(k))
+}
+
+func (p *Poisson) sampleWithTrace(r *draw) *sample {
+	val := p.sample(r)
+	s := newSample(val)
+	s.addTrace(p.i, val)
+	return s
+}
+
+func (p *Poisson) id() int {
+	return p.i
+}
+
+func (p *Poisson) support() interval {
+	if p.lambda == 0 {
+		return interval{lo: 0, hi: 0}
+	}
+	return interval{lo: 0, hi: math.Inf(1)}
+}
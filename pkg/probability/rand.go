@@ -22,29 +22,57 @@ package probability
 
 import (
 	"math/rand"
-	"sync"
+	"sync/atomic"
 	"time"
 )
 
-var (
-	globalRand     *rand.Rand
-	globalRandLock sync.Mutex
-)
+// seedCounter separates the seeds of evaluations that start at the same time
+var seedCounter int64
+
+// newRand returns the random number generator of an evaluation. It uses
+// the RandSource or the Seed and Stream of the options. Without either,
+// it is seeded from the current time. Every evaluation has its own
+// generator, so concurrent evaluations do not share any lock.
+func newRand(opts []Option) *rand.Rand {
+	if src := getRandSource(opts); src != nil {
+		return rand.New(src)
+	}
+
+	return rand.New(rand.NewSource(newSeed(opts)))
+}
+
+// newSeed returns the seed of the stream selected by the options. Without
+// a Seed, it is derived from the RandSource or the current time.
+func newSeed(opts []Option) int64 {
+	seed, ok := getSeed(opts)
+	if !ok {
+		if src := getRandSource(opts); src != nil {
+			return src.Int63()
+		}
+		seed = time.Now().UnixNano() + atomic.AddInt64(&seedCounter, 1)*0x5851f42d4c957f2d
+	}
+	return streamSeed(seed, getStream(opts, 0))
+}
 
-func randFloat64() float64 {
-	globalRandLock.Lock()
-	defer globalRandLock.Unlock()
-	if globalRand == nil {
-		globalRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+// streamSeed derives the seed of the stream from the seed using the
+// finalizer of splitmix64, so neighbouring streams are independent.
+// Stream 0 uses the seed itself.
+func streamSeed(seed int64, stream int) int64 {
+	if stream == 0 {
+		return seed
 	}
-	return globalRand.Float64()
+	z := uint64(seed) + uint64(stream)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
 }
 
-func randNormalFloat64() float64 {
-	globalRandLock.Lock()
-	defer globalRandLock.Unlock()
-	if globalRand == nil {
-		globalRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+// openUnit returns a uniform random variate in (0, 1), whose inverse cdf is
+// within the support of a discrete distribution.
+func openUnit(r *rand.Rand) float64 {
+	for {
+		if u := r.Float64(); u > 0 {
+			return u
+		}
 	}
-	return globalRand.NormFloat64()
 }
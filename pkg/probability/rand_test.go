@@ -0,0 +1,120 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func montyHall() UncertainBool {
+	carInDoor := NewEvenMultinomial([]float64{1.0, 2.0, 3.0})
+	chosenDoor := NewEvenMultinomial([]float64{1.0, 2.0, 3.0})
+	return Not(Equals(carInDoor, chosenDoor))
+}
+
+func sampleValues(s *Samples) []float64 {
+	out := make([]float64, len(s.Samples))
+	for i, v := range s.Samples {
+		out[i] = v.value
+	}
+	return out
+}
+
+func equalValues(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSeed(t *testing.T) {
+	x := Add(NewGaussian(0, 1), NewUniform(0, 1))
+
+	a := sampleValues(Materialize(x, 100, Seed(42)))
+	b := sampleValues(Materialize(x, 100, Seed(42)))
+	if !equalValues(a, b) {
+		t.Error("Materialize with the same seed gave different samples")
+	}
+	c := sampleValues(Materialize(x, 100, Seed(43)))
+	if equalValues(a, c) {
+		t.Error("Materialize with different seeds gave the same samples")
+	}
+
+	d := sampleValues(Materialize(x, 100, RandSource(rand.NewSource(42))))
+	if !equalValues(a, d) {
+		t.Error("RandSource gave different samples than Seed")
+	}
+
+	m1 := ExpectedValueWithConfidence(montyHall(), Seed(7))
+	m2 := ExpectedValueWithConfidence(montyHall(), Seed(7))
+	if m1 != m2 {
+		t.Errorf("ExpectedValueWithConfidence with the same seed gave %v and %v", m1, m2)
+	}
+}
+
+func TestStream(t *testing.T) {
+	x := montyHall()
+
+	run := func() [][]float64 {
+		out := make([][]float64, 8)
+		var wg sync.WaitGroup
+		for i := range out {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				out[i] = sampleValues(Materialize(x, 200, Seed(1), Stream(i)))
+			}(i)
+		}
+		wg.Wait()
+		return out
+	}
+
+	first, second := run(), run()
+	for i := range first {
+		if !equalValues(first[i], second[i]) {
+			t.Errorf("Stream %d is not reproducible", i)
+		}
+		if i > 0 && equalValues(first[i], first[i-1]) {
+			t.Errorf("Streams %d and %d are identical", i-1, i)
+		}
+	}
+
+	// Stream 0 is the stream of the seed itself
+	if !equalValues(first[0], sampleValues(Materialize(x, 200, Seed(1)))) {
+		t.Error("Stream 0 differs from the seed")
+	}
+}
+
+func TestSeedPr(t *testing.T) {
+	x := GreaterThan(NewGaussian(0.1, 1), NewConstant(0))
+	for i := 0; i < 5; i++ {
+		if x.Pr(Seed(5)) != x.Pr(Seed(5)) {
+			t.Fatal("Pr with the same seed gave different results")
+		}
+	}
+}
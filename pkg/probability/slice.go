@@ -24,33 +24,34 @@ import "fmt"
 
 type Samples struct {
 	Samples []*sample
-	n       int
 	i       int
 }
 
 func FromSamples(samples []*sample) *Samples {
 	return &Samples{
 		Samples: samples,
-		n:       0,
 		i:       newID(),
 	}
 }
 
-func (s *Samples) sampleWithTrace() *sample {
+func (s *Samples) sampleWithTrace(r *draw) *sample {
 	if len(s.Samples) == 0 {
 		panic("Must have at least some samples in a sampling distribution")
 	}
-	if s.n >= len(s.Samples) {
-		s.n = 0
+	// Every draw picks one of the samples with its own generator, so the
+	// picks only depend on the seed. The draw keeps the index of the pick,
+	// as the value alone would not give its trace.
+	n, ok := r.lookup(s.i)
+	if !ok {
+		n = r.store(s.i, float64(r.Intn(len(s.Samples))))
 	}
-	out := s.Samples[s.n]
-	s.n += 1
+	out := s.Samples[int(n)].clone()
 	out.addTrace(s.i, out.value)
 	return out
 }
 
-func (s *Samples) sample() float64 {
-	return s.sampleWithTrace().value
+func (s *Samples) sample(r *draw) float64 {
+	return s.sampleWithTrace(r).value
 }
 
 func (s *Samples) addSample(sample *sample) {
@@ -89,12 +90,31 @@ func (s *Samples) String() string {
 	return out
 }
 
-func Materialize(u Uncertain, n int) *Samples {
+// Materialize draws n samples of u. With Workers, they are drawn on
+// parallel workers as in Evaluate and a cancelled Context returns the
+// samples drawn so far. A panic in a worker, e.g. ErrMaxAttempts, is
+// raised again in the calling goroutine.
+func Materialize(u Uncertain, n int, opts ...Option) *Samples {
+	if getWorkers(opts, 0) > 0 {
+		return materializeParallel(u, n, opts)
+	}
+	r := newDraw(newRand(opts))
 	out := &Samples{
 		i: newID(),
 	}
 	for i := 0; i < n; i++ {
-		out.addSample(u.sampleWithTrace())
+		out.addSample(u.sampleWithTrace(r.next()))
 	}
 	return out
 }
+
+func (s *Samples) atoms() []float64 {
+	return s.Values()
+}
+
+func (s *Samples) validate() error {
+	if len(s.Samples) == 0 {
+		return invalidParameter("must have at least some samples in a sampling distribution")
+	}
+	return nil
+}
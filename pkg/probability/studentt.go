@@ -0,0 +1,91 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+type StudentT struct {
+	df, loc, scale float64
+	i              int
+}
+
+var _ Uncertain = &StudentT{}
+
+// NewStudentT creates a Student's t distribution with df degrees of
+// freedom, shifted by loc and stretched by scale.
+func NewStudentT(df, loc, scale float64) *StudentT {
+	t, err := TryNewStudentT(df, loc, scale)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// TryNewStudentT is NewStudentT returning an error for invalid
+// parameters.
+func TryNewStudentT(df, loc, scale float64) (*StudentT, error) {
+	if err := checkStudentT(df, loc, scale); err != nil {
+		return nil, err
+	}
+	return &StudentT{
+		df:    df,
+		loc:   loc,
+		scale: scale,
+		i:     newID(),
+	}, nil
+}
+
+func checkStudentT(df, loc, scale float64) error {
+	if !(df > 0) || !isFinite(loc) || !isFinite(scale) || scale < 0 {
+		return invalidParameter("degrees of freedom of a student's t distribution must be positive and its scale must not be negative, got %0.7f, %0.7f", df, scale)
+	}
+	return nil
+}
+
+func (t *StudentT) validate() error {
+	return checkStudentT(t.df, t.loc, t.scale)
+}
+
+func (t *StudentT) sample(r *draw) float64 {
+	if v, ok := r.lookup(t.i); ok {
+		return v
+	}
+	chi2 := 2 * randGamma(r.Rand, t.df/2)
+	return r.store(t.i, t.loc+t.scale*r.NormFloat64()/math.Sqrt(chi2/t.df))
+}
+
+func (t *StudentT) sampleWithTrace(r *draw) *sample {
+	val := t.sample(r)
+	s := newSample(val)
+	s.addTrace(t.i, val)
+	return s
+}
+
+func (t *StudentT) id() int {
+	return t.i
+}
+
+func (t *StudentT) support() interval {
+	if t.scale == 0 {
+		return interval{lo: t.loc, hi: t.loc}
+	}
+	return interval{lo: math.Inf(-1), hi: math.Inf(1)}
+}
@@ -0,0 +1,253 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	dataframe "github.com/bhojpur/mathematics/pkg/dataframe"
+	"github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+// Values returns a copy of the values of the samples.
+func (s *Samples) Values() []float64 {
+	out := make([]float64, len(s.Samples))
+	for i, v := range s.Samples {
+		out[i] = v.value
+	}
+	return out
+}
+
+// Float64Data returns the values of the samples, so the functions of the
+// statistics package can be applied to them.
+func (s *Samples) Float64Data() statistics.Float64Data {
+	return statistics.Float64Data(s.Values())
+}
+
+// SeriesFloat64 returns the values of the samples as a series.
+func (s *Samples) SeriesFloat64(name string) *dataframe.SeriesFloat64 {
+	return dataframe.NewSeriesFloat64(name, &dataframe.SeriesInit{Capacity: len(s.Samples)}, s.Values())
+}
+
+// sorted returns the values of the samples in increasing order
+func (s *Samples) sorted() []float64 {
+	out := s.Values()
+	sort.Float64s(out)
+	return out
+}
+
+// Variance returns the sample variance of the samples. It is NaN for less
+// than two samples.
+func (s *Samples) Variance() float64 {
+	if len(s.Samples) < 2 {
+		return math.NaN()
+	}
+	mean := s.Average()
+	squaredError := 0.0
+	for _, v := range s.Samples {
+		squaredError += (v.value - mean) * (v.value - mean)
+	}
+	return squaredError / float64(len(s.Samples)-1)
+}
+
+// StandardDeviation returns the sample standard deviation of the samples.
+func (s *Samples) StandardDeviation() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// Quantile returns the q-quantile of the samples for q in [0, 1],
+// interpolating linearly between the order statistics. It is NaN without
+// samples.
+func (s *Samples) Quantile(q float64) float64 {
+	return s.Quantiles(q)[0]
+}
+
+// Quantiles returns the quantiles of the samples like Quantile, sorting
+// the samples only once.
+func (s *Samples) Quantiles(qs ...float64) []float64 {
+	for _, q := range qs {
+		if q < 0 || q > 1 || math.IsNaN(q) {
+			panic("Trying to take a quantile outside [0.0, 1.0], got " + fmt.Sprintf("%0.7f", q))
+		}
+	}
+	sorted := s.sorted()
+	out := make([]float64, len(qs))
+	for i, q := range qs {
+		out[i] = quantileOfSorted(sorted, q)
+	}
+	return out
+}
+
+// quantileOfSorted interpolates the q-quantile of sorted values
+func quantileOfSorted(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	h := q * float64(len(sorted)-1)
+	lo := int(h)
+	if lo == len(sorted)-1 {
+		return sorted[lo]
+	}
+	return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// Median returns the median of the samples.
+func (s *Samples) Median() float64 {
+	return s.Quantile(0.5)
+}
+
+func checkMass(mass float64) {
+	if mass <= 0 || mass > 1 || math.IsNaN(mass) {
+		panic("Trying to create a credible interval with mass outside (0.0, 1.0], got " + fmt.Sprintf("%0.7f", mass))
+	}
+}
+
+// CredibleInterval returns the equal-tailed interval that holds the mass
+// of the samples, e.g. 0.95 for a 95% interval, with (1-mass)/2 of the
+// samples on either side.
+func (s *Samples) CredibleInterval(mass float64) (float64, float64) {
+	checkMass(mass)
+	q := s.Quantiles((1-mass)/2, (1+mass)/2)
+	return q[0], q[1]
+}
+
+// HPDInterval returns the highest posterior density interval, the
+// shortest interval that holds the mass of the samples. Unlike the
+// CredibleInterval, it follows the mode of skewed distributions. It is
+// only meaningful for unimodal distributions.
+func (s *Samples) HPDInterval(mass float64) (float64, float64) {
+	checkMass(mass)
+	sorted := s.sorted()
+	n := len(sorted)
+	if n == 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	// The interval spans k+1 samples, of which the shortest is taken
+	k := int(math.Ceil(mass*float64(n))) - 1
+	if k < 0 {
+		k = 0
+	}
+	best := 0
+	for i := 1; i+k < n; i++ {
+		if sorted[i+k]-sorted[i] < sorted[best+k]-sorted[best] {
+			best = i
+		}
+	}
+	return sorted[best], sorted[best+k]
+}
+
+// Histogram counts samples in bins of equal width. The last bin includes
+// its upper edge.
+type Histogram struct {
+	// Edges are the bins+1 edges of the bins.
+	Edges []float64
+	// Counts are the number of samples in each bin.
+	Counts []int
+	// Density is the estimate of the probability density in each bin, so
+	// that it integrates to 1.
+	Density []float64
+	// NonFinite is the number of NaN or infinite samples, which are left
+	// out of the bins.
+	NonFinite int
+}
+
+// Histogram returns the histogram of the finite samples with the number of
+// bins between their minimum and maximum.
+func (s *Samples) Histogram(bins int) Histogram {
+	if bins < 1 {
+		panic("Trying to create a histogram with less than one bin, got " + fmt.Sprintf("%d", bins))
+	}
+	out := Histogram{
+		Edges:   make([]float64, bins+1),
+		Counts:  make([]int, bins),
+		Density: make([]float64, bins),
+	}
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range s.Samples {
+		if !isFinite(v.value) {
+			out.NonFinite++
+			continue
+		}
+		min = math.Min(min, v.value)
+		max = math.Max(max, v.value)
+	}
+	n := len(s.Samples) - out.NonFinite
+	if n == 0 {
+		return out
+	}
+	if min == max {
+		min -= 0.5
+		max += 0.5
+	}
+	width := (max - min) / float64(bins)
+	for i := range out.Edges {
+		out.Edges[i] = min + float64(i)*width
+	}
+	out.Edges[bins] = max
+
+	for _, v := range s.Samples {
+		if !isFinite(v.value) {
+			continue
+		}
+		bin := int((v.value - min) / width)
+		if bin >= bins {
+			bin = bins - 1
+		}
+		out.Counts[bin]++
+	}
+	for i, c := range out.Counts {
+		out.Density[i] = float64(c) / (float64(n) * width)
+	}
+	return out
+}
+
+// Density returns a Gaussian kernel density estimate of the samples. The
+// bandwidth follows Silverman's rule of thumb.
+func (s *Samples) Density() func(x float64) float64 {
+	values := s.sorted()
+	n := float64(len(values))
+	if n == 0 {
+		return func(x float64) float64 {
+			return math.NaN()
+		}
+	}
+
+	spread := s.StandardDeviation()
+	if iqr := (quantileOfSorted(values, 0.75) - quantileOfSorted(values, 0.25)) / 1.34; iqr > 0 && !(iqr >= spread) {
+		spread = iqr
+	}
+	bandwidth := 0.9 * spread * math.Pow(n, -0.2)
+	if !(bandwidth > 0) {
+		// A single value or many equal values
+		bandwidth = 1
+	}
+
+	return func(x float64) float64 {
+		density := 0.0
+		for _, v := range values {
+			density += statistics.NormPdf(x, v, bandwidth)
+		}
+		return density / n
+	}
+}
@@ -0,0 +1,137 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	dataframe "github.com/bhojpur/mathematics/pkg/dataframe"
+)
+
+func fromValues(values ...float64) *Samples {
+	samples := make([]*sample, len(values))
+	for i, v := range values {
+		samples[i] = newSample(v)
+	}
+	return FromSamples(samples)
+}
+
+func TestSamplesSummary(t *testing.T) {
+	s := fromValues(4, 1, 3, 2)
+	if v := s.Variance(); !Within(v, 5.0/3, epsilon) {
+		t.Errorf("Variance %f", v)
+	}
+	if q := s.Quantiles(0, 0.5, 1, 1.0/3); q[0] != 1 || q[1] != 2.5 || q[2] != 4 || !Within(q[3], 2, epsilon) {
+		t.Errorf("Quantiles %v", q)
+	}
+	if m, _ := s.Float64Data().Median(); m != s.Median() {
+		t.Errorf("Median %f, statistics gives %f", s.Median(), m)
+	}
+
+	series := s.SeriesFloat64("x")
+	if series.Name(dataframe.DontLock) != "x" || series.NRows() != 4 || series.Values[2] != 3 {
+		t.Errorf("Series %v", series)
+	}
+	if n, _ := fromValues(1, math.NaN()).SeriesFloat64("x").NilCount(); n != 1 {
+		t.Error("NaN samples are not nil in the series")
+	}
+
+	if !math.IsNaN(fromValues().Quantile(0.5)) || !math.IsNaN(fromValues(1).Variance()) {
+		t.Error("Statistics of too few samples are not NaN")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Quantile outside [0, 1] did not panic")
+		}
+	}()
+	s.Quantile(1.5)
+}
+
+func TestCredibleIntervals(t *testing.T) {
+	g := Materialize(NewGaussian(2, 3), 50_000, Seed(1))
+	if !Within(g.StandardDeviation(), 3, 0.05) {
+		t.Errorf("Standard deviation %f", g.StandardDeviation())
+	}
+	lo, hi := g.CredibleInterval(0.95)
+	if !Within(lo, 2-1.96*3, 0.1) || !Within(hi, 2+1.96*3, 0.1) {
+		t.Errorf("Credible interval [%f, %f]", lo, hi)
+	}
+	hlo, hhi := g.HPDInterval(0.95)
+	if hhi-hlo > hi-lo || !Within(hlo, lo, 0.3) || !Within(hhi, hi, 0.3) {
+		t.Errorf("HPD interval [%f, %f] of a symmetric distribution", hlo, hhi)
+	}
+
+	// The HPD interval of an exponential starts at zero
+	e := Materialize(NewExponential(1), 50_000, Seed(1))
+	lo, hi = e.CredibleInterval(0.9)
+	if !Within(lo, -math.Log(0.95), 0.01) || !Within(hi, -math.Log(0.05), 0.05) {
+		t.Errorf("Credible interval [%f, %f]", lo, hi)
+	}
+	lo, hi = e.HPDInterval(0.9)
+	if !Within(lo, 0, 0.01) || !Within(hi, -math.Log(0.1), 0.05) {
+		t.Errorf("HPD interval [%f, %f]", lo, hi)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	h := fromValues(0, 1, 1, 2, 3, 4).Histogram(4)
+	if h.Edges[0] != 0 || h.Edges[4] != 4 || h.Edges[2] != 2 {
+		t.Errorf("Edges %v", h.Edges)
+	}
+	want := []int{1, 2, 1, 2}
+	for i := range want {
+		if h.Counts[i] != want[i] {
+			t.Fatalf("Counts %v, want %v", h.Counts, want)
+		}
+	}
+	if h.Density[1] != 2.0/6 {
+		t.Errorf("Density %v", h.Density)
+	}
+
+	// Non-finite samples are counted apart
+	h = fromValues(math.NaN(), 0, 1, math.Inf(1), 1, 2, 3, math.Inf(-1), 4).Histogram(4)
+	if h.NonFinite != 3 || h.Edges[0] != 0 || h.Edges[4] != 4 || h.Counts[1] != 2 || h.Density[1] != 2.0/6 {
+		t.Errorf("Histogram with non-finite samples %+v", h)
+	}
+	if h = fromValues(math.NaN()).Histogram(2); h.NonFinite != 1 || h.Counts[0] != 0 || h.Counts[1] != 0 {
+		t.Errorf("Histogram of NaN %+v", h)
+	}
+
+	g := Materialize(NewGaussian(2, 3), 50_000, Seed(2))
+	h = g.Histogram(50)
+	total := 0.0
+	for i, d := range h.Density {
+		total += d * (h.Edges[i+1] - h.Edges[i])
+	}
+	if !Within(total, 1, epsilon) {
+		t.Errorf("Histogram density integrates to %f", total)
+	}
+
+	density := g.Density()
+	if d := density(2); !Within(d, 1/(3*math.Sqrt(2*math.Pi)), 0.005) {
+		t.Errorf("Density at the mean %f", d)
+	}
+	if d := density(20); d > 1e-6 {
+		t.Errorf("Density far in the tail %f", d)
+	}
+}
@@ -0,0 +1,99 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+type Triangular struct {
+	lo, mode, hi float64
+	i            int
+}
+
+var _ Uncertain = &Triangular{}
+
+// NewTriangular creates a triangular distribution on [low, high]
+// whose density peaks at mode.
+func NewTriangular(low, mode, high float64) *Triangular {
+	t, err := TryNewTriangular(low, mode, high)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// TryNewTriangular is NewTriangular returning an error for invalid
+// parameters.
+func TryNewTriangular(low, mode, high float64) (*Triangular, error) {
+	if err := checkTriangular(low, mode, high); err != nil {
+		return nil, err
+	}
+	return &Triangular{
+		lo:   low,
+		mode: mode,
+		hi:   high,
+		i:    newID(),
+	}, nil
+}
+
+func checkTriangular(low, mode, high float64) error {
+	if !isFinite(low) || !isFinite(high) || !(low <= mode && mode <= high) {
+		return invalidParameter("mode of a triangular distribution must be within the range, got %0.7f, %0.7f, %0.7f", low, mode, high)
+	}
+	return nil
+}
+
+func (t *Triangular) validate() error {
+	return checkTriangular(t.lo, t.mode, t.hi)
+}
+
+func (t *Triangular) sample(r *draw) float64 {
+	if v, ok := r.lookup(t.i); ok {
+		return v
+	}
+	return r.store(t.i, t.quantile(r.Float64()))
+}
+
+func (t *Triangular) quantile(u float64) float64 {
+	width := t.hi - t.lo
+	if width == 0 {
+		return t.lo
+	}
+	c := (t.mode - t.lo) / width
+	if u < c {
+		return t.lo + math.Sqrt(u*width*(t.mode-t.lo))
+	}
+	return t.hi - math.Sqrt((1-u)*width*(t.hi-t.mode))
+}
+
+func (t *Triangular) sampleWithTrace(r *draw) *sample {
+	val := t.sample(r)
+	s := newSample(val)
+	s.addTrace(t.i, val)
+	return s
+}
+
+func (t *Triangular) id() int {
+	return t.i
+}
+
+func (t *Triangular) support() interval {
+	return interval{lo: t.lo, hi: t.hi}
+}
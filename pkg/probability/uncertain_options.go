@@ -20,18 +20,37 @@ package probability
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
+import (
+	"context"
+	"math/rand"
+)
+
 type OptionType int
 
 const (
 	sampleSizeOpt OptionType = iota
 	zScoreOpt
 	percentErrorOpt
+	seedOpt
+	streamOpt
+	randSourceOpt
+	workersOpt
+	contextOpt
+	errorRatesOpt
+	indifferenceOpt
+	methodOpt
+	maxAttemptsOpt
+	burnInOpt
 )
 
 type Option struct {
 	optionType OptionType
 	intVal     int
+	int64Val   int64
 	floatVal   float64
+	floatVal2  float64
+	source     rand.Source
+	ctx        context.Context
 }
 
 func SampleSize(n int) Option {
@@ -99,3 +118,201 @@ func getPercentError(opts []Option, def float64) float64 {
 	}
 	return def
 }
+
+// Seed makes an evaluation reproducible by seeding its random number
+// generator. Evaluating the same graph with the same options and seed
+// gives the same result.
+func Seed(seed int64) Option {
+	return Option{
+		optionType: seedOpt,
+		int64Val:   seed,
+	}
+}
+
+func getSeed(opts []Option) (int64, bool) {
+	for _, v := range opts {
+		if v.optionType == seedOpt {
+			return v.int64Val, true
+		}
+	}
+	return 0, false
+}
+
+// Stream selects an independent stream of random numbers derived from
+// the Seed. Concurrent evaluations that use the same seed and distinct
+// streams are reproducible and do not share any state.
+func Stream(n int) Option {
+	return Option{
+		optionType: streamOpt,
+		intVal:     n,
+	}
+}
+
+func getStream(opts []Option, def int) int {
+	for _, v := range opts {
+		if v.optionType == streamOpt {
+			return v.intVal
+		}
+	}
+	return def
+}
+
+// RandSource makes an evaluation draw its random numbers from src. It
+// takes precedence over Seed and Stream. A rand.Source is not safe for
+// concurrent use, so src must not be shared by concurrent evaluations.
+func RandSource(src rand.Source) Option {
+	return Option{
+		optionType: randSourceOpt,
+		source:     src,
+	}
+}
+
+func getRandSource(opts []Option) rand.Source {
+	for _, v := range opts {
+		if v.optionType == randSourceOpt {
+			return v.source
+		}
+	}
+	return nil
+}
+
+// Workers evaluates on n goroutines using Evaluate. Pr, ProbTrueAtLeast
+// and ExpectedValueWithConfidence evaluate sequentially unless it is set.
+func Workers(n int) Option {
+	return Option{
+		optionType: workersOpt,
+		intVal:     n,
+	}
+}
+
+func getWorkers(opts []Option, def int) int {
+	for _, v := range opts {
+		if v.optionType == workersOpt {
+			return v.intVal
+		}
+	}
+	return def
+}
+
+// Context cancels a parallel evaluation once ctx is done.
+func Context(ctx context.Context) Option {
+	return Option{
+		optionType: contextOpt,
+		ctx:        ctx,
+	}
+}
+
+func getContext(opts []Option) context.Context {
+	for _, v := range opts {
+		if v.optionType == contextOpt {
+			return v.ctx
+		}
+	}
+	return context.Background()
+}
+
+// ErrorRates sets the probability alpha of a false positive and beta of a
+// false negative accepted by the sequential probability ratio test. Both
+// default to the PercentError, or 0.05 without it. The test panics with
+// ErrInvalidParameter unless both are in (0, 1) and alpha+beta < 1.
+func ErrorRates(alpha, beta float64) Option {
+	return Option{
+		optionType: errorRatesOpt,
+		floatVal:   alpha,
+		floatVal2:  beta,
+	}
+}
+
+func getErrorRates(opts []Option, def float64) (float64, float64) {
+	for _, v := range opts {
+		if v.optionType == errorRatesOpt {
+			return v.floatVal, v.floatVal2
+		}
+	}
+	return def, def
+}
+
+// Indifference sets the half width of the region around the probability
+// in which the sequential probability ratio test does not care about the
+// answer. The narrower it is, the more samples the test takes. The test
+// panics with ErrInvalidParameter unless it is positive.
+func Indifference(v float64) Option {
+	return Option{
+		optionType: indifferenceOpt,
+		floatVal:   v,
+	}
+}
+
+func getIndifference(opts []Option, def float64) float64 {
+	for _, v := range opts {
+		if v.optionType == indifferenceOpt {
+			return v.floatVal
+		}
+	}
+	return def
+}
+
+// TestMethod selects how Pr and ProbTrueAtLeast decide.
+type TestMethod int
+
+const (
+	// SPRT samples until Wald's sequential probability ratio test decides,
+	// or SampleSize samples have been drawn.
+	SPRT TestMethod = iota
+	// FixedN draws SampleSize samples and compares the fraction that is
+	// true with the probability.
+	FixedN
+)
+
+// Method selects the TestMethod. It defaults to SPRT.
+func Method(m TestMethod) Option {
+	return Option{
+		optionType: methodOpt,
+		intVal:     int(m),
+	}
+}
+
+func getMethod(opts []Option, def TestMethod) TestMethod {
+	for _, v := range opts {
+		if v.optionType == methodOpt {
+			return TestMethod(v.intVal)
+		}
+	}
+	return def
+}
+
+// MaxAttempts bounds the draws rejection sampling takes to find a sample
+// that satisfies the condition.
+func MaxAttempts(n int) Option {
+	return Option{
+		optionType: maxAttemptsOpt,
+		intVal:     n,
+	}
+}
+
+func getMaxAttempts(opts []Option, def int) int {
+	for _, v := range opts {
+		if v.optionType == maxAttemptsOpt {
+			return v.intVal
+		}
+	}
+	return def
+}
+
+// BurnIn is the number of steps Metropolis-Hastings takes before it keeps
+// any samples.
+func BurnIn(n int) Option {
+	return Option{
+		optionType: burnInOpt,
+		intVal:     n,
+	}
+}
+
+func getBurnIn(opts []Option, def int) int {
+	for _, v := range opts {
+		if v.optionType == burnInOpt {
+			return v.intVal
+		}
+	}
+	return def
+}
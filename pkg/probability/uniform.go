@@ -26,30 +26,61 @@ type Uniform struct {
 }
 
 func NewUniform(low, high float64) *Uniform {
-	if high < low {
-		panic("High value of range is lower than low value of range")
+	u, err := TryNewUniform(low, high)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// TryNewUniform is NewUniform returning an error for an invalid range.
+func TryNewUniform(low, high float64) (*Uniform, error) {
+	if err := checkUniform(low, high); err != nil {
+		return nil, err
 	}
 	return &Uniform{
 		lo:   low,
 		size: high - low,
 		i:    newID(),
+	}, nil
+}
+
+func checkUniform(low, high float64) error {
+	if !isFinite(low) || !isFinite(high) {
+		return invalidParameter("range of a uniform distribution must be finite, got %0.7f, %0.7f", low, high)
+	}
+	if high < low {
+		return invalidParameter("high value of range is lower than low value of range, got %0.7f, %0.7f", low, high)
 	}
+	return nil
 }
 
-func (u *Uniform) sample() float64 {
-	r := randFloat64()
-	return r*u.size + u.lo
+func (u *Uniform) validate() error {
+	return checkUniform(u.lo, u.lo+u.size)
+}
+
+func (u *Uniform) sample(r *draw) float64 {
+	if v, ok := r.lookup(u.i); ok {
+		return v
+	}
+	return r.store(u.i, r.Float64()*u.size+u.lo)
 }
 
 func (u *Uniform) id() int {
 	return u.i
 }
 
-func (u *Uniform) sampleWithTrace() *sample {
-	val := u.sample()
-	s := &sample{
-		value: val,
-	}
+func (u *Uniform) sampleWithTrace(r *draw) *sample {
+	val := u.sample(r)
+	s := newSample(val)
 	s.addTrace(u.i, val)
 	return s
 }
+
+func (u *Uniform) quantile(p float64) float64 {
+	return p*u.size + u.lo
+}
+
+func (u *Uniform) support() interval {
+	return interval{lo: u.lo, hi: u.lo + u.size}
+}
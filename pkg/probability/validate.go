@@ -0,0 +1,369 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrInvalidParameter is wrapped by the errors of the constructors.
+	ErrInvalidParameter = errors.New("probability: invalid parameter")
+	// ErrMayDivideByZero is returned when the denominator of a division
+	// may be zero.
+	ErrMayDivideByZero = errors.New("probability: denominator may be zero")
+	// ErrNonFinite is reported when sampling a node gives NaN or Inf.
+	ErrNonFinite = errors.New("probability: operation gives NaN or Inf")
+	// ErrCycle is reported when a node depends on itself.
+	ErrCycle = errors.New("probability: node depends on itself")
+)
+
+func invalidParameter(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidParameter}, args...)...)
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+func isPositive(v float64) bool {
+	return v > 0 && !math.IsInf(v, 1)
+}
+
+func checkProbability(name string, probability float64) error {
+	if !(probability >= 0 && probability <= 1) {
+		return invalidParameter("trying to create a %s probability outside [0.0, 1.0], got %0.7f", name, probability)
+	}
+	return nil
+}
+
+// TryDiv is Div returning ErrMayDivideByZero if b may be zero.
+func TryDiv(a Uncertain, b Uncertain) (Uncertain, error) {
+	if mayBeZero(b, map[int]interval{}) {
+		return nil, ErrMayDivideByZero
+	}
+	return Div(a, b), nil
+}
+
+// IssueKind classifies the issues found by Validate.
+type IssueKind int
+
+const (
+	// InvalidNode is a node with invalid parameters.
+	InvalidNode IssueKind = iota
+	// NonFinite is an operation that may give NaN or Inf.
+	NonFinite
+	// Cycle is a node that depends on itself.
+	Cycle
+)
+
+func (k IssueKind) String() string {
+	switch k {
+	case InvalidNode:
+		return "invalid node"
+	case NonFinite:
+		return "non-finite operation"
+	case Cycle:
+		return "cycle"
+	}
+	return fmt.Sprintf("IssueKind(%d)", int(k))
+}
+
+// Issue is a problem of a node of a graph.
+type Issue struct {
+	Kind IssueKind
+	Node Uncertain
+	Err  error
+}
+
+func (i Issue) Error() string {
+	return fmt.Sprintf("%s at %s: %v", i.Kind, describe(i.Node), i.Err)
+}
+
+// ValidationError lists the issues found by Validate.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		msgs[i] = issue.Error()
+	}
+	return fmt.Sprintf("probability: %d issues in graph: %s", len(e.Issues), strings.Join(msgs, "; "))
+}
+
+// describe names a node by its type and id
+func describe(u Uncertain) string {
+	if ar, ok := u.(*arithmeticOperation); ok {
+		return fmt.Sprintf("%q %d", ar.op, ar.i)
+	}
+	if f, ok := u.(*functionOperation); ok && f.name != "" {
+		return fmt.Sprintf("%s %d", f.name, f.i)
+	}
+	return fmt.Sprintf("%T %d", u, u.id())
+}
+
+// validator is implemented by the nodes with parameters to check
+type validator interface {
+	validate() error
+}
+
+// composite is implemented by the nodes that combine other nodes
+type composite interface {
+	inputs() []Uncertain
+}
+
+// Validate checks the graph of u before sampling. It reports nodes with
+// invalid parameters, cycles, divisions whose denominator may be zero and
+// operations that gave NaN or Inf in SampleSize samples (1000 by
+// default, 0 to skip sampling). It returns nil or a *ValidationError.
+func Validate(u Uncertain, opts ...Option) error {
+	v := &validation{
+		state:    map[int]int{},
+		supports: map[int]interval{},
+		nodes:    map[int]Uncertain{},
+		reported: map[int]bool{},
+	}
+	v.visit(u)
+	if len(v.issues) == 0 {
+		v.sample(u, getSampleSize(opts, 1000), opts)
+	}
+	if len(v.issues) == 0 {
+		return nil
+	}
+	return &ValidationError{Issues: v.issues}
+}
+
+const (
+	visiting = iota + 1
+	visited
+)
+
+type validation struct {
+	state    map[int]int
+	supports map[int]interval
+	nodes    map[int]Uncertain
+	order    []Uncertain
+	reported map[int]bool
+	issues   []Issue
+}
+
+func (v *validation) report(kind IssueKind, u Uncertain, err error) {
+	if v.reported[u.id()] {
+		return
+	}
+	v.reported[u.id()] = true
+	v.issues = append(v.issues, Issue{
+		Kind: kind,
+		Node: u,
+		Err:  err,
+	})
+}
+
+// visit walks the inputs of u depth first. The nodes are ordered so that
+// every node comes after its inputs.
+func (v *validation) visit(u Uncertain) {
+	switch v.state[u.id()] {
+	case visiting:
+		v.report(Cycle, u, ErrCycle)
+		return
+	case visited:
+		return
+	}
+	v.state[u.id()] = visiting
+	v.nodes[u.id()] = u
+
+	if c, ok := u.(composite); ok {
+		for _, in := range c.inputs() {
+			v.visit(in)
+		}
+	}
+	if val, ok := u.(validator); ok {
+		if err := val.validate(); err != nil {
+			v.report(InvalidNode, u, err)
+		}
+	}
+	if ar, ok := u.(*arithmeticOperation); ok && ar.op == "/" && v.state[ar.b.id()] == visited {
+		if mayBeZero(ar.b, v.supports) {
+			v.report(NonFinite, ar, ErrMayDivideByZero)
+		}
+	}
+
+	v.state[u.id()] = visited
+	v.order = append(v.order, u)
+}
+
+// sample draws n samples of u and reports the first nodes whose values
+// are not finite while the values of their inputs are.
+func (v *validation) sample(u Uncertain, n int, opts []Option) {
+	if n == 0 {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			v.report(InvalidNode, u, fmt.Errorf("sampling panicked: %v", r))
+		}
+	}()
+
+	for _, s := range Materialize(u, n, opts...).Samples {
+		for _, node := range v.order {
+			val, ok := s.trace[node.id()]
+			if !ok || isFinite(val) {
+				continue
+			}
+			inputsFinite := true
+			if c, ok := node.(composite); ok {
+				for _, in := range c.inputs() {
+					if iv, ok := s.trace[in.id()]; ok && !isFinite(iv) {
+						inputsFinite = false
+					}
+				}
+			}
+			if inputsFinite {
+				v.report(NonFinite, node, ErrNonFinite)
+			}
+		}
+	}
+}
+
+// interval is the range of the values of a node. The bounds are included
+// unless it is open.
+type interval struct {
+	lo, hi float64
+	open   bool
+}
+
+var unbounded = interval{lo: math.Inf(-1), hi: math.Inf(1)}
+
+func (i interval) containsZero() bool {
+	if i.open {
+		return i.lo < 0 && 0 < i.hi
+	}
+	return i.lo <= 0 && 0 <= i.hi
+}
+
+// bounds is implemented by the distributions with a known support
+type bounds interface {
+	support() interval
+}
+
+// discrete is implemented by the distributions over a known set of values
+type discrete interface {
+	atoms() []float64
+}
+
+// mayBeZero returns whether u may take the value zero
+func mayBeZero(u Uncertain, memo map[int]interval) bool {
+	if d, ok := u.(discrete); ok {
+		for _, v := range d.atoms() {
+			if v == 0 {
+				return true
+			}
+		}
+		return false
+	}
+	return supportOf(u, memo).containsZero()
+}
+
+// supportOf returns an interval that contains all values of u, by
+// interval arithmetic over its inputs.
+func supportOf(u Uncertain, memo map[int]interval) interval {
+	if s, ok := memo[u.id()]; ok {
+		return s
+	}
+	// A cycle in the graph is unbounded
+	memo[u.id()] = unbounded
+
+	var s interval
+	switch n := u.(type) {
+	case bounds:
+		s = n.support()
+	case discrete:
+		s = interval{lo: math.Inf(1), hi: math.Inf(-1)}
+		for _, v := range n.atoms() {
+			s.lo = math.Min(s.lo, v)
+			s.hi = math.Max(s.hi, v)
+		}
+	case *arithmeticOperation:
+		s = arithmeticSupport(n.op, supportOf(n.a, memo), n.b, memo)
+	case *functionOperation:
+		args := make([]interval, len(n.args))
+		for k, arg := range n.args {
+			args[k] = supportOf(arg, memo)
+		}
+		s = functionSupport(n.name, args)
+	case *ConditionalDistribution:
+		s = supportOf(n.input, memo)
+	case *IfElseDistribution:
+		t, f := supportOf(n.trueBranch, memo), supportOf(n.falseBranch, memo)
+		s = interval{lo: math.Min(t.lo, f.lo), hi: math.Max(t.hi, f.hi)}
+	case UncertainBool:
+		s = interval{lo: 0, hi: 1}
+	default:
+		s = unbounded
+	}
+	memo[u.id()] = s
+	return s
+}
+
+func arithmeticSupport(op string, a interval, bu Uncertain, memo map[int]interval) interval {
+	b := supportOf(bu, memo)
+	switch op {
+	case "+":
+		return interval{lo: a.lo + b.lo, hi: a.hi + b.hi}
+	case "-":
+		return interval{lo: a.lo - b.hi, hi: a.hi - b.lo}
+	case "*":
+		return hull(mulBound(a.lo, b.lo), mulBound(a.lo, b.hi), mulBound(a.hi, b.lo), mulBound(a.hi, b.hi))
+	case "/":
+		if mayBeZero(bu, memo) {
+			return unbounded
+		}
+		return hull(a.lo/b.lo, a.lo/b.hi, a.hi/b.lo, a.hi/b.hi)
+	}
+	return unbounded
+}
+
+// mulBound multiplies bounds, where zero times infinity is zero
+func mulBound(x, y float64) float64 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return x * y
+}
+
+// hull returns the smallest interval that contains the values, or an
+// unbounded one if any is NaN.
+func hull(vals ...float64) interval {
+	out := interval{lo: math.Inf(1), hi: math.Inf(-1)}
+	for _, v := range vals {
+		if math.IsNaN(v) {
+			return unbounded
+		}
+		out.lo = math.Min(out.lo, v)
+		out.hi = math.Max(out.hi, v)
+	}
+	return out
+}
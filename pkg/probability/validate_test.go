@@ -0,0 +1,142 @@
+package probability
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTryConstructors(t *testing.T) {
+	for name, err := range map[string]error{
+		"uniform":     second(TryNewUniform(2, 1)),
+		"uniform nan": second(TryNewUniform(math.NaN(), 1)),
+		"gaussian":    second(TryNewGaussian(0, -1)),
+		"bernoulli":   second(TryNewBernoulli(1.5)),
+		"binomial":    second(TryNewBinomial(-1, 0.5)),
+		"multinomial": second(TryNewMultinomial([]float64{1, 2}, []float64{0.5, 0.4})),
+		"lengths":     second(TryNewMultinomial([]float64{1, 2}, []float64{1})),
+		"exponential": second(TryNewExponential(0)),
+		"gamma":       second(TryNewGamma(1, math.Inf(1))),
+		"triangular":  second(TryNewTriangular(0, 2, 1)),
+		"empirical":   second(TryNewEmpirical(nil)),
+		"mvn":         second(TryNewMultivariateNormal([]float64{0, 0}, [][]float64{{1, 2}, {2, 1}})),
+		"copula":      second(TryNewGaussianCopula([]Uncertain{NewUniform(0, 1)}, [][]float64{{0.5}})),
+		"student t":   second(TryNewStudentT(0, 0, 1)),
+		"log-normal":  second(TryNewLogNormal(0, -1)),
+		"poisson":     second(TryNewPoisson(-1)),
+		"beta":        second(TryNewBeta(1, 0)),
+		"no values":   second(TryNewMultinomial(nil, nil)),
+	} {
+		if !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("%s returned %v", name, err)
+		}
+	}
+
+	if u, err := TryNewUniform(1, 2); err != nil || u == nil {
+		t.Errorf("TryNewUniform returned %v", err)
+	}
+	if m, err := TryNewMultinomial([]float64{1, 2}, []float64{0.5, 0.5}); err != nil || m == nil {
+		t.Errorf("TryNewMultinomial returned %v", err)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("NewUniform panicked with %v", err)
+		}
+	}()
+	NewUniform(2, 1)
+}
+
+func second(_ interface{}, err error) error {
+	return err
+}
+
+func TestTryDiv(t *testing.T) {
+	x := NewGaussian(0, 1)
+	for name, tc := range map[string]struct {
+		b  Uncertain
+		ok bool
+	}{
+		"positive uniform":  {NewUniform(1, 2), true},
+		"uniform over zero": {NewUniform(-1, 1), false},
+		"dice":              {NewDice(6), true},
+		"zero constant":     {NewConstant(0), false},
+		"log-normal":        {NewLogNormal(0, 1), true},
+		"exponential":       {NewExponential(1), false},
+		"gaussian":          {x, false},
+		"shifted sum":       {Add(NewUniform(1, 2), NewConstant(-1.5)), false},
+		"product":           {Mul(NewUniform(1, 2), NewUniform(-3, -1)), true},
+		"quotient":          {Div(NewConstant(1), NewUniform(1, 2)), true},
+		"zero probability":  {NewBernoulli(1), true},
+		"comparison":        {GreaterThan(x, NewConstant(0)), false},
+	} {
+		_, err := TryDiv(x, tc.b)
+		if (err == nil) != tc.ok {
+			t.Errorf("TryDiv by %s returned %v", name, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	x := NewGaussian(1, 2)
+	if err := Validate(Add(Mul(x, NewUniform(1, 2)), NewDice(6)), Seed(1)); err != nil {
+		t.Errorf("Validate of a valid graph returned %v", err)
+	}
+
+	issues := func(u Uncertain, opts ...Option) []Issue {
+		var verr *ValidationError
+		if err := Validate(u, opts...); !errors.As(err, &verr) {
+			t.Fatalf("Validate returned %v", err)
+		}
+		return verr.Issues
+	}
+
+	bad := NewGaussian(0, -1)
+	is := issues(Add(x, Sub(bad, NewConstant(math.NaN()))))
+	if len(is) != 2 || is[0].Kind != InvalidNode || is[0].Node != bad || is[1].Kind != InvalidNode {
+		t.Errorf("Invalid nodes gave %v", is)
+	}
+
+	div := Div(x, Sub(x, NewConstant(1)))
+	is = issues(Add(div, NewConstant(1)))
+	if len(is) != 1 || is[0].Kind != NonFinite || is[0].Node != div || !errors.Is(is[0].Err, ErrMayDivideByZero) {
+		t.Errorf("Division gave %v", is)
+	}
+
+	// Only sampling finds the overflow
+	mul := Mul(NewUniform(1e200, 2e200), NewUniform(1e200, 2e200))
+	is = issues(Sub(mul, x), Seed(1))
+	if len(is) != 1 || is[0].Kind != NonFinite || is[0].Node != mul || !errors.Is(is[0].Err, ErrNonFinite) {
+		t.Errorf("Overflow gave %v", is)
+	}
+	if err := Validate(Sub(mul, x), SampleSize(0)); err != nil {
+		t.Errorf("Validate without sampling returned %v", err)
+	}
+
+	cycle := &arithmeticOperation{op: "+", combine: func(a, b float64) float64 { return a + b }, i: newID()}
+	cycle.a, cycle.b = x, cycle
+	is = issues(cycle)
+	if len(is) != 1 || is[0].Kind != Cycle || is[0].Node != cycle {
+		t.Errorf("Cycle gave %v", is)
+	}
+}
@@ -0,0 +1,201 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// BetaPdf is the probability density function of the beta distribution
+// with shapes a and b.
+func BetaPdf(x float64, a float64, b float64, loc float64, scale float64) float64 {
+	return math.Exp(BetaLogPdf(x, a, b, loc, scale))
+}
+
+// BetaLogPdf is the log of the probability density function.
+func BetaLogPdf(x float64, a float64, b float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z < 0 || z > 1 {
+		return math.Inf(-1)
+	}
+
+	// The terms vanish for a or b of one, also at the boundaries.
+	var t1, t2 float64
+	if a != 1 {
+		t1 = (a - 1) * math.Log(z)
+	}
+	if b != 1 {
+		t2 = (b - 1) * math.Log1p(-z)
+	}
+	return t1 + t2 - lbeta(a, b) - math.Log(scale)
+}
+
+// BetaCdf is the cumulative distribution function.
+func BetaCdf(x float64, a float64, b float64, loc float64, scale float64) float64 {
+	return regIncBeta(a, b, (x-loc)/scale)
+}
+
+// BetaLogCdf is the log of the cumulative distribution function.
+func BetaLogCdf(x float64, a float64, b float64, loc float64, scale float64) float64 {
+	return math.Log(BetaCdf(x, a, b, loc, scale))
+}
+
+// BetaSf is the survival function (1 - cdf).
+func BetaSf(x float64, a float64, b float64, loc float64, scale float64) float64 {
+	return regIncBeta(b, a, 1-(x-loc)/scale)
+}
+
+// BetaLogSf is the log of the survival function.
+func BetaLogSf(x float64, a float64, b float64, loc float64, scale float64) float64 {
+	return math.Log(BetaSf(x, a, b, loc, scale))
+}
+
+// BetaPpf is the point percentile function (inverse of cdf).
+func BetaPpf(p float64, a float64, b float64, loc float64, scale float64) float64 {
+	return loc + scale*invRegIncBeta(a, b, p)
+}
+
+// BetaIsf is the inverse survival function (inverse of sf).
+func BetaIsf(p float64, a float64, b float64, loc float64, scale float64) float64 {
+	return loc + scale*(1-invRegIncBeta(b, a, p))
+}
+
+// BetaMoment is the non-central (raw) moment of order n.
+func BetaMoment(n int, a float64, b float64, loc float64, scale float64) float64 {
+	return rawMoment(n, loc, scale, func(k int) float64 {
+		m := 1.0
+		for r := 0; r < k; r++ {
+			m *= (a + float64(r)) / (a + b + float64(r))
+		}
+		return m
+	})
+}
+
+// BetaStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func BetaStats(a float64, b float64, loc float64, scale float64, moments string) []float64 {
+	ab := a + b
+	m := a / ab
+	v := a * b / (ab * ab * (ab + 1))
+	s := 2 * (b - a) * math.Sqrt(ab+1) / ((ab + 2) * math.Sqrt(a*b))
+	k := 6 * ((a-b)*(a-b)*(ab+1) - a*b*(ab+2)) / (a * b * (ab + 2) * (ab + 3))
+	return selectStats(moments, loc, scale, m, v, s, k)
+}
+
+// BetaEntropy is the differential entropy of the RV.
+func BetaEntropy(a float64, b float64, loc float64, scale float64) float64 {
+	return lbeta(a, b) - (a-1)*digamma(a) - (b-1)*digamma(b) + (a+b-2)*digamma(a+b) + math.Log(scale)
+}
+
+// BetaFit returns the maximum likelihood estimators for the beta distribution.
+// The location is fixed at zero and the scale at one, so all values must
+// be within the open interval (0, 1).
+// Takes array of float64 values.
+// Returns array of the shapes a and b followed by the location and the scale.
+func BetaFit(data []float64) [4]float64 {
+	nan := [4]float64{math.NaN(), math.NaN(), 0, 1}
+	if len(data) < 2 {
+		return nan
+	}
+
+	var g1, g2 float64
+	for _, x := range data {
+		if x <= 0 || x >= 1 {
+			return nan
+		}
+		g1 += math.Log(x)
+		g2 += math.Log1p(-x)
+	}
+	n := float64(len(data))
+	g1 /= n
+	g2 /= n
+
+	// Initial guess by the method of moments
+	m, _ := Mean(data)
+	v, _ := PopulationVariance(data)
+	a, b := 1.0, 1.0
+	if c := m*(1-m)/v - 1; v > 0 && c > 0 {
+		a, b = m*c, (1-m)*c
+	}
+
+	// Newton's method on digamma(a) - digamma(a+b) = g1 and digamma(b) - digamma(a+b) = g2
+	for i := 0; i < 100; i++ {
+		dab := digamma(a + b)
+		f1 := digamma(a) - dab - g1
+		f2 := digamma(b) - dab - g2
+		tab := trigamma(a + b)
+		j11 := trigamma(a) - tab
+		j22 := trigamma(b) - tab
+		det := j11*j22 - tab*tab
+		da := (j22*f1 + tab*f2) / det
+		db := (tab*f1 + j11*f2) / det
+
+		// Halve the step while it leaves the parameter space
+		for a-da <= 0 || b-db <= 0 {
+			da /= 2
+			db /= 2
+		}
+		a -= da
+		b -= db
+		if math.Abs(da) < 1e-14*a && math.Abs(db) < 1e-14*b {
+			break
+		}
+	}
+
+	return [4]float64{a, b, 0, 1}
+}
+
+// BetaMedian is the median of the distribution.
+func BetaMedian(a float64, b float64, loc float64, scale float64) float64 {
+	return BetaPpf(0.5, a, b, loc, scale)
+}
+
+// BetaMean is the mean/expected value of the distribution.
+func BetaMean(a float64, b float64, loc float64, scale float64) float64 {
+	return loc + scale*a/(a+b)
+}
+
+// BetaVar is the variance of the distribution.
+func BetaVar(a float64, b float64, loc float64, scale float64) float64 {
+	return BetaStats(a, b, loc, scale, "v")[0]
+}
+
+// BetaStd is the standard deviation of the distribution.
+func BetaStd(a float64, b float64, loc float64, scale float64) float64 {
+	return math.Sqrt(BetaVar(a, b, loc, scale))
+}
+
+// BetaInterval finds endpoints of the range that contains alpha percent of the distribution.
+func BetaInterval(alpha float64, a float64, b float64, loc float64, scale float64) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return BetaPpf(p, a, b, loc, scale) })
+}
+
+// BetaRvs generates random variates from the ratio of gamma variates.
+func BetaRvs(a float64, b float64, loc float64, scale float64, size int) []float64 {
+	r := newRand()
+	toReturn := make([]float64, size)
+	for i := range toReturn {
+		x := randGamma(r, a)
+		y := randGamma(r, b)
+		toReturn[i] = loc + scale*x/(x+y)
+	}
+	return toReturn
+}
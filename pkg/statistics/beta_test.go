@@ -0,0 +1,62 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestBeta(t *testing.T) {
+	checkValues(t, "BetaPdf", func(x float64) float64 { return stats.BetaPdf(x, 2, 5, 0, 1) }, [][2]float64{{0.3, 2.1609}, {1.5, 0}}, 1e-12)
+	checkValues(t, "BetaCdf", func(x float64) float64 { return stats.BetaCdf(x, 2, 5, 0, 1) }, [][2]float64{{0.3, 0.579825}}, 1e-12)
+	checkValues(t, "BetaSf", func(x float64) float64 { return stats.BetaSf(x, 2, 5, 0, 1) }, [][2]float64{{0.3, 0.420175}}, 1e-12)
+	checkValues(t, "BetaPpf", func(p float64) float64 { return stats.BetaPpf(p, 2, 5, 0, 1) }, [][2]float64{{0.6, 0.3094444275453144}}, 1e-12)
+	checkValues(t, "BetaIsf", func(p float64) float64 { return stats.BetaIsf(p, 2, 5, 0, 1) }, [][2]float64{{0.4, 0.3094444275453144}}, 1e-12)
+
+	if got := stats.BetaPdf(0, 1, 3, 0, 1); !tolerance(got, 3, 1e-12) {
+		t.Errorf("BetaPdf(0, a=1, b=3) => %v != 3", got)
+	}
+	if got := stats.BetaMoment(2, 2, 3, 0, 1); !tolerance(got, 0.2, 1e-15) {
+		t.Errorf("BetaMoment(2) => %v != 0.2", got)
+	}
+	s := stats.BetaStats(2, 3, 0, 1, "mvsk")
+	exp := []float64{0.4, 0.04, 0.28571428571428575, -0.6428571428571429}
+	for i := range s {
+		if !tolerance(s[i], exp[i], 1e-12) {
+			t.Errorf("BetaStats(2, 3) => %v != %v", s, exp)
+			break
+		}
+	}
+	if got := stats.BetaEntropy(2, 5, 0, 1); !tolerance(got, -0.48453071499548983, 1e-12) {
+		t.Errorf("BetaEntropy => %v", got)
+	}
+
+	fit := stats.BetaFit([]float64{0.2, 0.4, 0.25, 0.1, 0.7, 0.35, 0.44, 0.18})
+	if !tolerance(fit[0], 2.2109356726536045, 1e-9) || !tolerance(fit[1], 4.455119845862975, 1e-9) {
+		t.Errorf("BetaFit => %v", fit)
+	}
+
+	if len(stats.BetaRvs(2, 5, 0, 1, 101)) != 101 {
+		t.Error("Input size=101, Expected 101")
+	}
+}
@@ -0,0 +1,142 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// BinomPmf is the probability mass function of the binomial distribution,
+// the number of successes in n trials with success probability p.
+func BinomPmf(k int, n int, p float64) float64 {
+	return math.Exp(BinomLogPmf(k, n, p))
+}
+
+// BinomLogPmf is the log of the probability mass function.
+func BinomLogPmf(k int, n int, p float64) float64 {
+	switch {
+	case k < 0 || k > n:
+		return math.Inf(-1)
+	case p == 0:
+		if k == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	case p == 1:
+		if k == n {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	return LogNcr(n, k) + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p)
+}
+
+// BinomCdf is the cumulative distribution function.
+func BinomCdf(k int, n int, p float64) float64 {
+	switch {
+	case k < 0:
+		return 0
+	case k >= n:
+		return 1
+	}
+	return regIncBeta(float64(n-k), float64(k+1), 1-p)
+}
+
+// BinomSf is the survival function (1 - cdf).
+func BinomSf(k int, n int, p float64) float64 {
+	switch {
+	case k < 0:
+		return 1
+	case k >= n:
+		return 0
+	}
+	return regIncBeta(float64(k+1), float64(n-k), p)
+}
+
+// BinomPpf is the point percentile function (inverse of cdf).
+func BinomPpf(q float64, n int, p float64) float64 {
+	return discretePpf(q, func(k int) float64 { return BinomCdf(k, n, p) }, 0, n, normalGuess(q, BinomMean(n, p), BinomVar(n, p)))
+}
+
+// BinomIsf is the inverse survival function (inverse of sf).
+func BinomIsf(q float64, n int, p float64) float64 {
+	return discreteIsf(q, func(k int) float64 { return BinomSf(k, n, p) }, 0, n, normalGuess(1-q, BinomMean(n, p), BinomVar(n, p)))
+}
+
+// BinomStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func BinomStats(n int, p float64, moments string) []float64 {
+	v := BinomVar(n, p)
+	return selectStats(moments, 0, 1, BinomMean(n, p), v, (1-2*p)/math.Sqrt(v), (1-6*p*(1-p))/v)
+}
+
+// BinomFit returns the maximum likelihood estimator of the success probability
+// for n trials. Takes array of the observed numbers of successes.
+func BinomFit(data []int, n int) float64 {
+	if len(data) == 0 || n <= 0 {
+		return math.NaN()
+	}
+	return meanInt(data) / float64(n)
+}
+
+// BinomMedian is the median of the distribution.
+func BinomMedian(n int, p float64) float64 {
+	return BinomPpf(0.5, n, p)
+}
+
+// BinomMean is the mean/expected value of the distribution.
+func BinomMean(n int, p float64) float64 {
+	return float64(n) * p
+}
+
+// BinomVar is the variance of the distribution.
+func BinomVar(n int, p float64) float64 {
+	return float64(n) * p * (1 - p)
+}
+
+// BinomStd is the standard deviation of the distribution.
+func BinomStd(n int, p float64) float64 {
+	return math.Sqrt(BinomVar(n, p))
+}
+
+// BinomInterval finds endpoints of the range that contains alpha percent of the distribution.
+func BinomInterval(alpha float64, n int, p float64) [2]float64 {
+	return interval(alpha, func(q float64) float64 { return BinomPpf(q, n, p) })
+}
+
+// BinomRvs generates random variates by counting successful trials
+// for small n and by inversion otherwise.
+func BinomRvs(n int, p float64, size int) []int {
+	r := newRand()
+	toReturn := make([]int, size)
+	for i := range toReturn {
+		if n <= 30 {
+			for j := 0; j < n; j++ {
+				if r.Float64() < p {
+					toReturn[i]++
+				}
+			}
+			continue
+		}
+		toReturn[i] = int(BinomPpf(randOpenUnit(r), n, p))
+	}
+	return toReturn
+}
@@ -0,0 +1,64 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestBinom(t *testing.T) {
+	checkValues(t, "BinomPmf", func(k float64) float64 { return stats.BinomPmf(int(k), 10, 0.5) }, [][2]float64{{3, 120.0 / 1024}, {0, 1.0 / 1024}, {11, 0}, {-1, 0}}, 1e-14)
+	checkValues(t, "BinomLogPmf", func(k float64) float64 { return stats.BinomLogPmf(int(k), 10, 0.5) }, [][2]float64{{3, math.Log(120.0 / 1024)}}, 1e-14)
+	checkValues(t, "BinomCdf", func(k float64) float64 { return stats.BinomCdf(int(k), 10, 0.5) }, [][2]float64{{3, 176.0 / 1024}, {-1, 0}, {10, 1}}, 1e-14)
+	checkValues(t, "BinomSf", func(k float64) float64 { return stats.BinomSf(int(k), 10, 0.5) }, [][2]float64{{3, 848.0 / 1024}, {9, 1.0 / 1024}, {10, 0}}, 1e-14)
+	checkValues(t, "BinomPpf", func(p float64) float64 { return stats.BinomPpf(p, 10, 0.5) }, [][2]float64{{0.5, 5}, {176.0 / 1024, 3}, {0.172, 4}, {0, -1}, {1, 10}}, 0)
+	checkValues(t, "BinomIsf", func(p float64) float64 { return stats.BinomIsf(p, 10, 0.5) }, [][2]float64{{1.0 / 1024, 9}, {0.001, 9}, {0.0009, 10}, {1, -1}, {0, 10}}, 0)
+
+	// The tail far beyond the range of Ncr
+	if got := stats.BinomSf(900, 1000, 0.5); got <= 0 || got > 1e-160 {
+		t.Errorf("BinomSf(900, 1000, 0.5) => %v", got)
+	}
+	if got := stats.BinomPmf(5000, 10000, 0.5); !tolerance(got, 0.007978646139382, 1e-12) {
+		t.Errorf("BinomPmf(5000, 10000, 0.5) => %v", got)
+	}
+
+	if s := stats.BinomStats(10, 0.5, "mvsk"); s[0] != 5 || s[1] != 2.5 || s[2] != 0 || !veryclose(s[3], -0.2) {
+		t.Errorf("BinomStats(10, 0.5) => %v", s)
+	}
+	if got := stats.BinomMedian(10, 0.5); got != 5 {
+		t.Errorf("BinomMedian => %v != 5", got)
+	}
+	if got := stats.BinomFit([]int{1, 2, 3, 6}, 10); got != 0.3 {
+		t.Errorf("BinomFit => %v != 0.3", got)
+	}
+	if got := stats.BinomInterval(0.9, 10, 0.5); got != [2]float64{2, 8} {
+		t.Errorf("BinomInterval => %v != [2 8]", got)
+	}
+
+	for _, k := range stats.BinomRvs(50, 0.3, 101) {
+		if k < 0 || k > 50 {
+			t.Errorf("BinomRvs => %v out of support", k)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// CauchyPdf is the probability density function of the Cauchy distribution.
+func CauchyPdf(x float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	return 1 / (math.Pi * scale * (1 + z*z))
+}
+
+// CauchyLogPdf is the log of the probability density function.
+func CauchyLogPdf(x float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	return -math.Log(math.Pi*scale) - math.Log1p(z*z)
+}
+
+// CauchyCdf is the cumulative distribution function.
+func CauchyCdf(x float64, loc float64, scale float64) float64 {
+	return 0.5 + math.Atan((x-loc)/scale)/math.Pi
+}
+
+// CauchyLogCdf is the log of the cumulative distribution function.
+func CauchyLogCdf(x float64, loc float64, scale float64) float64 {
+	return math.Log(CauchyCdf(x, loc, scale))
+}
+
+// CauchySf is the survival function (1 - cdf).
+func CauchySf(x float64, loc float64, scale float64) float64 {
+	return 0.5 - math.Atan((x-loc)/scale)/math.Pi
+}
+
+// CauchyLogSf is the log of the survival function.
+func CauchyLogSf(x float64, loc float64, scale float64) float64 {
+	return math.Log(CauchySf(x, loc, scale))
+}
+
+// CauchyPpf is the point percentile function (inverse of cdf).
+func CauchyPpf(p float64, loc float64, scale float64) float64 {
+	switch {
+	case p < 0 || p > 1:
+		return math.NaN()
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	}
+	return loc + scale*math.Tan(math.Pi*(p-0.5))
+}
+
+// CauchyIsf is the inverse survival function (inverse of sf).
+func CauchyIsf(p float64, loc float64, scale float64) float64 {
+	return 2*loc - CauchyPpf(p, loc, scale)
+}
+
+// CauchyMoment is the non-central (raw) moment of order n.
+// Only the moment of order zero exists, all others are NaN.
+func CauchyMoment(n int, loc float64, scale float64) float64 {
+	if n == 0 {
+		return 1
+	}
+	return math.NaN()
+}
+
+// CauchyStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+// None of them exist for the Cauchy distribution, so all are NaN.
+func CauchyStats(loc float64, scale float64, moments string) []float64 {
+	nan := math.NaN()
+	return selectStats(moments, loc, scale, nan, nan, nan, nan)
+}
+
+// CauchyEntropy is the differential entropy of the RV.
+func CauchyEntropy(loc float64, scale float64) float64 {
+	return math.Log(4 * math.Pi * scale)
+}
+
+// CauchyFit returns the maximum likelihood estimators for the Cauchy distribution.
+// Takes array of float64 values.
+// Returns array of the location followed by the scale.
+func CauchyFit(data []float64) [2]float64 {
+	if len(data) < 2 {
+		return [2]float64{math.NaN(), math.NaN()}
+	}
+
+	// Initial guess from the median and half the interquartile range
+	loc, _ := Median(data)
+	scale, _ := InterQuartileRange(data)
+	scale /= 2
+	if scale == 0 {
+		scale, _ = StandardDeviationPopulation(data)
+	}
+	if scale == 0 {
+		return [2]float64{loc, 0}
+	}
+
+	// The scale is optimized on a log scale to keep it positive
+	x := nelderMead(func(p []float64) float64 {
+		var ll float64
+		for _, x := range data {
+			ll += CauchyLogPdf(x, p[0], math.Exp(p[1]))
+		}
+		return -ll
+	}, []float64{loc, math.Log(scale)}, 0.5)
+
+	return [2]float64{x[0], math.Exp(x[1])}
+}
+
+// CauchyMedian is the median of the distribution.
+func CauchyMedian(loc float64, scale float64) float64 {
+	return loc
+}
+
+// CauchyMean is the mean/expected value of the distribution, which does not exist.
+func CauchyMean(loc float64, scale float64) float64 {
+	return math.NaN()
+}
+
+// CauchyVar is the variance of the distribution, which does not exist.
+func CauchyVar(loc float64, scale float64) float64 {
+	return math.NaN()
+}
+
+// CauchyStd is the standard deviation of the distribution, which does not exist.
+func CauchyStd(loc float64, scale float64) float64 {
+	return math.NaN()
+}
+
+// CauchyInterval finds endpoints of the range that contains alpha percent of the distribution.
+func CauchyInterval(alpha float64, loc float64, scale float64) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return CauchyPpf(p, loc, scale) })
+}
+
+// CauchyRvs generates random variates using the Point Percentile Function.
+func CauchyRvs(loc float64, scale float64, size int) []float64 {
+	r := newRand()
+	toReturn := make([]float64, size)
+	for i := range toReturn {
+		toReturn[i] = CauchyPpf(r.Float64(), loc, scale)
+	}
+	return toReturn
+}
@@ -0,0 +1,57 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestCauchy(t *testing.T) {
+	checkValues(t, "CauchyPdf", func(x float64) float64 { return stats.CauchyPdf(x, 0, 1) }, [][2]float64{{0, 1 / math.Pi}, {1, 0.5 / math.Pi}}, 1e-15)
+	checkValues(t, "CauchyCdf", func(x float64) float64 { return stats.CauchyCdf(x, 1, 2) }, [][2]float64{{1, 0.5}, {3, 0.75}}, 1e-15)
+	checkValues(t, "CauchySf", func(x float64) float64 { return stats.CauchySf(x, 1, 2) }, [][2]float64{{3, 0.25}}, 1e-15)
+	checkValues(t, "CauchyPpf", func(p float64) float64 { return stats.CauchyPpf(p, 1, 2) }, [][2]float64{{0.75, 3}, {0.5, 1}}, 1e-15)
+	checkValues(t, "CauchyIsf", func(p float64) float64 { return stats.CauchyIsf(p, 1, 2) }, [][2]float64{{0.25, 3}}, 1e-15)
+
+	if got := stats.CauchyMoment(1, 0, 1); !math.IsNaN(got) {
+		t.Errorf("CauchyMoment(1) => %v != NaN", got)
+	}
+	for _, s := range stats.CauchyStats(0, 1, "mvsk") {
+		if !math.IsNaN(s) {
+			t.Errorf("CauchyStats => %v, expected NaN", s)
+		}
+	}
+	if got := stats.CauchyEntropy(0, 1); !tolerance(got, math.Log(4*math.Pi), 1e-15) {
+		t.Errorf("CauchyEntropy => %v", got)
+	}
+
+	fit := stats.CauchyFit([]float64{-1.2, 0.4, 2.2, -0.1, 0.7, 2.9, -4.4, 1.8, 0.3, 0.1})
+	if !tolerance(fit[0], 0.37416017310807986, 1e-5) || !tolerance(fit[1], 0.72100424577402, 1e-5) {
+		t.Errorf("CauchyFit => %v", fit)
+	}
+
+	if len(stats.CauchyRvs(0, 1, 101)) != 101 {
+		t.Error("Input size=101, Expected 101")
+	}
+}
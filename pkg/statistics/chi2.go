@@ -0,0 +1,135 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// The chi-squared distribution with df degrees of freedom is the gamma
+// distribution with shape df/2 and twice the scale.
+
+// Chi2Pdf is the probability density function of the chi-squared distribution
+// with df degrees of freedom.
+func Chi2Pdf(x float64, df float64, loc float64, scale float64) float64 {
+	return GammaPdf(x, df/2, loc, 2*scale)
+}
+
+// Chi2LogPdf is the log of the probability density function.
+func Chi2LogPdf(x float64, df float64, loc float64, scale float64) float64 {
+	return GammaLogPdf(x, df/2, loc, 2*scale)
+}
+
+// Chi2Cdf is the cumulative distribution function.
+func Chi2Cdf(x float64, df float64, loc float64, scale float64) float64 {
+	return GammaCdf(x, df/2, loc, 2*scale)
+}
+
+// Chi2LogCdf is the log of the cumulative distribution function.
+func Chi2LogCdf(x float64, df float64, loc float64, scale float64) float64 {
+	return GammaLogCdf(x, df/2, loc, 2*scale)
+}
+
+// Chi2Sf is the survival function (1 - cdf).
+func Chi2Sf(x float64, df float64, loc float64, scale float64) float64 {
+	return GammaSf(x, df/2, loc, 2*scale)
+}
+
+// Chi2LogSf is the log of the survival function.
+func Chi2LogSf(x float64, df float64, loc float64, scale float64) float64 {
+	return GammaLogSf(x, df/2, loc, 2*scale)
+}
+
+// Chi2Ppf is the point percentile function (inverse of cdf).
+func Chi2Ppf(p float64, df float64, loc float64, scale float64) float64 {
+	return GammaPpf(p, df/2, loc, 2*scale)
+}
+
+// Chi2Isf is the inverse survival function (inverse of sf).
+func Chi2Isf(p float64, df float64, loc float64, scale float64) float64 {
+	return GammaIsf(p, df/2, loc, 2*scale)
+}
+
+// Chi2Moment is the non-central (raw) moment of order n.
+func Chi2Moment(n int, df float64, loc float64, scale float64) float64 {
+	return GammaMoment(n, df/2, loc, 2*scale)
+}
+
+// Chi2Stats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func Chi2Stats(df float64, loc float64, scale float64, moments string) []float64 {
+	return GammaStats(df/2, loc, 2*scale, moments)
+}
+
+// Chi2Entropy is the differential entropy of the RV.
+func Chi2Entropy(df float64, loc float64, scale float64) float64 {
+	return GammaEntropy(df/2, loc, 2*scale)
+}
+
+// Chi2Fit returns the maximum likelihood estimator of the degrees of freedom
+// of the chi-squared distribution. The location is fixed at zero and the scale
+// at one, so all values must be positive.
+// Takes array of float64 values.
+// Returns array of the degrees of freedom followed by the location and the scale.
+func Chi2Fit(data []float64) [3]float64 {
+	if len(data) == 0 {
+		return [3]float64{math.NaN(), 0, 1}
+	}
+	for _, x := range data {
+		if x <= 0 {
+			return [3]float64{math.NaN(), 0, 1}
+		}
+	}
+
+	// The likelihood is maximal where digamma(df/2) = mean(log(x)) - log(2)
+	_, logMean := meanLog(data)
+	return [3]float64{2 * invDigamma(logMean-math.Ln2), 0, 1}
+}
+
+// Chi2Median is the median of the distribution.
+func Chi2Median(df float64, loc float64, scale float64) float64 {
+	return GammaMedian(df/2, loc, 2*scale)
+}
+
+// Chi2Mean is the mean/expected value of the distribution.
+func Chi2Mean(df float64, loc float64, scale float64) float64 {
+	return GammaMean(df/2, loc, 2*scale)
+}
+
+// Chi2Var is the variance of the distribution.
+func Chi2Var(df float64, loc float64, scale float64) float64 {
+	return GammaVar(df/2, loc, 2*scale)
+}
+
+// Chi2Std is the standard deviation of the distribution.
+func Chi2Std(df float64, loc float64, scale float64) float64 {
+	return GammaStd(df/2, loc, 2*scale)
+}
+
+// Chi2Interval finds endpoints of the range that contains alpha percent of the distribution.
+func Chi2Interval(alpha float64, df float64, loc float64, scale float64) [2]float64 {
+	return GammaInterval(alpha, df/2, loc, 2*scale)
+}
+
+// Chi2Rvs generates random variates as scaled gamma variates.
+func Chi2Rvs(df float64, loc float64, scale float64, size int) []float64 {
+	return GammaRvs(df/2, loc, 2*scale, size)
+}
@@ -0,0 +1,61 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestChi2(t *testing.T) {
+	checkValues(t, "Chi2Pdf", func(x float64) float64 { return stats.Chi2Pdf(x, 4, 0, 1) }, [][2]float64{{3, 0.16734762011132237}}, 1e-12)
+	checkValues(t, "Chi2Cdf", func(x float64) float64 { return stats.Chi2Cdf(x, 4, 0, 1) }, [][2]float64{{3, 0.4421745996289252}}, 1e-12)
+	checkValues(t, "Chi2Sf", func(x float64) float64 { return stats.Chi2Sf(x, 4, 0, 1) }, [][2]float64{{9.4877, 0.050000599541234675}}, 1e-12)
+	checkValues(t, "Chi2Ppf", func(p float64) float64 { return stats.Chi2Ppf(p, 4, 0, 1) }, [][2]float64{{0.95, 9.487729036781154}}, 1e-12)
+	checkValues(t, "Chi2Isf", func(p float64) float64 { return stats.Chi2Isf(p, 4, 0, 1) }, [][2]float64{{0.05, 9.487729036781154}}, 1e-12)
+
+	if got := stats.Chi2Moment(2, 4, 0, 1); !tolerance(got, 24, 1e-12) {
+		t.Errorf("Chi2Moment(2) => %v != 24", got)
+	}
+	s := stats.Chi2Stats(4, 0, 1, "mvsk")
+	if s[0] != 4 || s[1] != 8 || !tolerance(s[2], 1.414213562373095, 1e-15) || s[3] != 3 {
+		t.Errorf("Chi2Stats(4) => %v", s)
+	}
+	if got := stats.Chi2Entropy(4, 0, 1); !tolerance(got, 2.270362845461498, 1e-12) {
+		t.Errorf("Chi2Entropy(4) => %v", got)
+	}
+
+	fit := stats.Chi2Fit([]float64{1.2, 3.4, 2.2, 5.1, 0.7, 2.9, 4.4, 1.8})
+	if !tolerance(fit[0], 3.213920926777403, 1e-9) {
+		t.Errorf("Chi2Fit => %v", fit)
+	}
+
+	if len(stats.Chi2Rvs(3, 0, 1, 101)) != 101 {
+		t.Error("Input size=101, Expected 101")
+	}
+}
+
+func TestChi2LargeDf(t *testing.T) {
+	checkValues(t, "Chi2Cdf", func(x float64) float64 { return stats.Chi2Cdf(x, 1e6, 0, 1) }, [][2]float64{{1e6, 0.5001880631966055}, {998000, 0.07858029198714495}}, 1e-9)
+	checkValues(t, "Chi2Sf", func(x float64) float64 { return stats.Chi2Sf(x, 1e6, 0, 1) }, [][2]float64{{1e6, 0.4998119368033945}}, 1e-9)
+	checkValues(t, "Chi2Ppf", func(p float64) float64 { return stats.Chi2Ppf(p, 1e6, 0, 1) }, [][2]float64{{0.5, 999999.3333334123}, {0.975, 1002773.701467926}}, 1e-12)
+}
@@ -0,0 +1,178 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Helpers shared by the discrete probability distributions. They follow
+// the API of the continuous distributions, but take an int k instead of
+// x and neither a location nor a scale parameter. Their probability mass
+// functions are evaluated in log space, so they do not overflow like Ncr
+// does for large arguments.
+
+// LogNcr is the natural logarithm of n choose r. Unlike Ncr it
+// does not overflow. It returns -Inf if r is not within [0, n].
+func LogNcr(n, r int) float64 {
+	if r < 0 || r > n {
+		return math.Inf(-1)
+	}
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(r + 1))
+	c, _ := math.Lgamma(float64(n - r + 1))
+	return a - b - c
+}
+
+// discreteSearch returns the smallest k within [lo, hi] for which pred
+// holds, starting the search at guess. pred must be monotone in k and
+// hold at hi. An hi below lo denotes an unbounded support.
+func discreteSearch(pred func(k int) bool, lo, hi, guess int) int {
+	unbounded := hi < lo
+	k := guess
+	if k < lo {
+		k = lo
+	}
+	if !unbounded && k > hi {
+		k = hi
+	}
+
+	// Find a bracket a < b with pred(b) and, unless a is below lo, !pred(a)
+	var a, b int
+	if pred(k) {
+		b = k
+		for step := 1; ; step *= 2 {
+			a = b - step
+			if a < lo {
+				a = lo - 1
+				break
+			}
+			if !pred(a) {
+				break
+			}
+			b = a
+		}
+	} else {
+		a = k
+		for step := 1; ; step *= 2 {
+			b = a + step
+			if !unbounded && b >= hi {
+				b = hi
+				break
+			}
+			if pred(b) {
+				break
+			}
+			a = b
+		}
+	}
+
+	// Bisect the bracket
+	for b-a > 1 {
+		m := a + (b-a)/2
+		if pred(m) {
+			b = m
+		} else {
+			a = m
+		}
+	}
+	return b
+}
+
+// searchTol is the relative tolerance of discretePpf and discreteIsf. It
+// absorbs the rounding errors of the cdf and sf, so that e.g. the Ppf of
+// the Cdf of k is k.
+const searchTol = 1e-12
+
+// discretePpf returns the smallest k with cdf(k) >= p. Like in scipy, the
+// Ppf of 0 is the lower edge of the support minus one.
+func discretePpf(p float64, cdf func(k int) float64, lo, hi, guess int) float64 {
+	switch {
+	case math.IsNaN(p) || p < 0 || p > 1:
+		return math.NaN()
+	case p == 0:
+		return float64(lo - 1)
+	case p == 1 && hi < lo:
+		return math.Inf(1)
+	case p == 1:
+		return float64(hi)
+	}
+	return float64(discreteSearch(func(k int) bool { return cdf(k) >= p*(1-searchTol) }, lo, hi, guess))
+}
+
+// discreteIsf returns the smallest k with sf(k) <= p. Like in scipy, the
+// Isf of 1 is the lower edge of the support minus one.
+func discreteIsf(p float64, sf func(k int) float64, lo, hi, guess int) float64 {
+	switch {
+	case math.IsNaN(p) || p < 0 || p > 1:
+		return math.NaN()
+	case p == 1:
+		return float64(lo - 1)
+	case p == 0 && hi < lo:
+		return math.Inf(1)
+	case p == 0:
+		return float64(hi)
+	}
+	return float64(discreteSearch(func(k int) bool { return sf(k) <= p*(1+searchTol) }, lo, hi, guess))
+}
+
+// normalGuess approximates the quantile p of a discrete distribution
+// by the normal distribution with the same mean and variance.
+func normalGuess(p, mean, variance float64) int {
+	g := mean + math.Sqrt(variance)*NormPpf(p, 0, 1)
+	if math.IsNaN(g) || math.IsInf(g, 0) || math.Abs(g) > 1e15 {
+		return int(mean)
+	}
+	return int(math.Floor(g))
+}
+
+// meanInt returns the mean of integer data.
+func meanInt(data []int) float64 {
+	var sum float64
+	for _, k := range data {
+		sum += float64(k)
+	}
+	return sum / float64(len(data))
+}
+
+// randOpenUnit returns a uniform random variate in (0, 1), whose Ppf and
+// Isf are within the support of a discrete distribution.
+func randOpenUnit(r *rand.Rand) float64 {
+	for {
+		if u := r.Float64(); u > 0 {
+			return u
+		}
+	}
+}
+
+// randPoisson draws a Poisson distributed random variate by multiplying
+// uniform variates for small mu and by inversion otherwise.
+func randPoisson(r *rand.Rand, mu float64) int {
+	if mu >= 30 {
+		return int(PoissonPpf(randOpenUnit(r), mu))
+	}
+	k, limit := 0, math.Exp(-mu)
+	for prod := r.Float64(); prod > limit; prod *= r.Float64() {
+		k++
+	}
+	return k
+}
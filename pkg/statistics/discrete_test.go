@@ -0,0 +1,39 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestLogNcr(t *testing.T) {
+	if got := stats.LogNcr(10, 3); !tolerance(got, math.Log(120), 1e-14) {
+		t.Errorf("LogNcr(10, 3) => %v != log(120)", got)
+	}
+	if got := stats.LogNcr(10, 11); !math.IsInf(got, -1) {
+		t.Errorf("LogNcr(10, 11) => %v != -Inf", got)
+	}
+	if got := stats.LogNcr(1000, 500); !tolerance(got, 689.4672615678, 1e-12) {
+		t.Errorf("LogNcr(1000, 500) => %v", got)
+	}
+}
@@ -0,0 +1,205 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Helpers shared by the probability distributions. Every distribution
+// follows the API of the normal distribution (see NormPdf) and accepts
+// a location and a scale parameter after its shape parameters.
+
+// newRand returns a random number generator seeded with the current time.
+func newRand() *rand.Rand {
+	return rand.New(rand.NewSource(unixnano()))
+}
+
+// randGamma draws a gamma distributed random variate with shape a
+// and scale 1 using the method of Marsaglia and Tsang.
+func randGamma(r *rand.Rand, a float64) float64 {
+	if a < 1 {
+		// Boost the shape and correct by a uniform power
+		return randGamma(r, a+1) * math.Pow(r.Float64(), 1/a)
+	}
+
+	d := a - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		var x, v float64
+		for v <= 0 {
+			x = r.NormFloat64()
+			v = 1 + c*x
+		}
+		v = v * v * v
+		u := r.Float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// choose returns the binomial coefficient n over k as a float64.
+func choose(n, k int) float64 {
+	c := 1.0
+	for i := 1; i <= k; i++ {
+		c = c * float64(n-k+i) / float64(i)
+	}
+	return c
+}
+
+// rawMoment returns the non-central moment of order n of loc + scale*Y,
+// where std returns the non-central moment of order k of Y.
+func rawMoment(n int, loc, scale float64, std func(k int) float64) float64 {
+	if n < 0 {
+		return math.NaN()
+	}
+
+	var m float64
+	for k := 0; k <= n; k++ {
+		c := choose(n, k) * math.Pow(loc, float64(n-k)) * math.Pow(scale, float64(k))
+		if c == 0 {
+			continue
+		}
+		if k == 0 {
+			m += c
+		} else {
+			m += c * std(k)
+		}
+	}
+	return m
+}
+
+// selectStats returns the moments requested by any of 'mvsk'
+// in that order, as done by NormStats. The mean and variance
+// of the standard distribution are shifted by loc and scale.
+func selectStats(moments string, loc, scale, m, v, s, k float64) []float64 {
+	var toReturn []float64
+	if strings.ContainsAny(moments, "m") {
+		toReturn = append(toReturn, loc+scale*m)
+	}
+	if strings.ContainsAny(moments, "v") {
+		toReturn = append(toReturn, scale*scale*v)
+	}
+	if strings.ContainsAny(moments, "s") {
+		toReturn = append(toReturn, s)
+	}
+	if strings.ContainsAny(moments, "k") {
+		toReturn = append(toReturn, k)
+	}
+	return toReturn
+}
+
+// interval returns the endpoints of the range that contains
+// alpha percent of the distribution with the given ppf.
+func interval(alpha float64, ppf func(p float64) float64) [2]float64 {
+	q1 := (1.0 - alpha) / 2
+	q2 := (1.0 + alpha) / 2
+	return [2]float64{ppf(q1), ppf(q2)}
+}
+
+// meanLog returns the mean of the input and of its logarithm.
+func meanLog(data []float64) (float64, float64) {
+	var sum, sumLog float64
+	for _, x := range data {
+		sum += x
+		sumLog += math.Log(x)
+	}
+	n := float64(len(data))
+	return sum / n, sumLog / n
+}
+
+// nelderMead minimizes f starting at x0 using the Nelder-Mead simplex
+// method. The initial simplex extends step in every direction.
+func nelderMead(f func(x []float64) float64, x0 []float64, step float64) []float64 {
+	const (
+		maxIter = 5000
+		tol     = 1e-12
+	)
+
+	n := len(x0)
+	type vertex struct {
+		x []float64
+		f float64
+	}
+	simplex := make([]vertex, n+1)
+	for i := range simplex {
+		x := append([]float64(nil), x0...)
+		if i > 0 {
+			x[i-1] += step
+		}
+		simplex[i] = vertex{x, f(x)}
+	}
+
+	point := func(c []float64, t float64, x []float64) []float64 {
+		p := make([]float64, n)
+		for j := range p {
+			p[j] = c[j] + t*(x[j]-c[j])
+		}
+		return p
+	}
+
+	for iter := 0; iter < maxIter; iter++ {
+		sort.Slice(simplex, func(i, j int) bool { return simplex[i].f < simplex[j].f })
+
+		best, worst := simplex[0], simplex[n]
+		if math.Abs(worst.f-best.f) <= tol*(math.Abs(best.f)+tol) {
+			break
+		}
+
+		centroid := make([]float64, n)
+		for _, v := range simplex[:n] {
+			for j := range centroid {
+				centroid[j] += v.x[j] / float64(n)
+			}
+		}
+
+		xr := point(centroid, -1, worst.x)
+		fr := f(xr)
+		switch {
+		case fr < best.f:
+			xe := point(centroid, -2, worst.x)
+			if fe := f(xe); fe < fr {
+				simplex[n] = vertex{xe, fe}
+			} else {
+				simplex[n] = vertex{xr, fr}
+			}
+		case fr < simplex[n-1].f:
+			simplex[n] = vertex{xr, fr}
+		default:
+			xc := point(centroid, 0.5, worst.x)
+			if fc := f(xc); fc < worst.f {
+				simplex[n] = vertex{xc, fc}
+				continue
+			}
+			for i := 1; i <= n; i++ {
+				x := point(best.x, 0.5, simplex[i].x)
+				simplex[i] = vertex{x, f(x)}
+			}
+		}
+	}
+
+	sort.Slice(simplex, func(i, j int) bool { return simplex[i].f < simplex[j].f })
+	return simplex[0].x
+}
@@ -51,4 +51,6 @@ var (
 	ErrInfValue = statsError{"Value is infinite."}
 	// ErrYCoord Y Value must be greater than zero
 	ErrYCoord = statsError{"Y Value must be greater than zero."}
+	// ErrSingular Matrix is singular
+	ErrSingular = statsError{"Matrix is singular."}
 )
@@ -0,0 +1,148 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// ExponPdf is the probability density function of the exponential distribution.
+func ExponPdf(x float64, loc float64, scale float64) float64 {
+	return math.Exp(ExponLogPdf(x, loc, scale))
+}
+
+// ExponLogPdf is the log of the probability density function.
+func ExponLogPdf(x float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z < 0 {
+		return math.Inf(-1)
+	}
+	return -z - math.Log(scale)
+}
+
+// ExponCdf is the cumulative distribution function.
+func ExponCdf(x float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z < 0 {
+		return 0
+	}
+	return -math.Expm1(-z)
+}
+
+// ExponLogCdf is the log of the cumulative distribution function.
+func ExponLogCdf(x float64, loc float64, scale float64) float64 {
+	return math.Log(ExponCdf(x, loc, scale))
+}
+
+// ExponSf is the survival function (1 - cdf).
+func ExponSf(x float64, loc float64, scale float64) float64 {
+	return math.Exp(ExponLogSf(x, loc, scale))
+}
+
+// ExponLogSf is the log of the survival function.
+func ExponLogSf(x float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z < 0 {
+		return 0
+	}
+	return -z
+}
+
+// ExponPpf is the point percentile function (inverse of cdf).
+func ExponPpf(p float64, loc float64, scale float64) float64 {
+	if p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return loc - scale*math.Log1p(-p)
+}
+
+// ExponIsf is the inverse survival function (inverse of sf).
+func ExponIsf(p float64, loc float64, scale float64) float64 {
+	if p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return loc - scale*math.Log(p)
+}
+
+// ExponMoment is the non-central (raw) moment of order n.
+func ExponMoment(n int, loc float64, scale float64) float64 {
+	return rawMoment(n, loc, scale, func(k int) float64 {
+		return math.Gamma(float64(k + 1))
+	})
+}
+
+// ExponStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func ExponStats(loc float64, scale float64, moments string) []float64 {
+	return selectStats(moments, loc, scale, 1, 1, 2, 6)
+}
+
+// ExponEntropy is the differential entropy of the RV.
+func ExponEntropy(loc float64, scale float64) float64 {
+	return 1 + math.Log(scale)
+}
+
+// ExponFit returns the maximum likelihood estimators for the exponential distribution.
+// Takes array of float64 values.
+// Returns array of the location (the minimum) followed by the scale.
+func ExponFit(data []float64) [2]float64 {
+	if len(data) == 0 {
+		return [2]float64{math.NaN(), math.NaN()}
+	}
+	min, _ := Min(data)
+	mean, _ := Mean(data)
+	return [2]float64{min, mean - min}
+}
+
+// ExponMedian is the median of the distribution.
+func ExponMedian(loc float64, scale float64) float64 {
+	return loc + scale*math.Ln2
+}
+
+// ExponMean is the mean/expected value of the distribution.
+func ExponMean(loc float64, scale float64) float64 {
+	return loc + scale
+}
+
+// ExponVar is the variance of the distribution.
+func ExponVar(loc float64, scale float64) float64 {
+	return scale * scale
+}
+
+// ExponStd is the standard deviation of the distribution.
+func ExponStd(loc float64, scale float64) float64 {
+	return scale
+}
+
+// ExponInterval finds endpoints of the range that contains alpha percent of the distribution.
+func ExponInterval(alpha float64, loc float64, scale float64) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return ExponPpf(p, loc, scale) })
+}
+
+// ExponRvs generates random variates using the ziggurat method of math/rand.
+func ExponRvs(loc float64, scale float64, size int) []float64 {
+	r := newRand()
+	toReturn := make([]float64, size)
+	for i := range toReturn {
+		toReturn[i] = loc + scale*r.ExpFloat64()
+	}
+	return toReturn
+}
@@ -0,0 +1,56 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestExpon(t *testing.T) {
+	checkValues(t, "ExponPdf", func(x float64) float64 { return stats.ExponPdf(x, 0, 2) }, [][2]float64{{1, 0.5 * math.Exp(-0.5)}, {-1, 0}}, 1e-15)
+	checkValues(t, "ExponCdf", func(x float64) float64 { return stats.ExponCdf(x, 0, 2) }, [][2]float64{{1, 1 - math.Exp(-0.5)}}, 1e-15)
+	checkValues(t, "ExponSf", func(x float64) float64 { return stats.ExponSf(x, 0, 2) }, [][2]float64{{1, math.Exp(-0.5)}, {-1, 1}}, 1e-15)
+	checkValues(t, "ExponPpf", func(p float64) float64 { return stats.ExponPpf(p, 1, 2) }, [][2]float64{{0.5, 1 + 2*math.Ln2}, {0, 1}}, 1e-15)
+	checkValues(t, "ExponIsf", func(p float64) float64 { return stats.ExponIsf(p, 1, 2) }, [][2]float64{{0.5, 1 + 2*math.Ln2}}, 1e-15)
+
+	if got := stats.ExponMoment(3, 0, 1); got != 6 {
+		t.Errorf("ExponMoment(3) => %v != 6", got)
+	}
+	if s := stats.ExponStats(1, 2, "mvsk"); s[0] != 3 || s[1] != 4 || s[2] != 2 || s[3] != 6 {
+		t.Errorf("ExponStats(1, 2) => %v", s)
+	}
+	if got := stats.ExponEntropy(0, 1); got != 1 {
+		t.Errorf("ExponEntropy => %v != 1", got)
+	}
+	if got := stats.ExponMedian(0, 1); got != math.Ln2 {
+		t.Errorf("ExponMedian => %v != ln(2)", got)
+	}
+	if fit := stats.ExponFit([]float64{1, 2, 3, 6}); fit != [2]float64{1, 2} {
+		t.Errorf("ExponFit => %v != [1 2]", fit)
+	}
+
+	if len(stats.ExponRvs(0, 1, 101)) != 101 {
+		t.Error("Input size=101, Expected 101")
+	}
+}
@@ -0,0 +1,209 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// FPdf is the probability density function of the F distribution
+// with dfn and dfd degrees of freedom.
+func FPdf(x float64, dfn float64, dfd float64, loc float64, scale float64) float64 {
+	return math.Exp(FLogPdf(x, dfn, dfd, loc, scale))
+}
+
+// FLogPdf is the log of the probability density function.
+func FLogPdf(x float64, dfn float64, dfd float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	switch {
+	case z < 0:
+		return math.Inf(-1)
+	case z == 0 && dfn < 2:
+		return math.Inf(1)
+	case z == 0 && dfn > 2:
+		return math.Inf(-1)
+	}
+
+	var t float64
+	if dfn != 2 {
+		t = (dfn/2 - 1) * math.Log(z)
+	}
+	return 0.5*(dfn*math.Log(dfn)+dfd*math.Log(dfd)) + t - (dfn+dfd)/2*math.Log(dfd+dfn*z) -
+		lbeta(dfn/2, dfd/2) - math.Log(scale)
+}
+
+// FCdf is the cumulative distribution function.
+func FCdf(x float64, dfn float64, dfd float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z <= 0 {
+		return 0
+	}
+	return regIncBeta(dfn/2, dfd/2, dfn*z/(dfn*z+dfd))
+}
+
+// FLogCdf is the log of the cumulative distribution function.
+func FLogCdf(x float64, dfn float64, dfd float64, loc float64, scale float64) float64 {
+	return math.Log(FCdf(x, dfn, dfd, loc, scale))
+}
+
+// FSf is the survival function (1 - cdf).
+func FSf(x float64, dfn float64, dfd float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z <= 0 {
+		return 1
+	}
+	return regIncBeta(dfd/2, dfn/2, dfd/(dfd+dfn*z))
+}
+
+// FLogSf is the log of the survival function.
+func FLogSf(x float64, dfn float64, dfd float64, loc float64, scale float64) float64 {
+	return math.Log(FSf(x, dfn, dfd, loc, scale))
+}
+
+// FPpf is the point percentile function (inverse of cdf).
+func FPpf(p float64, dfn float64, dfd float64, loc float64, scale float64) float64 {
+	x := invRegIncBeta(dfn/2, dfd/2, p)
+	return loc + scale*dfd*x/(dfn*(1-x))
+}
+
+// FIsf is the inverse survival function (inverse of sf).
+func FIsf(p float64, dfn float64, dfd float64, loc float64, scale float64) float64 {
+	y := invRegIncBeta(dfd/2, dfn/2, p)
+	return loc + scale*dfd*(1-y)/(dfn*y)
+}
+
+// FMoment is the non-central (raw) moment of order n.
+// Moments of order n >= dfd/2 do not exist and are returned as Inf.
+func FMoment(n int, dfn float64, dfd float64, loc float64, scale float64) float64 {
+	return rawMoment(n, loc, scale, func(k int) float64 {
+		fk := float64(k)
+		if 2*fk >= dfd {
+			return math.Inf(1)
+		}
+		a, _ := math.Lgamma(dfn/2 + fk)
+		b, _ := math.Lgamma(dfd/2 - fk)
+		c, _ := math.Lgamma(dfn / 2)
+		d, _ := math.Lgamma(dfd / 2)
+		return math.Exp(fk*math.Log(dfd/dfn) + a + b - c - d)
+	})
+}
+
+// FStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+// Moments that do not exist for the given dfd are NaN, or Inf if they diverge.
+func FStats(dfn float64, dfd float64, loc float64, scale float64, moments string) []float64 {
+	d1, d2 := dfn, dfd
+	m, v, s, k := math.Inf(1), math.NaN(), math.NaN(), math.NaN()
+	if d2 > 2 {
+		m = d2 / (d2 - 2)
+		v = math.Inf(1)
+	}
+	if d2 > 4 {
+		v = 2 * d2 * d2 * (d1 + d2 - 2) / (d1 * (d2 - 2) * (d2 - 2) * (d2 - 4))
+	}
+	if d2 > 6 {
+		s = (2*d1 + d2 - 2) * math.Sqrt(8*(d2-4)) / ((d2 - 6) * math.Sqrt(d1*(d1+d2-2)))
+	}
+	if d2 > 8 {
+		k = 12 * (d1*(5*d2-22)*(d1+d2-2) + (d2-4)*(d2-2)*(d2-2)) / (d1 * (d2 - 6) * (d2 - 8) * (d1 + d2 - 2))
+	}
+	return selectStats(moments, loc, scale, m, v, s, k)
+}
+
+// FEntropy is the differential entropy of the RV.
+func FEntropy(dfn float64, dfd float64, loc float64, scale float64) float64 {
+	h1, h2 := dfn/2, dfd/2
+	return lbeta(h1, h2) + (1-h1)*digamma(h1) - (1+h2)*digamma(h2) + (h1+h2)*digamma(h1+h2) +
+		math.Log(dfd/dfn) + math.Log(scale)
+}
+
+// FFit returns the maximum likelihood estimators for the F distribution.
+// The location is fixed at zero and the scale at one, so all values must be positive.
+// Takes array of float64 values.
+// Returns array of the degrees of freedom dfn and dfd followed by the location and the scale.
+func FFit(data []float64) [4]float64 {
+	nan := [4]float64{math.NaN(), math.NaN(), 0, 1}
+	if len(data) < 2 {
+		return nan
+	}
+	for _, x := range data {
+		if x <= 0 {
+			return nan
+		}
+	}
+
+	// Initial guess of dfd from the mean d/(d-2)
+	dfd := 10.0
+	if m, _ := Mean(data); m > 1 {
+		dfd = math.Min(2*m/(m-1), 100)
+	}
+
+	// The degrees of freedom are optimized on a log scale to keep them positive
+	x := nelderMead(func(p []float64) float64 {
+		var ll float64
+		for _, x := range data {
+			ll += FLogPdf(x, math.Exp(p[0]), math.Exp(p[1]), 0, 1)
+		}
+		if math.IsNaN(ll) {
+			return math.Inf(1)
+		}
+		return -ll
+	}, []float64{math.Log(5), math.Log(dfd)}, 0.5)
+
+	return [4]float64{math.Exp(x[0]), math.Exp(x[1]), 0, 1}
+}
+
+// FMedian is the median of the distribution.
+func FMedian(dfn float64, dfd float64, loc float64, scale float64) float64 {
+	return FPpf(0.5, dfn, dfd, loc, scale)
+}
+
+// FMean is the mean/expected value of the distribution.
+func FMean(dfn float64, dfd float64, loc float64, scale float64) float64 {
+	return FStats(dfn, dfd, loc, scale, "m")[0]
+}
+
+// FVar is the variance of the distribution.
+func FVar(dfn float64, dfd float64, loc float64, scale float64) float64 {
+	return FStats(dfn, dfd, loc, scale, "v")[0]
+}
+
+// FStd is the standard deviation of the distribution.
+func FStd(dfn float64, dfd float64, loc float64, scale float64) float64 {
+	return math.Sqrt(FVar(dfn, dfd, loc, scale))
+}
+
+// FInterval finds endpoints of the range that contains alpha percent of the distribution.
+func FInterval(alpha float64, dfn float64, dfd float64, loc float64, scale float64) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return FPpf(p, dfn, dfd, loc, scale) })
+}
+
+// FRvs generates random variates as the ratio of scaled chi-squared variates.
+func FRvs(dfn float64, dfd float64, loc float64, scale float64, size int) []float64 {
+	r := newRand()
+	toReturn := make([]float64, size)
+	for i := range toReturn {
+		x := 2 * randGamma(r, dfn/2) / dfn
+		y := 2 * randGamma(r, dfd/2) / dfd
+		toReturn[i] = loc + scale*x/y
+	}
+	return toReturn
+}
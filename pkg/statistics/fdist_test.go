@@ -0,0 +1,67 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestF(t *testing.T) {
+	checkValues(t, "FPdf", func(x float64) float64 { return stats.FPdf(x, 3, 12, 0, 1) }, [][2]float64{{1.2, 0.33676900957380346}}, 1e-12)
+	checkValues(t, "FCdf", func(x float64) float64 { return stats.FCdf(x, 3, 12, 0, 1) }, [][2]float64{{1.2, 0.6484873592760154}, {-1, 0}}, 1e-12)
+	checkValues(t, "FSf", func(x float64) float64 { return stats.FSf(x, 3, 12, 0, 1) }, [][2]float64{{3.49, 0.05001096647177694}}, 1e-12)
+	checkValues(t, "FPpf", func(p float64) float64 { return stats.FPpf(p, 3, 12, 0, 1) }, [][2]float64{{0.95, 3.490294819497605}}, 1e-12)
+	checkValues(t, "FIsf", func(p float64) float64 { return stats.FIsf(p, 3, 12, 0, 1) }, [][2]float64{{0.05, 3.490294819497605}}, 1e-12)
+
+	if got := stats.FPdf(0, 2, 5, 0, 1); got != 1 {
+		t.Errorf("FPdf(0, dfn=2) => %v != 1", got)
+	}
+	if got := stats.FMoment(2, 3, 12, 0, 1); !tolerance(got, 3, 1e-12) {
+		t.Errorf("FMoment(2) => %v != 3", got)
+	}
+	if got := stats.FMoment(2, 3, 4, 0, 1); !math.IsInf(got, 1) {
+		t.Errorf("FMoment(2, dfd=4) => %v != Inf", got)
+	}
+
+	s := stats.FStats(3, 12, 0, 1, "mvsk")
+	exp := []float64{1.2, 1.56, 3.416067281175192, 29.256410256410255}
+	for i := range s {
+		if !tolerance(s[i], exp[i], 1e-12) {
+			t.Errorf("FStats(3, 12) => %v != %v", s, exp)
+			break
+		}
+	}
+	if got := stats.FEntropy(3, 12, 0, 1); !tolerance(got, 1.1582520953417814, 1e-12) {
+		t.Errorf("FEntropy => %v", got)
+	}
+
+	fit := stats.FFit([]float64{1.2, 0.4, 2.2, 0.1, 0.7, 2.9, 4.4, 1.8, 0.3, 0.9})
+	if !tolerance(fit[0], 3.1367491700190344, 1e-5) || !tolerance(fit[1], 6.754717831380011, 1e-5) {
+		t.Errorf("FFit => %v", fit)
+	}
+
+	if len(stats.FRvs(3, 12, 0, 1, 101)) != 101 {
+		t.Error("Input size=101, Expected 101")
+	}
+}
@@ -0,0 +1,171 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// GammaPdf is the probability density function of the gamma distribution
+// with shape a.
+func GammaPdf(x float64, a float64, loc float64, scale float64) float64 {
+	return math.Exp(GammaLogPdf(x, a, loc, scale))
+}
+
+// GammaLogPdf is the log of the probability density function.
+func GammaLogPdf(x float64, a float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	switch {
+	case z < 0:
+		return math.Inf(-1)
+	case z == 0 && a < 1:
+		return math.Inf(1)
+	case z == 0 && a > 1:
+		return math.Inf(-1)
+	case z == 0:
+		return -math.Log(scale)
+	}
+	lg, _ := math.Lgamma(a)
+	return (a-1)*math.Log(z) - z - lg - math.Log(scale)
+}
+
+// GammaCdf is the cumulative distribution function.
+func GammaCdf(x float64, a float64, loc float64, scale float64) float64 {
+	return regIncGammaP(a, (x-loc)/scale)
+}
+
+// GammaLogCdf is the log of the cumulative distribution function.
+func GammaLogCdf(x float64, a float64, loc float64, scale float64) float64 {
+	return math.Log(GammaCdf(x, a, loc, scale))
+}
+
+// GammaSf is the survival function (1 - cdf).
+func GammaSf(x float64, a float64, loc float64, scale float64) float64 {
+	return regIncGammaQ(a, (x-loc)/scale)
+}
+
+// GammaLogSf is the log of the survival function.
+func GammaLogSf(x float64, a float64, loc float64, scale float64) float64 {
+	return math.Log(GammaSf(x, a, loc, scale))
+}
+
+// GammaPpf is the point percentile function (inverse of cdf).
+func GammaPpf(p float64, a float64, loc float64, scale float64) float64 {
+	return loc + scale*invRegIncGammaP(a, p)
+}
+
+// GammaIsf is the inverse survival function (inverse of sf).
+func GammaIsf(p float64, a float64, loc float64, scale float64) float64 {
+	return GammaPpf(1-p, a, loc, scale)
+}
+
+// GammaMoment is the non-central (raw) moment of order n.
+func GammaMoment(n int, a float64, loc float64, scale float64) float64 {
+	lg, _ := math.Lgamma(a)
+	return rawMoment(n, loc, scale, func(k int) float64 {
+		lgk, _ := math.Lgamma(a + float64(k))
+		return math.Exp(lgk - lg)
+	})
+}
+
+// GammaStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func GammaStats(a float64, loc float64, scale float64, moments string) []float64 {
+	return selectStats(moments, loc, scale, a, a, 2/math.Sqrt(a), 6/a)
+}
+
+// GammaEntropy is the differential entropy of the RV.
+func GammaEntropy(a float64, loc float64, scale float64) float64 {
+	lg, _ := math.Lgamma(a)
+	return a + lg + (1-a)*digamma(a) + math.Log(scale)
+}
+
+// GammaFit returns the maximum likelihood estimators for the gamma distribution.
+// The location is fixed at zero, so all values must be positive.
+// Takes array of float64 values.
+// Returns array of the shape followed by the location and the scale.
+func GammaFit(data []float64) [3]float64 {
+	nan := [3]float64{math.NaN(), 0, math.NaN()}
+	if len(data) == 0 {
+		return nan
+	}
+	for _, x := range data {
+		if x <= 0 {
+			return nan
+		}
+	}
+
+	mean, logMean := meanLog(data)
+	s := math.Log(mean) - logMean
+	if s <= 0 {
+		return nan
+	}
+
+	// Initial guess by Minka, refined by Newton's method on log(a) - digamma(a) = s
+	a := (3 - s + math.Sqrt((s-3)*(s-3)+24*s)) / (12 * s)
+	for i := 0; i < 100; i++ {
+		d := (math.Log(a) - digamma(a) - s) / (1/a - trigamma(a))
+		if a-d <= 0 {
+			d = a / 2
+		}
+		a -= d
+		if math.Abs(d) < 1e-14*a {
+			break
+		}
+	}
+
+	return [3]float64{a, 0, mean / a}
+}
+
+// GammaMedian is the median of the distribution.
+func GammaMedian(a float64, loc float64, scale float64) float64 {
+	return GammaPpf(0.5, a, loc, scale)
+}
+
+// GammaMean is the mean/expected value of the distribution.
+func GammaMean(a float64, loc float64, scale float64) float64 {
+	return loc + scale*a
+}
+
+// GammaVar is the variance of the distribution.
+func GammaVar(a float64, loc float64, scale float64) float64 {
+	return scale * scale * a
+}
+
+// GammaStd is the standard deviation of the distribution.
+func GammaStd(a float64, loc float64, scale float64) float64 {
+	return scale * math.Sqrt(a)
+}
+
+// GammaInterval finds endpoints of the range that contains alpha percent of the distribution.
+func GammaInterval(alpha float64, a float64, loc float64, scale float64) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return GammaPpf(p, a, loc, scale) })
+}
+
+// GammaRvs generates random variates using the method of Marsaglia and Tsang.
+func GammaRvs(a float64, loc float64, scale float64, size int) []float64 {
+	r := newRand()
+	toReturn := make([]float64, size)
+	for i := range toReturn {
+		toReturn[i] = loc + scale*randGamma(r, a)
+	}
+	return toReturn
+}
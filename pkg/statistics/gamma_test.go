@@ -0,0 +1,67 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestGamma(t *testing.T) {
+	checkValues(t, "GammaPdf", func(x float64) float64 { return stats.GammaPdf(x, 2.5, 0, 2) }, [][2]float64{{3, 0.15418032980376928}, {-1, 0}}, 1e-12)
+	checkValues(t, "GammaCdf", func(x float64) float64 { return stats.GammaCdf(x, 2.5, 0, 2) }, [][2]float64{{3, 0.3000141641213724}, {0, 0}}, 1e-12)
+	checkValues(t, "GammaSf", func(x float64) float64 { return stats.GammaSf(x, 2.5, 0, 2) }, [][2]float64{{3, 0.6999858358786276}}, 1e-12)
+	checkValues(t, "GammaPpf", func(p float64) float64 { return stats.GammaPpf(p, 2.5, 0, 2) }, [][2]float64{{0.3, 2.9999081327599066}}, 1e-12)
+	checkValues(t, "GammaIsf", func(p float64) float64 { return stats.GammaIsf(p, 2.5, 0, 2) }, [][2]float64{{0.7, 2.9999081327599066}}, 1e-12)
+
+	if got := stats.GammaPdf(0, 1, 0, 1); got != 1 {
+		t.Errorf("GammaPdf(0, a=1) => %v != 1", got)
+	}
+	if got := stats.GammaMoment(2, 2.5, 0, 2); !tolerance(got, 35, 1e-12) {
+		t.Errorf("GammaMoment(2) => %v != 35", got)
+	}
+	s := stats.GammaStats(4, 1, 2, "mvsk")
+	if s[0] != 9 || s[1] != 16 || s[2] != 1 || s[3] != 1.5 {
+		t.Errorf("GammaStats(4, 1, 2) => %v", s)
+	}
+	if got := stats.GammaEntropy(2.5, 0, 2); !tolerance(got, 2.4230950900650168, 1e-12) {
+		t.Errorf("GammaEntropy => %v", got)
+	}
+
+	fit := stats.GammaFit([]float64{1.2, 3.4, 2.2, 5.1, 0.7, 2.9, 4.4, 1.8})
+	if !tolerance(fit[0], 3.0513916056500547, 1e-9) || fit[1] != 0 || !tolerance(fit[2], 0.8889386714499207, 1e-9) {
+		t.Errorf("GammaFit => %v", fit)
+	}
+	if fit := stats.GammaFit([]float64{1, -1}); fit[0] == fit[0] {
+		t.Errorf("GammaFit of negative values => %v, expected NaN", fit)
+	}
+
+	if len(stats.GammaRvs(0.5, 0, 1, 101)) != 101 {
+		t.Error("Input size=101, Expected 101")
+	}
+}
+
+func TestGammaLargeShape(t *testing.T) {
+	checkValues(t, "GammaCdf", func(x float64) float64 { return stats.GammaCdf(x, 1e4, 0, 1) }, [][2]float64{{10100, 0.8413487504471796}}, 1e-9)
+	checkValues(t, "GammaCdf", func(x float64) float64 { return stats.GammaCdf(x, 5e5, 0, 2) }, [][2]float64{{998000, 0.07858029198714495}}, 1e-9)
+	checkValues(t, "GammaSf", func(x float64) float64 { return stats.GammaSf(x, 1e4, 0, 1) }, [][2]float64{{9800, 0.9777924561860303}}, 1e-9)
+}
@@ -0,0 +1,133 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// GeomPmf is the probability mass function of the geometric distribution,
+// the number of trials up to and including the first success with
+// success probability p.
+func GeomPmf(k int, p float64) float64 {
+	return math.Exp(GeomLogPmf(k, p))
+}
+
+// GeomLogPmf is the log of the probability mass function.
+func GeomLogPmf(k int, p float64) float64 {
+	switch {
+	case k < 1:
+		return math.Inf(-1)
+	case p == 1:
+		if k == 1 {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	return float64(k-1)*math.Log1p(-p) + math.Log(p)
+}
+
+// GeomCdf is the cumulative distribution function.
+func GeomCdf(k int, p float64) float64 {
+	if k < 1 {
+		return 0
+	}
+	return -math.Expm1(float64(k) * math.Log1p(-p))
+}
+
+// GeomSf is the survival function (1 - cdf).
+func GeomSf(k int, p float64) float64 {
+	if k < 1 {
+		return 1
+	}
+	return math.Exp(float64(k) * math.Log1p(-p))
+}
+
+// GeomPpf is the point percentile function (inverse of cdf).
+func GeomPpf(q float64, p float64) float64 {
+	guess := math.Ceil(math.Log1p(-q) / math.Log1p(-p))
+	return discretePpf(q, func(k int) float64 { return GeomCdf(k, p) }, 1, -1, geomGuess(guess))
+}
+
+// GeomIsf is the inverse survival function (inverse of sf).
+func GeomIsf(q float64, p float64) float64 {
+	guess := math.Ceil(math.Log(q) / math.Log1p(-p))
+	return discreteIsf(q, func(k int) float64 { return GeomSf(k, p) }, 1, -1, geomGuess(guess))
+}
+
+// geomGuess converts the closed-form quantile to a starting point for the
+// search, which corrects for rounding errors.
+func geomGuess(guess float64) int {
+	if math.IsNaN(guess) || guess > 1e15 {
+		return 1
+	}
+	return int(guess)
+}
+
+// GeomStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func GeomStats(p float64, moments string) []float64 {
+	return selectStats(moments, 0, 1, GeomMean(p), GeomVar(p), (2-p)/math.Sqrt(1-p), 6+p*p/(1-p))
+}
+
+// GeomFit returns the maximum likelihood estimator of the success probability.
+// Takes array of the observed numbers of trials.
+func GeomFit(data []int) float64 {
+	if len(data) == 0 {
+		return math.NaN()
+	}
+	return 1 / meanInt(data)
+}
+
+// GeomMedian is the median of the distribution.
+func GeomMedian(p float64) float64 {
+	return GeomPpf(0.5, p)
+}
+
+// GeomMean is the mean/expected value of the distribution.
+func GeomMean(p float64) float64 {
+	return 1 / p
+}
+
+// GeomVar is the variance of the distribution.
+func GeomVar(p float64) float64 {
+	return (1 - p) / (p * p)
+}
+
+// GeomStd is the standard deviation of the distribution.
+func GeomStd(p float64) float64 {
+	return math.Sqrt(GeomVar(p))
+}
+
+// GeomInterval finds endpoints of the range that contains alpha percent of the distribution.
+func GeomInterval(alpha float64, p float64) [2]float64 {
+	return interval(alpha, func(q float64) float64 { return GeomPpf(q, p) })
+}
+
+// GeomRvs generates random variates by inversion.
+func GeomRvs(p float64, size int) []int {
+	r := newRand()
+	toReturn := make([]int, size)
+	for i := range toReturn {
+		toReturn[i] = int(GeomIsf(1-randOpenUnit(r), p))
+	}
+	return toReturn
+}
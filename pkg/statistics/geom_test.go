@@ -0,0 +1,50 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestGeom(t *testing.T) {
+	checkValues(t, "GeomPmf", func(k float64) float64 { return stats.GeomPmf(int(k), 0.25) }, [][2]float64{{3, 0.140625}, {1, 0.25}, {0, 0}}, 1e-14)
+	checkValues(t, "GeomLogPmf", func(k float64) float64 { return stats.GeomLogPmf(int(k), 0.25) }, [][2]float64{{3, math.Log(0.140625)}}, 1e-14)
+	checkValues(t, "GeomCdf", func(k float64) float64 { return stats.GeomCdf(int(k), 0.25) }, [][2]float64{{3, 0.578125}, {0, 0}}, 1e-14)
+	checkValues(t, "GeomSf", func(k float64) float64 { return stats.GeomSf(int(k), 0.25) }, [][2]float64{{3, 0.421875}, {0, 1}}, 1e-14)
+	checkValues(t, "GeomPpf", func(p float64) float64 { return stats.GeomPpf(p, 0.25) }, [][2]float64{{0.5, 3}, {0.578125, 3}, {0.25, 1}, {0, 0}}, 0)
+	checkValues(t, "GeomIsf", func(p float64) float64 { return stats.GeomIsf(p, 0.25) }, [][2]float64{{0.421875, 3}, {0.5, 3}, {1, 0}}, 0)
+
+	if s := stats.GeomStats(0.5, "mvsk"); s[0] != 2 || s[1] != 2 || !veryclose(s[2], 1.5/math.Sqrt(0.5)) || s[3] != 6.5 {
+		t.Errorf("GeomStats(0.5) => %v", s)
+	}
+	if got := stats.GeomFit([]int{1, 2, 3, 6}); got != 1.0/3 {
+		t.Errorf("GeomFit => %v != 1/3", got)
+	}
+
+	for _, k := range stats.GeomRvs(0.25, 101) {
+		if k < 1 {
+			t.Errorf("GeomRvs => %v out of support", k)
+		}
+	}
+}
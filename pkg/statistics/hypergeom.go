@@ -0,0 +1,191 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// HypergeomPmf is the probability mass function of the hypergeometric
+// distribution, the number of successes in draws without replacement
+// from a population of popSize objects that contains successes
+// objects counted as a success.
+func HypergeomPmf(k int, popSize int, successes int, draws int) float64 {
+	return math.Exp(HypergeomLogPmf(k, popSize, successes, draws))
+}
+
+// HypergeomLogPmf is the log of the probability mass function.
+func HypergeomLogPmf(k int, popSize int, successes int, draws int) float64 {
+	lo, hi := hypergeomSupport(popSize, successes, draws)
+	if k < lo || k > hi {
+		return math.Inf(-1)
+	}
+	return LogNcr(successes, k) + LogNcr(popSize-successes, draws-k) - LogNcr(popSize, draws)
+}
+
+// hypergeomSupport returns the smallest and largest possible number of successes.
+func hypergeomSupport(popSize, successes, draws int) (int, int) {
+	lo := draws - (popSize - successes)
+	if lo < 0 {
+		lo = 0
+	}
+	hi := draws
+	if successes < hi {
+		hi = successes
+	}
+	return lo, hi
+}
+
+// hypergeomSum sums the probability mass function from k to l.
+func hypergeomSum(k, l, popSize, successes, draws int) float64 {
+	var sum float64
+	for j := k; j <= l; j++ {
+		sum += HypergeomPmf(j, popSize, successes, draws)
+	}
+	return sum
+}
+
+// HypergeomCdf is the cumulative distribution function. The probabilities
+// are summed over the shorter tail to avoid cancellation.
+func HypergeomCdf(k int, popSize int, successes int, draws int) float64 {
+	lo, hi := hypergeomSupport(popSize, successes, draws)
+	switch {
+	case k < lo:
+		return 0
+	case k >= hi:
+		return 1
+	case float64(k) < HypergeomMean(popSize, successes, draws):
+		return hypergeomSum(lo, k, popSize, successes, draws)
+	}
+	return 1 - hypergeomSum(k+1, hi, popSize, successes, draws)
+}
+
+// HypergeomSf is the survival function (1 - cdf).
+func HypergeomSf(k int, popSize int, successes int, draws int) float64 {
+	lo, hi := hypergeomSupport(popSize, successes, draws)
+	switch {
+	case k < lo:
+		return 1
+	case k >= hi:
+		return 0
+	case float64(k) < HypergeomMean(popSize, successes, draws):
+		return 1 - hypergeomSum(lo, k, popSize, successes, draws)
+	}
+	return hypergeomSum(k+1, hi, popSize, successes, draws)
+}
+
+// HypergeomPpf is the point percentile function (inverse of cdf).
+func HypergeomPpf(p float64, popSize int, successes int, draws int) float64 {
+	lo, hi := hypergeomSupport(popSize, successes, draws)
+	guess := normalGuess(p, HypergeomMean(popSize, successes, draws), HypergeomVar(popSize, successes, draws))
+	return discretePpf(p, func(k int) float64 { return HypergeomCdf(k, popSize, successes, draws) }, lo, hi, guess)
+}
+
+// HypergeomIsf is the inverse survival function (inverse of sf).
+func HypergeomIsf(p float64, popSize int, successes int, draws int) float64 {
+	lo, hi := hypergeomSupport(popSize, successes, draws)
+	guess := normalGuess(1-p, HypergeomMean(popSize, successes, draws), HypergeomVar(popSize, successes, draws))
+	return discreteIsf(p, func(k int) float64 { return HypergeomSf(k, popSize, successes, draws) }, lo, hi, guess)
+}
+
+// HypergeomStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func HypergeomStats(popSize int, successes int, draws int, moments string) []float64 {
+	m, n, d := float64(popSize), float64(successes), float64(draws)
+	s := (m - 2*n) * math.Sqrt(m-1) * (m - 2*d) / (math.Sqrt(d*n*(m-n)*(m-d)) * (m - 2))
+	k := (m-1)*m*m*(m*(m+1)-6*n*(m-n)-6*d*(m-d)) + 6*d*n*(m-n)*(m-d)*(5*m-6)
+	k /= d * n * (m - n) * (m - d) * (m - 2) * (m - 3)
+	return selectStats(moments, 0, 1, HypergeomMean(popSize, successes, draws), HypergeomVar(popSize, successes, draws), s, k)
+}
+
+// HypergeomFit returns the maximum likelihood estimator of the number of
+// successes in a population of popSize objects. Takes array of the observed
+// numbers of successes in draws. Returns -1 if no number of successes
+// is consistent with the data.
+func HypergeomFit(data []int, popSize int, draws int) int {
+	if len(data) == 0 {
+		return -1
+	}
+	min, max := data[0], data[0]
+	for _, k := range data {
+		if k < min {
+			min = k
+		}
+		if k > max {
+			max = k
+		}
+	}
+
+	best, bestLogL := -1, math.Inf(-1)
+	for n := max; n <= popSize-draws+min; n++ {
+		var logL float64
+		for _, k := range data {
+			logL += HypergeomLogPmf(k, popSize, n, draws)
+		}
+		if logL > bestLogL {
+			best, bestLogL = n, logL
+		}
+	}
+	return best
+}
+
+// HypergeomMedian is the median of the distribution.
+func HypergeomMedian(popSize int, successes int, draws int) float64 {
+	return HypergeomPpf(0.5, popSize, successes, draws)
+}
+
+// HypergeomMean is the mean/expected value of the distribution.
+func HypergeomMean(popSize int, successes int, draws int) float64 {
+	return float64(draws) * float64(successes) / float64(popSize)
+}
+
+// HypergeomVar is the variance of the distribution.
+func HypergeomVar(popSize int, successes int, draws int) float64 {
+	m, n, d := float64(popSize), float64(successes), float64(draws)
+	return d * n * (m - n) * (m - d) / (m * m * (m - 1))
+}
+
+// HypergeomStd is the standard deviation of the distribution.
+func HypergeomStd(popSize int, successes int, draws int) float64 {
+	return math.Sqrt(HypergeomVar(popSize, successes, draws))
+}
+
+// HypergeomInterval finds endpoints of the range that contains alpha percent of the distribution.
+func HypergeomInterval(alpha float64, popSize int, successes int, draws int) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return HypergeomPpf(p, popSize, successes, draws) })
+}
+
+// HypergeomRvs generates random variates by simulating the draws.
+func HypergeomRvs(popSize int, successes int, draws int, size int) []int {
+	r := newRand()
+	toReturn := make([]int, size)
+	for i := range toReturn {
+		left, good := popSize, successes
+		for j := 0; j < draws; j++ {
+			if r.Intn(left) < good {
+				good--
+			}
+			left--
+		}
+		toReturn[i] = successes - good
+	}
+	return toReturn
+}
@@ -0,0 +1,58 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestHypergeom(t *testing.T) {
+	checkValues(t, "HypergeomPmf", func(k float64) float64 { return stats.HypergeomPmf(int(k), 10, 4, 3) }, [][2]float64{{1, 0.5}, {0, 1.0 / 6}, {4, 0}}, 1e-14)
+	checkValues(t, "HypergeomLogPmf", func(k float64) float64 { return stats.HypergeomLogPmf(int(k), 10, 4, 3) }, [][2]float64{{1, -math.Ln2}}, 1e-14)
+	checkValues(t, "HypergeomCdf", func(k float64) float64 { return stats.HypergeomCdf(int(k), 10, 4, 3) }, [][2]float64{{1, 2.0 / 3}, {-1, 0}, {3, 1}}, 1e-14)
+	checkValues(t, "HypergeomSf", func(k float64) float64 { return stats.HypergeomSf(int(k), 10, 4, 3) }, [][2]float64{{1, 1.0 / 3}, {0, 5.0 / 6}, {3, 0}}, 1e-14)
+	checkValues(t, "HypergeomPpf", func(p float64) float64 { return stats.HypergeomPpf(p, 10, 4, 3) }, [][2]float64{{0.5, 1}, {0.1, 0}, {0.7, 2}, {1, 3}, {0, -1}}, 0)
+	checkValues(t, "HypergeomIsf", func(p float64) float64 { return stats.HypergeomIsf(p, 10, 4, 3) }, [][2]float64{{0.5, 1}, {1.0 / 3, 1}}, 0)
+
+	// The support starts above zero if the draws exceed the failures
+	if got := stats.HypergeomPmf(0, 10, 8, 3); got != 0 {
+		t.Errorf("HypergeomPmf(0, 10, 8, 3) => %v != 0", got)
+	}
+	if got := stats.HypergeomPpf(0, 10, 8, 5); got != 2 {
+		t.Errorf("HypergeomPpf(0, 10, 8, 5) => %v != 2", got)
+	}
+
+	if s := stats.HypergeomStats(10, 4, 3, "mv"); !veryclose(s[0], 1.2) || !veryclose(s[1], 0.56) {
+		t.Errorf("HypergeomStats(10, 4, 3) => %v", s)
+	}
+	if got := stats.HypergeomFit([]int{1, 2, 1, 0, 2}, 10, 3); got != 4 {
+		t.Errorf("HypergeomFit => %v != 4", got)
+	}
+
+	for _, k := range stats.HypergeomRvs(10, 8, 3, 101) {
+		if k < 1 || k > 3 {
+			t.Errorf("HypergeomRvs => %v out of support", k)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// KolmogorovSmirnov2Sample tests the null hypothesis that two independent
+// samples are drawn from the same continuous distribution.
+//
+// The statistic is the maximum absolute difference between the empirical
+// distribution functions of both samples. The p-value is computed from
+// the asymptotic Kolmogorov distribution with Stephens' correction for
+// small samples. DF is NaN.
+func KolmogorovSmirnov2Sample(data1, data2 Float64Data) (TestResult, error) {
+	l1 := data1.Len()
+	l2 := data2.Len()
+
+	if l1 == 0 || l2 == 0 {
+		return nanTestResult, ErrEmptyInput
+	}
+
+	s1 := sortedCopy(data1)
+	s2 := sortedCopy(data2)
+	n1 := float64(l1)
+	n2 := float64(l2)
+
+	var d float64
+	var i, j int
+	for i < l1 && j < l2 {
+		x := math.Min(s1[i], s2[j])
+		for i < l1 && s1[i] == x {
+			i++
+		}
+		for j < l2 && s2[j] == x {
+			j++
+		}
+		d = math.Max(d, math.Abs(float64(i)/n1-float64(j)/n2))
+	}
+
+	en := math.Sqrt(n1 * n2 / (n1 + n2))
+
+	return TestResult{
+		Statistic: d,
+		PValue:    kolmogorovSf((en + 0.12 + 0.11/en) * d),
+		DF:        math.NaN(),
+	}, nil
+}
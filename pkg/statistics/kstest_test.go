@@ -0,0 +1,53 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestKolmogorovSmirnov2Sample(t *testing.T) {
+	for _, c := range []struct {
+		name         string
+		data1, data2 []float64
+		out          stats.TestResult
+	}{
+		{"Disjoint", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, stats.TestResult{Statistic: 1, PValue: 0.0037813540593701006, DF: math.NaN()}},
+		{"Overlapping", []float64{1, 2, 3, 4, 5}, []float64{3, 4, 5, 6, 7, 8}, stats.TestResult{Statistic: 0.5, PValue: 0.3670013850902251, DF: math.NaN()}},
+		{"Identical", []float64{1, 2, 3}, []float64{3, 2, 1}, stats.TestResult{Statistic: 0, PValue: 1, DF: math.NaN()}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := stats.KolmogorovSmirnov2Sample(c.data1, c.data2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTestResult(t, "KolmogorovSmirnov2Sample", got, c.out)
+		})
+	}
+
+	_, err := stats.KolmogorovSmirnov2Sample([]float64{}, []float64{1})
+	if err != stats.ErrEmptyInput {
+		t.Errorf("Should have returned error %s", stats.ErrEmptyInput)
+	}
+}
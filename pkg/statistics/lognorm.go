@@ -0,0 +1,156 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// LogNormPdf is the probability density function of the log-normal
+// distribution with shape s, the standard deviation of the underlying
+// normal distribution. The scale is the exponential of its mean.
+func LogNormPdf(x float64, s float64, loc float64, scale float64) float64 {
+	return math.Exp(LogNormLogPdf(x, s, loc, scale))
+}
+
+// LogNormLogPdf is the log of the probability density function.
+func LogNormLogPdf(x float64, s float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z <= 0 {
+		return math.Inf(-1)
+	}
+	lz := math.Log(z)
+	return -lz - math.Log(s*math.Sqrt(2*math.Pi)) - lz*lz/(2*s*s) - math.Log(scale)
+}
+
+// LogNormCdf is the cumulative distribution function.
+func LogNormCdf(x float64, s float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z <= 0 {
+		return 0
+	}
+	return normSf(-math.Log(z) / s)
+}
+
+// LogNormLogCdf is the log of the cumulative distribution function.
+func LogNormLogCdf(x float64, s float64, loc float64, scale float64) float64 {
+	return math.Log(LogNormCdf(x, s, loc, scale))
+}
+
+// LogNormSf is the survival function (1 - cdf).
+func LogNormSf(x float64, s float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z <= 0 {
+		return 1
+	}
+	return normSf(math.Log(z) / s)
+}
+
+// LogNormLogSf is the log of the survival function.
+func LogNormLogSf(x float64, s float64, loc float64, scale float64) float64 {
+	return math.Log(LogNormSf(x, s, loc, scale))
+}
+
+// LogNormPpf is the point percentile function (inverse of cdf).
+func LogNormPpf(p float64, s float64, loc float64, scale float64) float64 {
+	return loc + scale*math.Exp(s*NormPpf(p, 0, 1))
+}
+
+// LogNormIsf is the inverse survival function (inverse of sf).
+func LogNormIsf(p float64, s float64, loc float64, scale float64) float64 {
+	return loc + scale*math.Exp(-s*NormPpf(p, 0, 1))
+}
+
+// LogNormMoment is the non-central (raw) moment of order n.
+func LogNormMoment(n int, s float64, loc float64, scale float64) float64 {
+	return rawMoment(n, loc, scale, func(k int) float64 {
+		fk := float64(k)
+		return math.Exp(fk * fk * s * s / 2)
+	})
+}
+
+// LogNormStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func LogNormStats(s float64, loc float64, scale float64, moments string) []float64 {
+	w := math.Exp(s * s)
+	m := math.Sqrt(w)
+	v := (w - 1) * w
+	sk := (w + 2) * math.Sqrt(w-1)
+	k := w*w*w*w + 2*w*w*w + 3*w*w - 6
+	return selectStats(moments, loc, scale, m, v, sk, k)
+}
+
+// LogNormEntropy is the differential entropy of the RV.
+func LogNormEntropy(s float64, loc float64, scale float64) float64 {
+	return 0.5*(1+math.Log(2*math.Pi)) + math.Log(s) + math.Log(scale)
+}
+
+// LogNormFit returns the maximum likelihood estimators for the log-normal distribution.
+// The location is fixed at zero, so all values must be positive.
+// Takes array of float64 values.
+// Returns array of the shape followed by the location and the scale.
+func LogNormFit(data []float64) [3]float64 {
+	logs := make([]float64, len(data))
+	for i, x := range data {
+		if x <= 0 {
+			return [3]float64{math.NaN(), 0, math.NaN()}
+		}
+		logs[i] = math.Log(x)
+	}
+
+	fit := NormFit(logs)
+	return [3]float64{fit[1], 0, math.Exp(fit[0])}
+}
+
+// LogNormMedian is the median of the distribution.
+func LogNormMedian(s float64, loc float64, scale float64) float64 {
+	return loc + scale
+}
+
+// LogNormMean is the mean/expected value of the distribution.
+func LogNormMean(s float64, loc float64, scale float64) float64 {
+	return loc + scale*math.Exp(s*s/2)
+}
+
+// LogNormVar is the variance of the distribution.
+func LogNormVar(s float64, loc float64, scale float64) float64 {
+	return LogNormStats(s, loc, scale, "v")[0]
+}
+
+// LogNormStd is the standard deviation of the distribution.
+func LogNormStd(s float64, loc float64, scale float64) float64 {
+	return math.Sqrt(LogNormVar(s, loc, scale))
+}
+
+// LogNormInterval finds endpoints of the range that contains alpha percent of the distribution.
+func LogNormInterval(alpha float64, s float64, loc float64, scale float64) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return LogNormPpf(p, s, loc, scale) })
+}
+
+// LogNormRvs generates random variates as the exponential of normal variates.
+func LogNormRvs(s float64, loc float64, scale float64, size int) []float64 {
+	r := newRand()
+	toReturn := make([]float64, size)
+	for i := range toReturn {
+		toReturn[i] = loc + scale*math.Exp(s*r.NormFloat64())
+	}
+	return toReturn
+}
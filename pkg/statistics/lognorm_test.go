@@ -0,0 +1,55 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestLogNorm(t *testing.T) {
+	checkValues(t, "LogNormPdf", func(x float64) float64 { return stats.LogNormPdf(x, 0.5, 0, 1) }, [][2]float64{{1.3, 0.5348196821268515}, {0, 0}}, 1e-12)
+	checkValues(t, "LogNormCdf", func(x float64) float64 { return stats.LogNormCdf(x, 0.5, 0, 1) }, [][2]float64{{1.3, 0.700114039009087}}, 1e-12)
+	checkValues(t, "LogNormSf", func(x float64) float64 { return stats.LogNormSf(x, 0.5, 0, 1) }, [][2]float64{{1.3, 0.299885960990913}}, 1e-12)
+	checkValues(t, "LogNormPpf", func(p float64) float64 { return stats.LogNormPpf(p, 0.5, 0, 1) }, [][2]float64{{0.9, 1.8979527073347109}}, 1e-12)
+	checkValues(t, "LogNormIsf", func(p float64) float64 { return stats.LogNormIsf(p, 0.5, 0, 1) }, [][2]float64{{0.1, 1.8979527073347109}}, 1e-12)
+
+	if got := stats.LogNormMoment(1, 0.5, 0, 1); !tolerance(got, math.Exp(0.125), 1e-15) {
+		t.Errorf("LogNormMoment(1) => %v", got)
+	}
+	if got := stats.LogNormMedian(0.5, 0, 3); got != 3 {
+		t.Errorf("LogNormMedian => %v != 3", got)
+	}
+	if got := stats.LogNormEntropy(1, 0, 1); !tolerance(got, stats.NormEntropy(0, 1), 1e-15) {
+		t.Errorf("LogNormEntropy => %v", got)
+	}
+
+	fit := stats.LogNormFit([]float64{1.2, 3.4, 2.2, 5.1, 0.7, 2.9, 4.4, 1.8})
+	if !tolerance(fit[0], 0.6286306596415407, 1e-12) || !tolerance(fit[2], 2.2822293171135817, 1e-12) {
+		t.Errorf("LogNormFit => %v", fit)
+	}
+
+	if len(stats.LogNormRvs(0.5, 0, 1, 101)) != 101 {
+		t.Error("Input size=101, Expected 101")
+	}
+}
@@ -0,0 +1,170 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// NBinomPmf is the probability mass function of the negative binomial
+// distribution, the number of failures before the n-th success with
+// success probability p. n need not be an integer.
+func NBinomPmf(k int, n float64, p float64) float64 {
+	return math.Exp(NBinomLogPmf(k, n, p))
+}
+
+// NBinomLogPmf is the log of the probability mass function.
+func NBinomLogPmf(k int, n float64, p float64) float64 {
+	switch {
+	case k < 0:
+		return math.Inf(-1)
+	case p == 1:
+		if k == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	a, _ := math.Lgamma(float64(k) + n)
+	b, _ := math.Lgamma(n)
+	c, _ := math.Lgamma(float64(k + 1))
+	return a - b - c + n*math.Log(p) + float64(k)*math.Log1p(-p)
+}
+
+// NBinomCdf is the cumulative distribution function.
+func NBinomCdf(k int, n float64, p float64) float64 {
+	if k < 0 {
+		return 0
+	}
+	return regIncBeta(n, float64(k+1), p)
+}
+
+// NBinomSf is the survival function (1 - cdf).
+func NBinomSf(k int, n float64, p float64) float64 {
+	if k < 0 {
+		return 1
+	}
+	return regIncBeta(float64(k+1), n, 1-p)
+}
+
+// NBinomPpf is the point percentile function (inverse of cdf).
+func NBinomPpf(q float64, n float64, p float64) float64 {
+	return discretePpf(q, func(k int) float64 { return NBinomCdf(k, n, p) }, 0, -1, normalGuess(q, NBinomMean(n, p), NBinomVar(n, p)))
+}
+
+// NBinomIsf is the inverse survival function (inverse of sf).
+func NBinomIsf(q float64, n float64, p float64) float64 {
+	return discreteIsf(q, func(k int) float64 { return NBinomSf(k, n, p) }, 0, -1, normalGuess(1-q, NBinomMean(n, p), NBinomVar(n, p)))
+}
+
+// NBinomStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func NBinomStats(n float64, p float64, moments string) []float64 {
+	return selectStats(moments, 0, 1, NBinomMean(n, p), NBinomVar(n, p), (2-p)/math.Sqrt(n*(1-p)), 6/n+p*p/(n*(1-p)))
+}
+
+// NBinomFit returns the maximum likelihood estimators for the negative binomial distribution.
+// Takes array of the observed numbers of failures.
+// Returns array of n followed by p. Both are NaN if the data is not
+// overdispersed, i.e. its variance does not exceed its mean.
+func NBinomFit(data []int) [2]float64 {
+	nan := [2]float64{math.NaN(), math.NaN()}
+	if len(data) == 0 {
+		return nan
+	}
+	mean := meanInt(data)
+	var variance float64
+	for _, k := range data {
+		variance += (float64(k) - mean) * (float64(k) - mean)
+	}
+	variance /= float64(len(data))
+	if variance <= mean {
+		return nan
+	}
+
+	// The score of n once p = n/(n+mean) is substituted. It is positive
+	// for small n and decreases towards zero from below for large n.
+	size := float64(len(data))
+	score := func(n float64) float64 {
+		var s float64
+		for _, k := range data {
+			s += digamma(float64(k)+n) - digamma(n)
+		}
+		return s + size*math.Log(n/(n+mean))
+	}
+
+	// Bracket the root, starting at the moment estimator, and bisect
+	lo := mean * mean / (variance - mean)
+	hi := lo
+	for score(lo) < 0 {
+		lo /= 2
+	}
+	for score(hi) > 0 {
+		hi *= 2
+		if hi > 1e15 {
+			return nan
+		}
+	}
+	for i := 0; i < 200 && hi-lo > 1e-12*hi; i++ {
+		m := (lo + hi) / 2
+		if score(m) > 0 {
+			lo = m
+		} else {
+			hi = m
+		}
+	}
+	n := (lo + hi) / 2
+	return [2]float64{n, n / (n + mean)}
+}
+
+// NBinomMedian is the median of the distribution.
+func NBinomMedian(n float64, p float64) float64 {
+	return NBinomPpf(0.5, n, p)
+}
+
+// NBinomMean is the mean/expected value of the distribution.
+func NBinomMean(n float64, p float64) float64 {
+	return n * (1 - p) / p
+}
+
+// NBinomVar is the variance of the distribution.
+func NBinomVar(n float64, p float64) float64 {
+	return n * (1 - p) / (p * p)
+}
+
+// NBinomStd is the standard deviation of the distribution.
+func NBinomStd(n float64, p float64) float64 {
+	return math.Sqrt(NBinomVar(n, p))
+}
+
+// NBinomInterval finds endpoints of the range that contains alpha percent of the distribution.
+func NBinomInterval(alpha float64, n float64, p float64) [2]float64 {
+	return interval(alpha, func(q float64) float64 { return NBinomPpf(q, n, p) })
+}
+
+// NBinomRvs generates random variates as a gamma mixture of Poisson variates.
+func NBinomRvs(n float64, p float64, size int) []int {
+	r := newRand()
+	toReturn := make([]int, size)
+	for i := range toReturn {
+		toReturn[i] = randPoisson(r, randGamma(r, n)*(1-p)/p)
+	}
+	return toReturn
+}
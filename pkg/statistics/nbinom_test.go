@@ -0,0 +1,50 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestNBinom(t *testing.T) {
+	checkValues(t, "NBinomPmf", func(k float64) float64 { return stats.NBinomPmf(int(k), 2, 0.5) }, [][2]float64{{2, 0.1875}, {0, 0.25}, {-1, 0}}, 1e-14)
+	checkValues(t, "NBinomLogPmf", func(k float64) float64 { return stats.NBinomLogPmf(int(k), 2, 0.5) }, [][2]float64{{2, math.Log(0.1875)}}, 1e-14)
+	checkValues(t, "NBinomCdf", func(k float64) float64 { return stats.NBinomCdf(int(k), 2, 0.5) }, [][2]float64{{2, 0.6875}, {-1, 0}}, 1e-14)
+	checkValues(t, "NBinomSf", func(k float64) float64 { return stats.NBinomSf(int(k), 2, 0.5) }, [][2]float64{{2, 0.3125}, {-1, 1}}, 1e-14)
+	checkValues(t, "NBinomPpf", func(p float64) float64 { return stats.NBinomPpf(p, 2, 0.5) }, [][2]float64{{0.6875, 2}, {0.6, 2}, {0.5, 1}}, 0)
+	checkValues(t, "NBinomIsf", func(p float64) float64 { return stats.NBinomIsf(p, 2, 0.5) }, [][2]float64{{0.3125, 2}, {0.4, 2}}, 0)
+
+	if s := stats.NBinomStats(2, 0.5, "mvsk"); s[0] != 2 || s[1] != 4 || !veryclose(s[2], 1.5) || !veryclose(s[3], 3.25) {
+		t.Errorf("NBinomStats(2, 0.5) => %v", s)
+	}
+
+	// Underdispersed data has no maximum likelihood estimate
+	if fit := stats.NBinomFit([]int{2, 2, 2}); !math.IsNaN(fit[0]) || !math.IsNaN(fit[1]) {
+		t.Errorf("NBinomFit => %v != [NaN NaN]", fit)
+	}
+	fit := stats.NBinomFit(stats.NBinomRvs(2.5, 0.3, 20000))
+	if math.Abs(fit[0]-2.5) > 0.25 || math.Abs(fit[1]-0.3) > 0.03 {
+		t.Errorf("NBinomFit => %v != [2.5 0.3]", fit)
+	}
+}
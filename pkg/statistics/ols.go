@@ -0,0 +1,374 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// Matrix is a read-only matrix of predictors with one row per
+// observation. It is satisfied by matrix.MatrixWrap, which wraps
+// a DataFrame, and by the matrices of gonum.
+type Matrix interface {
+	// Dims returns the dimensions of a Matrix.
+	Dims() (r, c int)
+
+	// At returns the value of a matrix element at row i, column j.
+	At(i, j int) float64
+}
+
+// Rows is a Matrix that holds its values row by row
+type Rows [][]float64
+
+// Dims returns the dimensions of a Matrix.
+func (r Rows) Dims() (int, int) {
+	if len(r) == 0 {
+		return 0, 0
+	}
+	return len(r), len(r[0])
+}
+
+// At returns the value of a matrix element at row i, column j.
+func (r Rows) At(i, j int) float64 {
+	return r[i][j]
+}
+
+// RegressionResult holds a fitted least squares model and its diagnostics
+type RegressionResult struct {
+
+	// Coefficients of the model. If it has an intercept, it is the first
+	// coefficient, followed by one coefficient per predictor.
+	Coefficients []float64
+
+	// StdErrors are the standard errors of the coefficients.
+	StdErrors []float64
+
+	// TValues and PValues test if the coefficients are zero.
+	// The p-values are two-sided.
+	TValues []float64
+	PValues []float64
+
+	// RSquared is the coefficient of determination. If the model has
+	// no intercept, it is computed around zero instead of the mean.
+	RSquared    float64
+	AdjRSquared float64
+
+	// FStatistic tests if all coefficients besides the intercept are zero.
+	FStatistic float64
+	FPValue    float64
+
+	// DFModel and DFResid are the degrees of freedom of the model
+	// and of the residuals.
+	DFModel float64
+	DFResid float64
+
+	// Fitted are the fitted values and Residuals the observed minus
+	// the fitted values.
+	Fitted    []float64
+	Residuals []float64
+
+	// Intercept reports if the model has an intercept.
+	Intercept bool
+
+	// expand maps the arguments of Predict to the predictors
+	expand func(x []float64) []float64
+}
+
+// Predict returns the value predicted by the model for the predictors x.
+// For a PolynomialRegression it takes a single x.
+func (r *RegressionResult) Predict(x ...float64) (float64, error) {
+	if r.expand != nil {
+		if len(x) != 1 {
+			return math.NaN(), ErrSize
+		}
+		x = r.expand(x)
+	}
+
+	coef := r.Coefficients
+	var y float64
+	if r.Intercept {
+		y = coef[0]
+		coef = coef[1:]
+	}
+	if len(x) != len(coef) {
+		return math.NaN(), ErrSize
+	}
+	for j, v := range x {
+		y += coef[j] * v
+	}
+	return y, nil
+}
+
+// OLS fits a linear model by ordinary least squares. Takes a Matrix
+// with one column per predictor and the observed values y. If intercept
+// is set, a constant term is added to the model.
+func OLS(x Matrix, y Float64Data, intercept bool) (*RegressionResult, error) {
+	return leastSquares(x, y, nil, intercept)
+}
+
+// WLS fits a linear model by weighted least squares. The weights are
+// typically the inverse variances of the observations. Observations with
+// zero weight do not count towards the degrees of freedom.
+func WLS(x Matrix, y Float64Data, weights Float64Data, intercept bool) (*RegressionResult, error) {
+	if weights.Len() != y.Len() {
+		return nil, ErrSize
+	}
+	return leastSquares(x, y, weights, intercept)
+}
+
+// PolynomialRegression fits a polynomial of the given degree on data series.
+// The coefficients start with the constant term.
+func PolynomialRegression(s Series, degree int) (*RegressionResult, error) {
+	if len(s) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if degree < 1 {
+		return nil, ErrBounds
+	}
+
+	expand := func(x []float64) []float64 {
+		row := make([]float64, degree)
+		v := 1.0
+		for j := range row {
+			v *= x[0]
+			row[j] = v
+		}
+		return row
+	}
+
+	x := make(Rows, len(s))
+	y := make(Float64Data, len(s))
+	for i, c := range s {
+		x[i] = expand([]float64{c.X})
+		y[i] = c.Y
+	}
+
+	r, err := leastSquares(x, y, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	r.expand = expand
+	return r, nil
+}
+
+// leastSquares solves the weighted least squares problem using a
+// Householder QR decomposition. weights may be nil.
+func leastSquares(x Matrix, y, weights Float64Data, intercept bool) (*RegressionResult, error) {
+	n, k := x.Dims()
+	if n == 0 {
+		return nil, ErrEmptyInput
+	}
+	if y.Len() != n {
+		return nil, ErrSize
+	}
+
+	p := k
+	offset := 0
+	if intercept {
+		p++
+		offset = 1
+	}
+
+	// Scale the rows by the square roots of the weights. Only the rows
+	// with a positive weight are observations.
+	sw := make([]float64, n)
+	nobs := 0
+	for i := range sw {
+		sw[i] = 1
+		if weights != nil {
+			if weights[i] < 0 {
+				return nil, ErrNegative
+			}
+			sw[i] = math.Sqrt(weights[i])
+		}
+		if sw[i] > 0 {
+			nobs++
+		}
+	}
+	if nobs <= p {
+		return nil, ErrBounds
+	}
+
+	a := make([][]float64, p)
+	for j := range a {
+		a[j] = make([]float64, n)
+	}
+	b := make([]float64, n)
+	for i := 0; i < n; i++ {
+		if intercept {
+			a[0][i] = sw[i]
+		}
+		for j := 0; j < k; j++ {
+			v := x.At(i, j)
+			if math.IsNaN(v) || math.IsNaN(y[i]) {
+				return nil, ErrNaN
+			}
+			a[j+offset][i] = sw[i] * v
+		}
+		b[i] = sw[i] * y[i]
+	}
+
+	beta, rinv, err := solveQR(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &RegressionResult{
+		Coefficients: beta,
+		StdErrors:    make([]float64, p),
+		TValues:      make([]float64, p),
+		PValues:      make([]float64, p),
+		DFModel:      float64(p - offset),
+		DFResid:      float64(nobs - p),
+		Fitted:       make([]float64, n),
+		Residuals:    make([]float64, n),
+		Intercept:    intercept,
+	}
+
+	var sumW, meanY float64
+	for i := 0; i < n; i++ {
+		sumW += sw[i] * sw[i]
+		meanY += sw[i] * sw[i] * y[i]
+	}
+	meanY /= sumW
+
+	var rss, tss float64
+	for i := 0; i < n; i++ {
+		fitted := 0.0
+		if intercept {
+			fitted = beta[0]
+		}
+		for j := 0; j < k; j++ {
+			fitted += beta[j+offset] * x.At(i, j)
+		}
+		r.Fitted[i] = fitted
+		r.Residuals[i] = y[i] - fitted
+
+		w := sw[i] * sw[i]
+		rss += w * r.Residuals[i] * r.Residuals[i]
+		if intercept {
+			tss += w * (y[i] - meanY) * (y[i] - meanY)
+		} else {
+			tss += w * y[i] * y[i]
+		}
+	}
+
+	// The covariance of the coefficients is s² (R'R)^-1 = s² R^-1 R^-T
+	s2 := rss / r.DFResid
+	for j := 0; j < p; j++ {
+		var v float64
+		for l := j; l < p; l++ {
+			v += rinv[j][l] * rinv[j][l]
+		}
+		r.StdErrors[j] = math.Sqrt(s2 * v)
+		r.TValues[j] = beta[j] / r.StdErrors[j]
+		r.PValues[j] = 2 * studentTSf(math.Abs(r.TValues[j]), r.DFResid)
+	}
+
+	r.RSquared = 1 - rss/tss
+	r.AdjRSquared = 1 - (1-r.RSquared)*float64(nobs-offset)/r.DFResid
+	r.FStatistic = math.NaN()
+	r.FPValue = math.NaN()
+	if r.DFModel > 0 {
+		r.FStatistic = (tss - rss) / r.DFModel / s2
+		r.FPValue = FSf(r.FStatistic, r.DFModel, r.DFResid, 0, 1)
+	}
+
+	return r, nil
+}
+
+// solveQR solves the least squares problem for the columns a and the
+// values b, both of which are overwritten. It returns the solution and
+// the inverse of the upper triangular factor R.
+func solveQR(a [][]float64, b []float64) ([]float64, [][]float64, error) {
+	p, n := len(a), len(b)
+
+	var maxNorm float64
+	for j := range a {
+		maxNorm = math.Max(maxNorm, vecNorm(a[j]))
+	}
+
+	rdiag := make([]float64, p)
+	for j := 0; j < p; j++ {
+		norm := vecNorm(a[j][j:])
+		if norm <= 1e-12*maxNorm {
+			return nil, nil, ErrSingular
+		}
+		if a[j][j] > 0 {
+			norm = -norm
+		}
+
+		// Householder vector v = a[j][j:] - norm*e1, stored in place
+		a[j][j] -= norm
+		vv := -norm * a[j][j]
+		for l := j + 1; l < p; l++ {
+			var d float64
+			for i := j; i < n; i++ {
+				d += a[j][i] * a[l][i]
+			}
+			d /= vv
+			for i := j; i < n; i++ {
+				a[l][i] -= d * a[j][i]
+			}
+		}
+		var d float64
+		for i := j; i < n; i++ {
+			d += a[j][i] * b[i]
+		}
+		d /= vv
+		for i := j; i < n; i++ {
+			b[i] -= d * a[j][i]
+		}
+		rdiag[j] = norm
+	}
+
+	// R[j][l] is a[l][j] above the diagonal and rdiag[j] on it
+	rinv := make([][]float64, p)
+	for j := range rinv {
+		rinv[j] = make([]float64, p)
+	}
+	beta := make([]float64, p)
+	for j := p - 1; j >= 0; j-- {
+		s := b[j]
+		for l := j + 1; l < p; l++ {
+			s -= a[l][j] * beta[l]
+		}
+		beta[j] = s / rdiag[j]
+
+		rinv[j][j] = 1 / rdiag[j]
+		for l := j + 1; l < p; l++ {
+			var s float64
+			for m := j + 1; m <= l; m++ {
+				s += a[m][j] * rinv[m][l]
+			}
+			rinv[j][l] = -s / rdiag[j]
+		}
+	}
+
+	return beta, rinv, nil
+}
+
+// vecNorm returns the euclidean norm of v
+func vecNorm(v []float64) float64 {
+	var s float64
+	for _, x := range v {
+		s = math.Hypot(s, x)
+	}
+	return s
+}
@@ -0,0 +1,201 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func ExampleOLS() {
+	x := stats.Rows{{1}, {2}, {3}, {4}, {5}}
+	y := stats.Float64Data{2, 4, 5, 4, 5}
+
+	r, _ := stats.OLS(x, y, true)
+	p, _ := r.Predict(6)
+	fmt.Printf("%.2f %.2f %.2f %.2f\n", r.Coefficients[0], r.Coefficients[1], r.RSquared, p)
+	// Output: 2.20 0.60 0.60 5.80
+}
+
+func TestOLS(t *testing.T) {
+	x := stats.Rows{{1}, {2}, {3}, {4}, {5}}
+	y := stats.Float64Data{2, 4, 5, 4, 5}
+
+	r, err := stats.OLS(x, y, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// RSS = 2.4 and TSS = 6 with 3 residual degrees of freedom
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"intercept", r.Coefficients[0], 2.2},
+		{"slope", r.Coefficients[1], 0.6},
+		{"SE intercept", r.StdErrors[0], math.Sqrt(0.88)},
+		{"SE slope", r.StdErrors[1], math.Sqrt(0.08)},
+		{"t slope", r.TValues[1], 0.6 / math.Sqrt(0.08)},
+		{"p slope", r.PValues[1], r.FPValue},
+		{"R²", r.RSquared, 0.6},
+		{"adjusted R²", r.AdjRSquared, 1 - 0.4*4/3},
+		{"F", r.FStatistic, 4.5},
+		{"p F", r.FPValue, stats.FSf(4.5, 1, 3, 0, 1)},
+		{"DFModel", r.DFModel, 1},
+		{"DFResid", r.DFResid, 3},
+		{"residual", r.Residuals[0], -0.8},
+		{"fitted", r.Fitted[4], 5.2},
+	}
+	for _, c := range checks {
+		if !tolerance(c.got, c.want, 1e-12) {
+			t.Errorf("%s => %v != %v", c.name, c.got, c.want)
+		}
+	}
+
+	if _, err := r.Predict(1, 2); err != stats.ErrSize {
+		t.Errorf("Predict with too many predictors => %v != %v", err, stats.ErrSize)
+	}
+}
+
+func TestOLSMultiple(t *testing.T) {
+	// y = 1 + 2 x1 - 3 x2 exactly
+	x := stats.Rows{{0, 1}, {1, 0}, {2, 1}, {3, 5}, {4, 2}, {5, 3}}
+	y := make(stats.Float64Data, len(x))
+	for i, row := range x {
+		y[i] = 1 + 2*row[0] - 3*row[1]
+	}
+
+	r, err := stats.OLS(x, y, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j, want := range []float64{1, 2, -3} {
+		if !tolerance(r.Coefficients[j], want, 1e-12) {
+			t.Errorf("Coefficients[%d] => %v != %v", j, r.Coefficients[j], want)
+		}
+	}
+	if !tolerance(r.RSquared, 1, 1e-12) {
+		t.Errorf("RSquared => %v != 1", r.RSquared)
+	}
+
+	// Without an intercept, the model is fitted through the origin
+	r, err = stats.OLS(stats.Rows{{1}, {2}, {3}}, stats.Float64Data{2, 4, 7}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 31.0 / 14; !tolerance(r.Coefficients[0], want, 1e-12) || len(r.Coefficients) != 1 {
+		t.Errorf("Coefficients => %v != [%v]", r.Coefficients, want)
+	}
+}
+
+func TestWLS(t *testing.T) {
+	x := stats.Rows{{1}, {2}, {3}, {4}, {5}}
+	y := stats.Float64Data{2, 4, 5, 4, 5}
+
+	// Integer weights are equivalent to repeated observations
+	r, err := stats.WLS(x, y, stats.Float64Data{1, 2, 1, 1, 1}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, _ := stats.OLS(stats.Rows{{1}, {2}, {2}, {3}, {4}, {5}}, stats.Float64Data{2, 4, 4, 5, 4, 5}, true)
+	for j := range o.Coefficients {
+		if !tolerance(r.Coefficients[j], o.Coefficients[j], 1e-12) {
+			t.Errorf("Coefficients[%d] => %v != %v", j, r.Coefficients[j], o.Coefficients[j])
+		}
+	}
+	if !tolerance(r.RSquared, o.RSquared, 1e-12) {
+		t.Errorf("RSquared => %v != %v", r.RSquared, o.RSquared)
+	}
+
+	// Observations with zero weight are left out
+	r, err = stats.WLS(x, y, stats.Float64Data{1, 0, 1, 1, 1}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, _ = stats.OLS(stats.Rows{{1}, {3}, {4}, {5}}, stats.Float64Data{2, 5, 4, 5}, true)
+	if r.DFResid != o.DFResid || !tolerance(r.AdjRSquared, o.AdjRSquared, 1e-12) {
+		t.Errorf("DFResid, AdjRSquared => %v, %v != %v, %v", r.DFResid, r.AdjRSquared, o.DFResid, o.AdjRSquared)
+	}
+	for j := range o.StdErrors {
+		if !tolerance(r.StdErrors[j], o.StdErrors[j], 1e-12) || !tolerance(r.PValues[j], o.PValues[j], 1e-12) {
+			t.Errorf("StdErrors[%d], PValues[%d] => %v, %v != %v, %v", j, j, r.StdErrors[j], r.PValues[j], o.StdErrors[j], o.PValues[j])
+		}
+	}
+	if _, err := stats.WLS(x, y, stats.Float64Data{1, 0, 0, 0, 1}, true); err != stats.ErrBounds {
+		t.Errorf("WLS with too few positive weights => %v != %v", err, stats.ErrBounds)
+	}
+
+	if _, err := stats.WLS(x, y, stats.Float64Data{1, 1}, true); err != stats.ErrSize {
+		t.Errorf("WLS with too few weights => %v != %v", err, stats.ErrSize)
+	}
+	if _, err := stats.WLS(x, y, stats.Float64Data{1, 1, -1, 1, 1}, true); err != stats.ErrNegative {
+		t.Errorf("WLS with negative weights => %v != %v", err, stats.ErrNegative)
+	}
+}
+
+func TestPolynomialRegression(t *testing.T) {
+	// y = 1 - x + 0.5 x² exactly
+	var s stats.Series
+	for x := -2.0; x <= 3; x++ {
+		s = append(s, stats.Coordinate{X: x, Y: 1 - x + 0.5*x*x})
+	}
+
+	r, err := stats.PolynomialRegression(s, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j, want := range []float64{1, -1, 0.5} {
+		if !tolerance(r.Coefficients[j], want, 1e-12) {
+			t.Errorf("Coefficients[%d] => %v != %v", j, r.Coefficients[j], want)
+		}
+	}
+	if p, _ := r.Predict(4); !tolerance(p, 5, 1e-12) {
+		t.Errorf("Predict(4) => %v != 5", p)
+	}
+
+	if _, err := stats.PolynomialRegression(s, 0); err != stats.ErrBounds {
+		t.Errorf("PolynomialRegression(0) => %v != %v", err, stats.ErrBounds)
+	}
+	if _, err := stats.PolynomialRegression(s, 5); err != stats.ErrBounds {
+		t.Errorf("PolynomialRegression(5) => %v != %v", err, stats.ErrBounds)
+	}
+}
+
+func TestOLSErrors(t *testing.T) {
+	if _, err := stats.OLS(stats.Rows{}, stats.Float64Data{}, true); err != stats.ErrEmptyInput {
+		t.Errorf("OLS of no rows => %v != %v", err, stats.ErrEmptyInput)
+	}
+	if _, err := stats.OLS(stats.Rows{{1}, {2}, {3}}, stats.Float64Data{1, 2}, true); err != stats.ErrSize {
+		t.Errorf("OLS with too few values => %v != %v", err, stats.ErrSize)
+	}
+	if _, err := stats.OLS(stats.Rows{{1}, {2}, {math.NaN()}}, stats.Float64Data{1, 2, 3}, true); err != stats.ErrNaN {
+		t.Errorf("OLS with NaN => %v != %v", err, stats.ErrNaN)
+	}
+
+	// The second predictor is a multiple of the first
+	x := stats.Rows{{1, 2}, {2, 4}, {3, 6}, {4, 8}}
+	if _, err := stats.OLS(x, stats.Float64Data{1, 2, 3, 5}, true); err != stats.ErrSingular {
+		t.Errorf("OLS of collinear predictors => %v != %v", err, stats.ErrSingular)
+	}
+}
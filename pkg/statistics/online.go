@@ -0,0 +1,359 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+)
+
+// Accumulator computes statistics of a stream of values in constant
+// memory. The moments are updated using the algorithms of Welford and
+// Pébay, the quantiles are estimated by a t-digest. Accumulators of
+// several shards can be combined using Merge, and their state can be
+// saved and restored using encoding/json.
+//
+// The zero value is an empty Accumulator ready to use. An Accumulator
+// is not safe for concurrent use.
+type Accumulator struct {
+	n, mean, m2, m3, m4 float64
+	min, max            float64
+	digest              tdigest
+}
+
+// DefaultCompression is the compression of the t-digest of an
+// Accumulator unless set by NewAccumulator.
+const DefaultCompression = 100
+
+// NewAccumulator creates an empty Accumulator. Higher compressions
+// improve the accuracy of the quantiles at the cost of memory, which
+// is roughly proportional to the compression.
+func NewAccumulator(compression float64) *Accumulator {
+	return &Accumulator{digest: tdigest{compression: compression}}
+}
+
+// Add adds the value x. NaN and infinite values are rejected.
+func (a *Accumulator) Add(x float64) error {
+	if math.IsNaN(x) {
+		return ErrNaN
+	}
+	if math.IsInf(x, 0) {
+		return ErrInfValue
+	}
+	a.merge(1, x, 0, 0, 0, x, x)
+	a.digest.add(centroid{x, 1})
+	return nil
+}
+
+// AddAll adds all values of input. It stops at the first value
+// that is rejected by Add.
+func (a *Accumulator) AddAll(input Float64Data) error {
+	for _, x := range input {
+		if err := a.Add(x); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Merge adds all values that were added to b.
+func (a *Accumulator) Merge(b *Accumulator) {
+	if b.n == 0 {
+		return
+	}
+	a.merge(b.n, b.mean, b.m2, b.m3, b.m4, b.min, b.max)
+	b.digest.compress()
+	for _, c := range b.digest.centroids {
+		a.digest.add(c)
+	}
+}
+
+// merge combines the moments of a with those of another set of values
+func (a *Accumulator) merge(nb, meanb, m2b, m3b, m4b, minb, maxb float64) {
+	if a.n == 0 {
+		a.n, a.mean, a.m2, a.m3, a.m4, a.min, a.max = nb, meanb, m2b, m3b, m4b, minb, maxb
+		return
+	}
+
+	na := a.n
+	n := na + nb
+	d := meanb - a.mean
+	dn := d / n
+
+	m4 := a.m4 + m4b + d*dn*dn*dn*na*nb*(na*na-na*nb+nb*nb) +
+		6*dn*dn*(na*na*m2b+nb*nb*a.m2) + 4*dn*(na*m3b-nb*a.m3)
+	m3 := a.m3 + m3b + d*dn*dn*na*nb*(na-nb) + 3*dn*(na*m2b-nb*a.m2)
+	m2 := a.m2 + m2b + d*dn*na*nb
+
+	a.n, a.mean, a.m2, a.m3, a.m4 = n, a.mean+dn*nb, m2, m3, m4
+	a.min = math.Min(a.min, minb)
+	a.max = math.Max(a.max, maxb)
+}
+
+// Count returns the number of values.
+func (a *Accumulator) Count() int {
+	return int(a.n)
+}
+
+// Mean returns the mean of the values.
+func (a *Accumulator) Mean() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmptyInput
+	}
+	return a.mean, nil
+}
+
+// Min returns the smallest value.
+func (a *Accumulator) Min() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmptyInput
+	}
+	return a.min, nil
+}
+
+// Max returns the largest value.
+func (a *Accumulator) Max() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmptyInput
+	}
+	return a.max, nil
+}
+
+// Variance returns the population variance of the values.
+func (a *Accumulator) Variance() (float64, error) {
+	return a.PopulationVariance()
+}
+
+// PopulationVariance returns the population variance of the values.
+func (a *Accumulator) PopulationVariance() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmptyInput
+	}
+	return a.m2 / a.n, nil
+}
+
+// SampleVariance returns the sample variance of the values.
+func (a *Accumulator) SampleVariance() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmptyInput
+	}
+	return a.m2 / (a.n - 1), nil
+}
+
+// StandardDeviation returns the population standard deviation of the values.
+func (a *Accumulator) StandardDeviation() (float64, error) {
+	v, err := a.PopulationVariance()
+	return math.Sqrt(v), err
+}
+
+// Skewness returns the population skewness of the values.
+func (a *Accumulator) Skewness() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmptyInput
+	}
+	return math.Sqrt(a.n) * a.m3 / math.Pow(a.m2, 1.5), nil
+}
+
+// Kurtosis returns the population excess kurtosis of the values.
+func (a *Accumulator) Kurtosis() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmptyInput
+	}
+	return a.n*a.m4/(a.m2*a.m2) - 3, nil
+}
+
+// Percentile returns an estimate of the percentile of the values.
+// The percent must be within [0, 100].
+func (a *Accumulator) Percentile(percent float64) (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmptyInput
+	}
+	if percent < 0 || percent > 100 || math.IsNaN(percent) {
+		return math.NaN(), ErrBounds
+	}
+	return a.digest.quantile(percent/100, a.min, a.max), nil
+}
+
+// Median returns an estimate of the median of the values.
+func (a *Accumulator) Median() (float64, error) {
+	return a.Percentile(50)
+}
+
+// accumulatorState is the serialised form of an Accumulator
+type accumulatorState struct {
+	Count       float64      `json:"count"`
+	Mean        float64      `json:"mean"`
+	M2          float64      `json:"m2"`
+	M3          float64      `json:"m3"`
+	M4          float64      `json:"m4"`
+	Min         float64      `json:"min"`
+	Max         float64      `json:"max"`
+	Compression float64      `json:"compression"`
+	Centroids   [][2]float64 `json:"centroids"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a *Accumulator) MarshalJSON() ([]byte, error) {
+	a.digest.compress()
+	s := accumulatorState{
+		Count:       a.n,
+		Mean:        a.mean,
+		M2:          a.m2,
+		M3:          a.m3,
+		M4:          a.m4,
+		Min:         a.min,
+		Max:         a.max,
+		Compression: a.digest.compression,
+		Centroids:   make([][2]float64, len(a.digest.centroids)),
+	}
+	for i, c := range a.digest.centroids {
+		s.Centroids[i] = [2]float64{c.mean, c.weight}
+	}
+	if a.n == 0 {
+		// Min and max are not defined and JSON cannot hold infinite values
+		s.Min, s.Max = 0, 0
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (a *Accumulator) UnmarshalJSON(data []byte) error {
+	var s accumulatorState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*a = Accumulator{
+		n:      s.Count,
+		mean:   s.Mean,
+		m2:     s.M2,
+		m3:     s.M3,
+		m4:     s.M4,
+		min:    s.Min,
+		max:    s.Max,
+		digest: tdigest{compression: s.Compression},
+	}
+	for _, c := range s.Centroids {
+		a.digest.centroids = append(a.digest.centroids, centroid{c[0], c[1]})
+	}
+	return nil
+}
+
+// centroid summarises values of a t-digest by their mean and number
+type centroid struct {
+	mean, weight float64
+}
+
+// tdigest is the merging variant of the t-digest of Dunning and Ertl,
+// using the scale function k1.
+type tdigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+}
+
+// delta returns the compression, or the default if it is not set
+func (t *tdigest) delta() float64 {
+	if t.compression <= 0 {
+		return DefaultCompression
+	}
+	return t.compression
+}
+
+// add buffers the centroid c and compresses the buffer once it is full
+func (t *tdigest) add(c centroid) {
+	t.buffer = append(t.buffer, c)
+	if len(t.buffer) >= 5*int(t.delta()) {
+		t.compress()
+	}
+}
+
+// compress merges the buffer into the centroids
+func (t *tdigest) compress() {
+	if len(t.buffer) == 0 {
+		return
+	}
+
+	all := append(t.centroids, t.buffer...)
+	t.buffer = t.buffer[:0]
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	var total float64
+	for _, c := range all {
+		total += c.weight
+	}
+
+	delta := t.delta()
+	k := func(q float64) float64 { return delta / (2 * math.Pi) * math.Asin(2*q-1) }
+	kInv := func(k float64) float64 { return (math.Sin(k*2*math.Pi/delta) + 1) / 2 }
+
+	merged := make([]centroid, 0, int(delta))
+	cur := all[0]
+	var q0 float64
+	qLimit := kInv(k(q0) + 1)
+	for _, c := range all[1:] {
+		if q0+(cur.weight+c.weight)/total <= qLimit {
+			w := cur.weight + c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / w
+			cur.weight = w
+			continue
+		}
+		merged = append(merged, cur)
+		q0 += cur.weight / total
+		qLimit = kInv(k(q0) + 1)
+		cur = c
+	}
+	t.centroids = append(merged, cur)
+}
+
+// quantile estimates the quantile q by interpolating between the
+// centroids, whose weight is assumed to be centred at their mean
+func (t *tdigest) quantile(q, min, max float64) float64 {
+	t.compress()
+	cs := t.centroids
+	if len(cs) == 1 {
+		return cs[0].mean
+	}
+
+	var total float64
+	for _, c := range cs {
+		total += c.weight
+	}
+	target := q * total
+
+	// Before the centre of the first centroid
+	if target < cs[0].weight/2 {
+		return min + (cs[0].mean-min)*target/(cs[0].weight/2)
+	}
+
+	cum := cs[0].weight / 2
+	for i := 0; i < len(cs)-1; i++ {
+		step := (cs[i].weight + cs[i+1].weight) / 2
+		if target <= cum+step {
+			return cs[i].mean + (cs[i+1].mean-cs[i].mean)*(target-cum)/step
+		}
+		cum += step
+	}
+
+	// After the centre of the last centroid
+	last := cs[len(cs)-1]
+	return last.mean + (max-last.mean)*(target-cum)/(last.weight/2)
+}
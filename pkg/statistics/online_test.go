@@ -0,0 +1,197 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestAccumulator(t *testing.T) {
+	data := stats.Float64Data{2, 4, 4, 4, 5, 5, 7, 9}
+
+	var a stats.Accumulator
+	if err := a.AddAll(data); err != nil {
+		t.Fatal(err)
+	}
+
+	mean, _ := data.Mean()
+	pvar, _ := data.PopulationVariance()
+	svar, _ := data.SampleVariance()
+	min, _ := data.Min()
+	max, _ := data.Max()
+
+	// Third and fourth central moments of the data are 5.25 and 44.5
+	fns := []struct {
+		name string
+		fn   func() (float64, error)
+		want float64
+	}{
+		{"Mean", a.Mean, mean},
+		{"Variance", a.Variance, pvar},
+		{"SampleVariance", a.SampleVariance, svar},
+		{"StandardDeviation", a.StandardDeviation, 2},
+		{"Min", a.Min, min},
+		{"Max", a.Max, max},
+		{"Skewness", a.Skewness, 5.25 / 8},
+		{"Kurtosis", a.Kurtosis, 44.5/16 - 3},
+	}
+	for _, f := range fns {
+		got, err := f.fn()
+		if err != nil || !tolerance(got, f.want, 1e-12) {
+			t.Errorf("%s => %v, %v != %v", f.name, got, err, f.want)
+		}
+	}
+	if a.Count() != 8 {
+		t.Errorf("Count => %v != 8", a.Count())
+	}
+
+	if err := a.Add(math.NaN()); err != stats.ErrNaN {
+		t.Errorf("Add(NaN) => %v != %v", err, stats.ErrNaN)
+	}
+	if err := a.Add(math.Inf(1)); err != stats.ErrInfValue {
+		t.Errorf("Add(+Inf) => %v != %v", err, stats.ErrInfValue)
+	}
+	if a.Count() != 8 {
+		t.Errorf("Count after rejected values => %v != 8", a.Count())
+	}
+}
+
+func TestAccumulatorEmpty(t *testing.T) {
+	a := stats.NewAccumulator(50)
+	fns := map[string]func() (float64, error){
+		"Mean":     a.Mean,
+		"Variance": a.Variance,
+		"Min":      a.Min,
+		"Max":      a.Max,
+		"Skewness": a.Skewness,
+		"Median":   a.Median,
+	}
+	for name, fn := range fns {
+		if got, err := fn(); err != stats.ErrEmptyInput || !math.IsNaN(got) {
+			t.Errorf("%s of empty Accumulator => %v, %v", name, got, err)
+		}
+	}
+}
+
+func TestAccumulatorPercentile(t *testing.T) {
+	var a stats.Accumulator
+	for i := 1; i <= 100000; i++ {
+		a.Add(float64(i))
+	}
+
+	for _, p := range []float64{0.1, 1, 25, 50, 75, 99, 99.9} {
+		got, err := a.Percentile(p)
+		if err != nil || math.Abs(got-p*1000) > 200 {
+			t.Errorf("Percentile(%v) => %v, %v != %v", p, got, err, p*1000)
+		}
+	}
+	if got, _ := a.Percentile(0); got != 1 {
+		t.Errorf("Percentile(0) => %v != 1", got)
+	}
+	if got, _ := a.Percentile(100); got != 100000 {
+		t.Errorf("Percentile(100) => %v != 100000", got)
+	}
+	if _, err := a.Percentile(101); err != stats.ErrBounds {
+		t.Errorf("Percentile(101) => %v != %v", err, stats.ErrBounds)
+	}
+}
+
+func TestAccumulatorMerge(t *testing.T) {
+	var whole stats.Accumulator
+	shards := make([]stats.Accumulator, 3)
+	for i := 0; i < 30000; i++ {
+		x := math.Sin(float64(i)) * float64(i%97)
+		whole.Add(x)
+		shards[i%3].Add(x)
+	}
+
+	var merged stats.Accumulator
+	for i := range shards {
+		merged.Merge(&shards[i])
+	}
+
+	fns := []struct {
+		name string
+		fn   func(a *stats.Accumulator) (float64, error)
+	}{
+		{"Mean", (*stats.Accumulator).Mean},
+		{"Variance", (*stats.Accumulator).Variance},
+		{"Skewness", (*stats.Accumulator).Skewness},
+		{"Kurtosis", (*stats.Accumulator).Kurtosis},
+		{"Min", (*stats.Accumulator).Min},
+		{"Max", (*stats.Accumulator).Max},
+	}
+	for _, f := range fns {
+		want, _ := f.fn(&whole)
+		got, _ := f.fn(&merged)
+		if !tolerance(got, want, 1e-9) {
+			t.Errorf("%s of merged => %v != %v", f.name, got, want)
+		}
+	}
+	if merged.Count() != whole.Count() {
+		t.Errorf("Count of merged => %v != %v", merged.Count(), whole.Count())
+	}
+
+	want, _ := whole.Median()
+	got, _ := merged.Median()
+	if math.Abs(got-want) > 0.5 {
+		t.Errorf("Median of merged => %v != %v", got, want)
+	}
+}
+
+func TestAccumulatorJSON(t *testing.T) {
+	a := stats.NewAccumulator(20)
+	for i := 0; i < 1000; i++ {
+		a.Add(float64(i % 17))
+	}
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored stats.Accumulator
+	if err := json.Unmarshal(b, &restored); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []float64{0, 10, 50, 90, 100} {
+		want, _ := a.Percentile(p)
+		got, _ := restored.Percentile(p)
+		if got != want {
+			t.Errorf("Percentile(%v) after restore => %v != %v", p, got, want)
+		}
+	}
+	want, _ := a.Kurtosis()
+	got, _ := restored.Kurtosis()
+	if got != want {
+		t.Errorf("Kurtosis after restore => %v != %v", got, want)
+	}
+
+	// The state keeps accumulating after it is restored
+	restored.Add(100)
+	if max, _ := restored.Max(); max != 100 || restored.Count() != 1001 {
+		t.Errorf("Max, Count after restore => %v, %v", max, restored.Count())
+	}
+}
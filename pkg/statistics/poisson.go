@@ -0,0 +1,123 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// PoissonPmf is the probability mass function of the Poisson distribution
+// with mean mu.
+func PoissonPmf(k int, mu float64) float64 {
+	return math.Exp(PoissonLogPmf(k, mu))
+}
+
+// PoissonLogPmf is the log of the probability mass function.
+func PoissonLogPmf(k int, mu float64) float64 {
+	switch {
+	case k < 0:
+		return math.Inf(-1)
+	case mu == 0:
+		if k == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	lg, _ := math.Lgamma(float64(k + 1))
+	return float64(k)*math.Log(mu) - mu - lg
+}
+
+// PoissonCdf is the cumulative distribution function.
+func PoissonCdf(k int, mu float64) float64 {
+	if k < 0 {
+		return 0
+	}
+	return regIncGammaQ(float64(k+1), mu)
+}
+
+// PoissonSf is the survival function (1 - cdf).
+func PoissonSf(k int, mu float64) float64 {
+	if k < 0 {
+		return 1
+	}
+	return regIncGammaP(float64(k+1), mu)
+}
+
+// PoissonPpf is the point percentile function (inverse of cdf).
+func PoissonPpf(p float64, mu float64) float64 {
+	return discretePpf(p, func(k int) float64 { return PoissonCdf(k, mu) }, 0, -1, normalGuess(p, mu, mu))
+}
+
+// PoissonIsf is the inverse survival function (inverse of sf).
+func PoissonIsf(p float64, mu float64) float64 {
+	return discreteIsf(p, func(k int) float64 { return PoissonSf(k, mu) }, 0, -1, normalGuess(1-p, mu, mu))
+}
+
+// PoissonStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func PoissonStats(mu float64, moments string) []float64 {
+	return selectStats(moments, 0, 1, mu, mu, 1/math.Sqrt(mu), 1/mu)
+}
+
+// PoissonFit returns the maximum likelihood estimator of the mean.
+// Takes array of the observed counts.
+func PoissonFit(data []int) float64 {
+	if len(data) == 0 {
+		return math.NaN()
+	}
+	return meanInt(data)
+}
+
+// PoissonMedian is the median of the distribution.
+func PoissonMedian(mu float64) float64 {
+	return PoissonPpf(0.5, mu)
+}
+
+// PoissonMean is the mean/expected value of the distribution.
+func PoissonMean(mu float64) float64 {
+	return mu
+}
+
+// PoissonVar is the variance of the distribution.
+func PoissonVar(mu float64) float64 {
+	return mu
+}
+
+// PoissonStd is the standard deviation of the distribution.
+func PoissonStd(mu float64) float64 {
+	return math.Sqrt(mu)
+}
+
+// PoissonInterval finds endpoints of the range that contains alpha percent of the distribution.
+func PoissonInterval(alpha float64, mu float64) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return PoissonPpf(p, mu) })
+}
+
+// PoissonRvs generates random variates by multiplying uniform variates
+// for small mu and by inversion otherwise.
+func PoissonRvs(mu float64, size int) []int {
+	r := newRand()
+	toReturn := make([]int, size)
+	for i := range toReturn {
+		toReturn[i] = randPoisson(r, mu)
+	}
+	return toReturn
+}
@@ -0,0 +1,77 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestPoisson(t *testing.T) {
+	checkValues(t, "PoissonPmf", func(k float64) float64 { return stats.PoissonPmf(int(k), 3) }, [][2]float64{{2, 4.5 * math.Exp(-3)}, {0, math.Exp(-3)}, {-1, 0}}, 1e-14)
+	checkValues(t, "PoissonLogPmf", func(k float64) float64 { return stats.PoissonLogPmf(int(k), 3) }, [][2]float64{{2, math.Log(4.5) - 3}}, 1e-14)
+	checkValues(t, "PoissonCdf", func(k float64) float64 { return stats.PoissonCdf(int(k), 3) }, [][2]float64{{2, 8.5 * math.Exp(-3)}, {-1, 0}}, 1e-14)
+	checkValues(t, "PoissonSf", func(k float64) float64 { return stats.PoissonSf(int(k), 3) }, [][2]float64{{2, 1 - 8.5*math.Exp(-3)}, {-1, 1}}, 1e-14)
+	checkValues(t, "PoissonPpf", func(p float64) float64 { return stats.PoissonPpf(p, 3) }, [][2]float64{{0.5, 3}, {0.4, 2}, {0, -1}}, 0)
+	checkValues(t, "PoissonIsf", func(p float64) float64 { return stats.PoissonIsf(p, 3) }, [][2]float64{{1 - 8.5*math.Exp(-3), 2}, {0.5, 3}}, 0)
+
+	if got := stats.PoissonPpf(1, 3); !math.IsInf(got, 1) {
+		t.Errorf("PoissonPpf(1) => %v != +Inf", got)
+	}
+	if got := stats.PoissonSf(100, 10); !tolerance(got, 5.339405460719e-64, 1e-10) {
+		t.Errorf("PoissonSf(100, 10) => %v", got)
+	}
+
+	if s := stats.PoissonStats(4, "mvsk"); s[0] != 4 || s[1] != 4 || s[2] != 0.5 || s[3] != 0.25 {
+		t.Errorf("PoissonStats(4) => %v", s)
+	}
+	if got := stats.PoissonFit([]int{1, 2, 3, 6}); got != 3 {
+		t.Errorf("PoissonFit => %v != 3", got)
+	}
+	if got := stats.PoissonMedian(3); got != 3 {
+		t.Errorf("PoissonMedian => %v != 3", got)
+	}
+
+	for _, mu := range []float64{3, 100} {
+		for _, k := range stats.PoissonRvs(mu, 101) {
+			if k < 0 {
+				t.Errorf("PoissonRvs(%v) => %v out of support", mu, k)
+			}
+		}
+	}
+}
+
+func TestPoissonLargeMean(t *testing.T) {
+	checkValues(t, "PoissonCdf", func(k float64) float64 { return stats.PoissonCdf(int(k), 1e5) }, [][2]float64{{1e5, 0.5008410430993401}, {99999, 0.4995794778896348}, {99000, 0.0007742008294447389}}, 1e-9)
+	checkValues(t, "PoissonCdf", func(k float64) float64 { return stats.PoissonCdf(int(k), 1e4) }, [][2]float64{{1e4, 0.5026595812190076}, {10100, 0.8425485756351695}}, 1e-9)
+	checkValues(t, "PoissonSf", func(k float64) float64 { return stats.PoissonSf(int(k), 1e5) }, [][2]float64{{99000, 0.9992257991705553}}, 1e-9)
+	checkValues(t, "PoissonPpf", func(p float64) float64 { return stats.PoissonPpf(p, 1e4) }, [][2]float64{{0.84, 10099}, {0.841, 10100}, {0.025, 9804}}, 0)
+	checkValues(t, "PoissonIsf", func(p float64) float64 { return stats.PoissonIsf(p, 1e4) }, [][2]float64{{0.16, 10099}, {0.159, 10100}}, 0)
+
+	if got := stats.PoissonMedian(1e5); got != 1e5 {
+		t.Errorf("PoissonMedian(1e5) => %v != 1e5", got)
+	}
+	if got := stats.PoissonInterval(0.95, 1e4); got != [2]float64{9804, 10196} {
+		t.Errorf("PoissonInterval(0.95, 1e4) => %v != [9804 10196]", got)
+	}
+}
@@ -20,183 +20,256 @@ package statistics
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-// import "math"
-//
-// // WilcoxonRankSum tests the null hypothesis that two sets
-// // of data are drawn from the same distribution. It does
-// // not handle ties between measurements in x and y.
-// //
-// // Parameters:
-// //    data1 Float64Data: First set of data points.
-// //    data2 Float64Data: Second set of data points.
-// //    Length of both data samples must be equal.
-// //
-// // Return:
-// //    statistic float64: The test statistic under the
-// //                       large-sample approximation that the
-// //                       rank sum statistic is normally distributed.
-// //    pvalue float64: The two-sided p-value of the test
-// //    err error: Any error from the input data parameters
-// //
-// func WilcoxonRankSum(data1, data2 Float64Data) (float64, float64, error) {
-//
-// 	l1 := data1.Len()
-// 	l2 := data2.Len()
-//
-// 	if l1 == 0 || l2 == 0 {
-// 		return math.NaN(), math.NaN(), EmptyInputErr
-// 	}
-//
-// 	if l1 != l2 {
-// 		return math.NaN(), math.NaN(), SizeErr
-// 	}
-//
-// 	alldata := Float64Data{}
-// 	alldata = append(alldata, data1...)
-// 	alldata = append(alldata, data2...)
-//
-// 	// ranked :=
-//
-// 	return 0.0, 0.0, nil
-// }
-//
-// //     x, y = map(np.asarray, (x, y))
-// //     n1 = len(x)
-// //     n2 = len(y)
-// //     alldata = np.concatenate((x, y))
-// //     ranked = rankdata(alldata)
-// //     x = ranked[:n1]
-// //     s = np.sum(x, axis=0)
-// //     expected = n1 * (n1+n2+1) / 2.0
-// //     z = (s - expected) / np.sqrt(n1*n2*(n1+n2+1)/12.0)
-// //     prob = 2 * distributions.norm.sf(abs(z))
-// //
-// //     return RanksumsResult(z, prob)
-//
-// // def rankdata(a, method='average'):
-// //     """
-// //     Assign ranks to data, dealing with ties appropriately.
-// //     Ranks begin at 1.  The `method` argument controls how ranks are assigned
-// //     to equal values.  See [1]_ for further discussion of ranking methods.
-// //     Parameters
-// //     ----------
-// //     a : array_like
-// //         The array of values to be ranked.  The array is first flattened.
-// //     method : str, optional
-// //         The method used to assign ranks to tied elements.
-// //         The options are 'average', 'min', 'max', 'dense' and 'ordinal'.
-// //         'average':
-// //             The average of the ranks that would have been assigned to
-// //             all the tied values is assigned to each value.
-// //         'min':
-// //             The minimum of the ranks that would have been assigned to all
-// //             the tied values is assigned to each value.  (This is also
-// //             referred to as "competition" ranking.)
-// //         'max':
-// //             The maximum of the ranks that would have been assigned to all
-// //             the tied values is assigned to each value.
-// //         'dense':
-// //             Like 'min', but the rank of the next highest element is assigned
-// //             the rank immediately after those assigned to the tied elements.
-// //         'ordinal':
-// //             All values are given a distinct rank, corresponding to the order
-// //             that the values occur in `a`.
-// //         The default is 'average'.
-// //     Returns
-// //     -------
-// //     ranks : ndarray
-// //          An array of length equal to the size of `a`, containing rank
-// //          scores.
-// //     References
-// //     ----------
-// //     .. [1] "Ranking", https://en.wikipedia.org/wiki/Ranking
-// //     Examples
-// //     --------
-// //     >>> from scipy.stats import rankdata
-// //     >>> rankdata([0, 2, 3, 2])
-// //     array([ 1. ,  2.5,  4. ,  2.5])
-// //     """
-// //
-// //     arr = np.ravel(np.asarray(a))
-// //     algo = 'quicksort'
-// //     sorter = np.argsort(arr, kind=algo)
-// //
-// //     inv = np.empty(sorter.size, dtype=np.intp)
-// //     inv[sorter] = np.arange(sorter.size, dtype=np.intp)
-// //
-// //
-// //     arr = arr[sorter]
-// //     obs = np.r_[True, arr[1:] != arr[:-1]]
-// //     dense = obs.cumsum()[inv]
-// //
-// //
-// //     # cumulative counts of each unique value
-// //     count = np.r_[np.nonzero(obs)[0], len(obs)]
-// //
-// //     # average method
-// //     return .5 * (count[dense] + count[dense - 1] + 1)
-//
-// type rankable interface {
-// 	Len() int
-// 	RankEqual(int, int) bool
-// }
+import (
+	"math"
+	"sort"
+)
+
+// rank assigns ranks to the input using fn, which ranks the data
+// after it was sorted. The ranks are returned in the order of the input.
+func rank(input Float64Data, fn func(sorted Float64Data) []float64) ([]float64, error) {
+	if input.Len() == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	idx := make([]int, input.Len())
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return input[idx[i]] < input[idx[j]] })
+
+	sorted := make(Float64Data, len(idx))
+	for i, j := range idx {
+		sorted[i] = input[j]
+	}
+
+	r := make([]float64, len(idx))
+	for i, v := range fn(sorted) {
+		r[idx[i]] = v
+	}
+
+	return r, nil
+}
+
+// StandardRank assigns ranks to the input where tied values
+// receive the lowest rank of the tie ("1224" ranking).
+func StandardRank(input Float64Data) ([]float64, error) {
+	return rank(input, func(d Float64Data) []float64 {
+		r := make([]float64, d.Len())
+		var k int
+		for i := range r {
+			if i == 0 || d[i] != d[i-1] {
+				k = i + 1
+			}
+			r[i] = float64(k)
+		}
+		return r
+	})
+}
+
+// ModifiedRank assigns ranks to the input where tied values
+// receive the highest rank of the tie ("1334" ranking).
+func ModifiedRank(input Float64Data) ([]float64, error) {
+	return rank(input, func(d Float64Data) []float64 {
+		r := make([]float64, d.Len())
+		for i := range r {
+			k := i + 1
+			for j := i + 1; j < len(r) && d[i] == d[j]; j++ {
+				k = j + 1
+			}
+			r[i] = float64(k)
+		}
+		return r
+	})
+}
+
+// DenseRank assigns ranks to the input where tied values receive the
+// same rank and the next value receives the next rank ("1223" ranking).
+func DenseRank(input Float64Data) ([]float64, error) {
+	return rank(input, func(d Float64Data) []float64 {
+		r := make([]float64, d.Len())
+		var k int
+		for i := range r {
+			if i == 0 || d[i] != d[i-1] {
+				k++
+			}
+			r[i] = float64(k)
+		}
+		return r
+	})
+}
+
+// OrdinalRank assigns distinct ranks to the input. Tied values
+// are ranked in the order they appear in ("1234" ranking).
+func OrdinalRank(input Float64Data) ([]float64, error) {
+	return rank(input, func(d Float64Data) []float64 {
+		r := make([]float64, d.Len())
+		for i := range r {
+			r[i] = float64(i + 1)
+		}
+		return r
+	})
+}
+
+// FractionalRank assigns ranks to the input where tied values receive
+// the mean of the ranks they would have received ("1 2.5 2.5 4" ranking).
+func FractionalRank(input Float64Data) ([]float64, error) {
+	return rank(input, fractionalRank)
+}
+
+func fractionalRank(d Float64Data) []float64 {
+	r := make([]float64, d.Len())
+	for i := 0; i < len(r); {
+		var j int
+		f := float64(i + 1)
+		for j = i + 1; j < len(r) && d[i] == d[j]; j++ {
+			f += float64(j + 1)
+		}
+		f /= float64(j - i)
+		for ; i < j; i++ {
+			r[i] = f
+		}
+	}
+	return r
+}
+
+// rankSum returns the sum of the fractional ranks of data1 within
+// the combined data and the tie correction term sum(t^3 - t).
+func rankSum(data1, data2 Float64Data) (float64, float64) {
+	alldata := make(Float64Data, 0, data1.Len()+data2.Len())
+	alldata = append(alldata, data1...)
+	alldata = append(alldata, data2...)
+
+	ranked, _ := FractionalRank(alldata)
+
+	var s float64
+	for _, r := range ranked[:data1.Len()] {
+		s += r
+	}
+
+	sorted := sortedCopy(alldata)
+	var ties float64
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	return s, ties
+}
+
+// WilcoxonRankSum tests the null hypothesis that two sets
+// of data are drawn from the same distribution. It does
+// not correct for ties between measurements in x and y.
 //
-// func StandardRank(d rankable) []float64 {
-// 	r := make([]float64, d.Len())
-// 	var k int
-// 	for i := range r {
-// 		if i == 0 || !d.RankEqual(i, i-1) {
-// 			k = i + 1
-// 		}
-// 		r[i] = float64(k)
-// 	}
-// 	return r
-// }
+// Parameters:
 //
-// func ModifiedRank(d rankable) []float64 {
-// 	r := make([]float64, d.Len())
-// 	for i := range r {
-// 		k := i + 1
-// 		for j := i + 1; j < len(r) && d.RankEqual(i, j); j++ {
-// 			k = j + 1
-// 		}
-// 		r[i] = float64(k)
-// 	}
-// 	return r
-// }
+//	data1 Float64Data: First set of data points.
+//	data2 Float64Data: Second set of data points.
 //
-// func DenseRank(d rankable) []float64 {
-// 	r := make([]float64, d.Len())
-// 	var k int
-// 	for i := range r {
-// 		if i == 0 || !d.RankEqual(i, i-1) {
-// 			k++
-// 		}
-// 		r[i] = float64(k)
-// 	}
-// 	return r
-// }
+// Return:
 //
-// func OrdinalRank(d rankable) []float64 {
-// 	r := make([]float64, d.Len())
-// 	for i := range r {
-// 		r[i] = float64(i + 1)
-// 	}
-// 	return r
-// }
+//	TestResult: The test statistic under the large-sample
+//	            approximation that the rank sum statistic is
+//	            normally distributed, and the two-sided p-value.
+//	            DF is NaN.
+//	err error: Any error from the input data parameters
+func WilcoxonRankSum(data1, data2 Float64Data) (TestResult, error) {
+	n1 := float64(data1.Len())
+	n2 := float64(data2.Len())
+
+	if n1 == 0 || n2 == 0 {
+		return nanTestResult, ErrEmptyInput
+	}
+
+	s, _ := rankSum(data1, data2)
+	expected := n1 * (n1 + n2 + 1) / 2
+	z := (s - expected) / math.Sqrt(n1*n2*(n1+n2+1)/12)
+
+	return TestResult{
+		Statistic: z,
+		PValue:    2 * normSf(math.Abs(z)),
+		DF:        math.NaN(),
+	}, nil
+}
+
+// MannWhitneyU tests the null hypothesis that the distributions underlying
+// data1 and data2 are equal, against the two-sided alternative.
 //
-// func FractionalRank(d rankable) []float64 {
-// 	r := make([]float64, d.Len())
-// 	for i := 0; i < len(r); {
-// 		var j int
-// 		f := float64(i + 1)
-// 		for j = i + 1; j < len(r) && d.RankEqual(i, j); j++ {
-// 			f += float64(j + 1)
-// 		}
-// 		f /= float64(j - i)
-// 		for ; i < j; i++ {
-// 			r[i] = f
-// 		}
-// 	}
-// 	return r
-// }
+// The statistic is the U statistic of data1. If both sets contain at most
+// 8 values and there are no ties, the exact p-value is computed. Otherwise
+// the normal approximation with tie and continuity correction is used.
+// DF is NaN.
+func MannWhitneyU(data1, data2 Float64Data) (TestResult, error) {
+	l1 := data1.Len()
+	l2 := data2.Len()
+
+	if l1 == 0 || l2 == 0 {
+		return nanTestResult, ErrEmptyInput
+	}
+
+	n1 := float64(l1)
+	n2 := float64(l2)
+	n := n1 + n2
+
+	s, ties := rankSum(data1, data2)
+	u1 := s - n1*(n1+1)/2
+	u := math.Max(u1, n1*n2-u1)
+
+	var p float64
+	if l1 <= 8 && l2 <= 8 && ties == 0 {
+		p = 2 * mannWhitneyUSf(u, l1, l2)
+	} else {
+		mu := n1 * n2 / 2
+		sigma := math.Sqrt(n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1))))
+		p = 2 * normSf((u-mu-0.5)/sigma)
+	}
+
+	return TestResult{
+		Statistic: u1,
+		PValue:    math.Min(p, 1),
+		DF:        math.NaN(),
+	}, nil
+}
+
+// mannWhitneyUSf returns the exact probability P(U >= u) for
+// samples of size n1 and n2 without ties.
+func mannWhitneyUSf(u float64, n1, n2 int) float64 {
+
+	// counts[i][j][k] is the number of arrangements of i values of the
+	// first and j values of the second sample with a U statistic of k.
+	counts := make([][][]float64, n1+1)
+	for i := range counts {
+		counts[i] = make([][]float64, n2+1)
+		for j := range counts[i] {
+			c := make([]float64, i*j+1)
+			if i == 0 || j == 0 {
+				c[0] = 1
+			} else {
+				for k := range c {
+					if k >= j {
+						c[k] += counts[i-1][j][k-j]
+					}
+					if k <= i*(j-1) {
+						c[k] += counts[i][j-1][k]
+					}
+				}
+			}
+			counts[i][j] = c
+		}
+	}
+
+	var total, tail float64
+	for k, c := range counts[n1][n2] {
+		total += c
+		if float64(k) >= u {
+			tail += c
+		}
+	}
+
+	return tail / total
+}
@@ -20,40 +20,101 @@ package statistics_test
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-// import (
-// 	"testing"
-// )
-//
-// //   >>> y1=[125,115,130,140,140,115,140,125,140,135]
-// // >>> y2=[110,122,125,120,140,124,123,137,135,145]
-// // >>> ss.wilcoxon(y1, y2)
-// // (18.0, 0.5936305914425295)
-//
-// // func ExampleWilcoxonRankSum() {
-// // 	t, p, err := WilcoxonRankSum([]float64{3.0, 1.0, 0.2}, []float64{3.1, 1.2, 1.2})
-// // 	fmt.Println(t, p, err)
-// // 	// Output: 18.0, 0.5936305914425295, nil
-// //
-// // }
-//
-// func TestRanked(t *testing.T) {
-//
-// 	var data = []float64{0.1, 3.2, 3.2}
-//
-// 	StandardRank(data)
-// 	// show := func(name string, fn func(rankable) []float64) {
-// 	// 	fmt.Println(name, "Ranking:")
-// 	// 	r := fn(data)
-// 	// 	for i, d := range data {
-// 	// 		fmt.Printf("%4v\n", r[i])
-// 	// 	}
-// 	// }
-// 	//
-// 	// sort.Sort(data)
-// 	// show("Standard", StandardRank)
-// 	// show("\nModified", ModifiedRank)
-// 	// show("\nDense", DenseRank)
-// 	// show("\nOrdinal", OrdinalRank)
-// 	// show("\nFractional", FractionalRank)
-//
-// }
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func ExampleFractionalRank() {
+	r, _ := stats.FractionalRank([]float64{0, 2, 3, 2})
+	fmt.Println(r)
+	// Output: [1 2.5 4 2.5]
+}
+
+func TestRanks(t *testing.T) {
+	data := []float64{0, 2, 3, 2}
+	for _, c := range []struct {
+		name string
+		fn   func(stats.Float64Data) ([]float64, error)
+		out  []float64
+	}{
+		{"Standard", stats.StandardRank, []float64{1, 2, 4, 2}},
+		{"Modified", stats.ModifiedRank, []float64{1, 3, 4, 3}},
+		{"Dense", stats.DenseRank, []float64{1, 2, 3, 2}},
+		{"Ordinal", stats.OrdinalRank, []float64{1, 2, 4, 3}},
+		{"Fractional", stats.FractionalRank, []float64{1, 2.5, 4, 2.5}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := c.fn(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range got {
+				if got[i] != c.out[i] {
+					t.Errorf("%s(%v) => %v != %v", c.name, data, got, c.out)
+					break
+				}
+			}
+
+			_, err = c.fn([]float64{})
+			if err != stats.ErrEmptyInput {
+				t.Errorf("Should have returned error %s", stats.ErrEmptyInput)
+			}
+		})
+	}
+}
+
+func checkTestResult(t *testing.T, name string, got, exp stats.TestResult) {
+	t.Helper()
+
+	if !tolerance(got.Statistic, exp.Statistic, 1e-9) {
+		t.Errorf("%s statistic %v should be %v", name, got.Statistic, exp.Statistic)
+	}
+	if !tolerance(got.PValue, exp.PValue, 1e-9) {
+		t.Errorf("%s p-value %v should be %v", name, got.PValue, exp.PValue)
+	}
+	if math.IsNaN(exp.DF) != math.IsNaN(got.DF) || !math.IsNaN(exp.DF) && !tolerance(got.DF, exp.DF, 1e-9) {
+		t.Errorf("%s df %v should be %v", name, got.DF, exp.DF)
+	}
+}
+
+func TestWilcoxonRankSum(t *testing.T) {
+	got, err := stats.WilcoxonRankSum([]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestResult(t, "WilcoxonRankSum", got, stats.TestResult{Statistic: -2.6111648393354674, PValue: 0.009023438818080334, DF: math.NaN()})
+
+	_, err = stats.WilcoxonRankSum([]float64{}, []float64{1})
+	if err != stats.ErrEmptyInput {
+		t.Errorf("Should have returned error %s", stats.ErrEmptyInput)
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	for _, c := range []struct {
+		name         string
+		data1, data2 []float64
+		out          stats.TestResult
+	}{
+		{"Exact", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, stats.TestResult{Statistic: 0, PValue: 0.007936507936507936, DF: math.NaN()}},
+		{"Exact Overlapping", []float64{1, 3, 5, 7}, []float64{2, 4, 6, 8, 10}, stats.TestResult{Statistic: 6, PValue: 0.41269841269841273, DF: math.NaN()}},
+		{"Asymptotic With Ties", []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, []float64{3, 5, 7, 11, 12, 13}, stats.TestResult{Statistic: 13.5, PValue: 0.12449301394906692, DF: math.NaN()}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := stats.MannWhitneyU(c.data1, c.data2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTestResult(t, "MannWhitneyU", got, c.out)
+		})
+	}
+
+	_, err := stats.MannWhitneyU([]float64{1}, []float64{})
+	if err != stats.ErrEmptyInput {
+		t.Errorf("Should have returned error %s", stats.ErrEmptyInput)
+	}
+}
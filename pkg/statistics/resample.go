@@ -0,0 +1,353 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Statistic computes a statistic of the input, e.g. Median or Trimean.
+// When used for resampling, it must be safe for concurrent use and must
+// not retain the input.
+type Statistic func(input Float64Data) (float64, error)
+
+// TwoSampleStatistic computes a statistic that compares two samples.
+type TwoSampleStatistic func(data1, data2 Float64Data) (float64, error)
+
+// ResampleOptions configures Bootstrap and PermutationTest.
+type ResampleOptions struct {
+
+	// Resamples is the number of resamples. The default is 10000.
+	Resamples int
+
+	// Seed seeds the random number generators. For a given seed, the
+	// results are the same regardless of the number of workers. If Seed
+	// is 0, it is derived from the current time.
+	Seed int64
+
+	// Workers is the number of goroutines computing the resamples.
+	// The default is GOMAXPROCS.
+	Workers int
+}
+
+// resampleBlock is the number of resamples drawn from one random
+// number generator.
+const resampleBlock = 256
+
+// runResamples calls fn for the tasks 0 to n-1. The tasks are split
+// into blocks, each of which uses its own random number generator
+// derived from the seed, so the results do not depend on the order
+// in which the workers process the blocks. It returns the error of
+// the first failed task.
+func runResamples(n int, opts ResampleOptions, fn func(r *rand.Rand, task int) error) error {
+	seed := opts.Seed
+	if seed == 0 {
+		seed = unixnano()
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	blocks := (n + resampleBlock - 1) / resampleBlock
+	errs := make([]error, blocks)
+	var next, failed int32 = -1, 0
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < blocks; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				b := int(atomic.AddInt32(&next, 1))
+				if b >= blocks || atomic.LoadInt32(&failed) != 0 {
+					return
+				}
+				r := rand.New(rand.NewSource(splitmix64(seed, b)))
+				for task := b * resampleBlock; task < n && task < (b+1)*resampleBlock; task++ {
+					if err := fn(r, task); err != nil {
+						errs[b] = err
+						atomic.StoreInt32(&failed, 1)
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitmix64 derives the seed of the random number generator of a block
+func splitmix64(seed int64, block int) int64 {
+	z := uint64(seed) + uint64(block+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
+
+// resamples returns the number of resamples, or the default if it is not set
+func (o ResampleOptions) resamples() int {
+	if o.Resamples <= 0 {
+		return 10000
+	}
+	return o.Resamples
+}
+
+// BootstrapMethod selects how Bootstrap computes the confidence interval
+type BootstrapMethod int
+
+const (
+	// BootstrapPercentile uses the quantiles of the replicates.
+	BootstrapPercentile BootstrapMethod = iota
+
+	// BootstrapBasic reflects the quantiles of the replicates
+	// around the estimate.
+	BootstrapBasic
+
+	// BootstrapBCa corrects the quantiles of the replicates for
+	// bias and skewness. The acceleration is estimated by the
+	// jackknife, which computes the statistic once per value.
+	BootstrapBCa
+)
+
+// BootstrapResult holds a bootstrap confidence interval
+type BootstrapResult struct {
+
+	// Estimate is the statistic of the original data.
+	Estimate float64
+
+	// Lower and Upper are the endpoints of the confidence interval.
+	Lower, Upper float64
+
+	// StdError is the standard deviation of the replicates.
+	StdError float64
+
+	// Bias is the mean of the replicates minus the estimate.
+	Bias float64
+
+	// Replicates are the statistics of the resamples, in the
+	// order they were drawn.
+	Replicates []float64
+}
+
+// Bootstrap estimates a confidence interval of the statistic by resampling
+// the input with replacement. The confidence level, e.g. 0.95, must be
+// within (0, 1).
+func Bootstrap(input Float64Data, stat Statistic, confidence float64, method BootstrapMethod, opts ResampleOptions) (BootstrapResult, error) {
+	nan := BootstrapResult{Estimate: math.NaN(), Lower: math.NaN(), Upper: math.NaN(), StdError: math.NaN(), Bias: math.NaN()}
+	if input.Len() == 0 {
+		return nan, ErrEmptyInput
+	}
+	if !(confidence > 0 && confidence < 1) {
+		return nan, ErrBounds
+	}
+
+	estimate, err := stat(input)
+	if err != nil {
+		return nan, err
+	}
+
+	n := input.Len()
+	replicates := make([]float64, opts.resamples())
+	buffers := sync.Pool{New: func() interface{} { return make(Float64Data, n) }}
+	err = runResamples(len(replicates), opts, func(r *rand.Rand, task int) error {
+		buf := buffers.Get().(Float64Data)
+		defer buffers.Put(buf)
+		for i := range buf {
+			buf[i] = input[r.Intn(n)]
+		}
+		v, err := stat(buf)
+		replicates[task] = v
+		return err
+	})
+	if err != nil {
+		return nan, err
+	}
+
+	sorted := sortedCopy(replicates)
+	mean, _ := Mean(replicates)
+	sd, _ := StandardDeviationSample(replicates)
+	res := BootstrapResult{
+		Estimate:   estimate,
+		StdError:   sd,
+		Bias:       mean - estimate,
+		Replicates: replicates,
+	}
+
+	alpha := (1 - confidence) / 2
+	switch method {
+	case BootstrapPercentile:
+		res.Lower = quantileSorted(sorted, alpha)
+		res.Upper = quantileSorted(sorted, 1-alpha)
+	case BootstrapBasic:
+		res.Lower = 2*estimate - quantileSorted(sorted, 1-alpha)
+		res.Upper = 2*estimate - quantileSorted(sorted, alpha)
+	case BootstrapBCa:
+		a, err := jackknifeAcceleration(input, stat, opts)
+		if err != nil {
+			return nan, err
+		}
+
+		// The bias correction is the normal quantile of the share of
+		// replicates below the estimate, counting ties half
+		below := sort.SearchFloat64s(sorted, estimate)
+		ties := sort.SearchFloat64s(sorted, math.Nextafter(estimate, math.Inf(1))) - below
+		z0 := NormPpf((float64(below)+0.5*float64(ties))/float64(len(sorted)), 0, 1)
+
+		adjust := func(p float64) float64 {
+			z := z0 + NormPpf(p, 0, 1)
+			return NormCdf(z0+z/(1-a*z), 0, 1)
+		}
+		res.Lower = quantileSorted(sorted, adjust(alpha))
+		res.Upper = quantileSorted(sorted, adjust(1-alpha))
+	default:
+		return nan, ErrBounds
+	}
+
+	return res, nil
+}
+
+// jackknifeAcceleration estimates the acceleration of the BCa interval
+// from the skewness of the leave-one-out statistics
+func jackknifeAcceleration(input Float64Data, stat Statistic, opts ResampleOptions) (float64, error) {
+	n := input.Len()
+	if n < 2 {
+		return 0, nil
+	}
+
+	loo := make([]float64, n)
+	err := runResamples(n, opts, func(_ *rand.Rand, i int) error {
+		buf := make(Float64Data, 0, n-1)
+		buf = append(buf, input[:i]...)
+		buf = append(buf, input[i+1:]...)
+		v, err := stat(buf)
+		loo[i] = v
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	mean, _ := Mean(loo)
+	var num, den float64
+	for _, v := range loo {
+		d := mean - v
+		num += d * d * d
+		den += d * d
+	}
+	if den == 0 {
+		return 0, nil
+	}
+	return num / (6 * math.Pow(den, 1.5)), nil
+}
+
+// quantileSorted returns the quantile q of sorted data, interpolating
+// linearly between the order statistics
+func quantileSorted(sorted []float64, q float64) float64 {
+	if math.IsNaN(q) {
+		return math.NaN()
+	}
+	h := q * float64(len(sorted)-1)
+	if h <= 0 {
+		return sorted[0]
+	}
+	if h >= float64(len(sorted)-1) {
+		return sorted[len(sorted)-1]
+	}
+	i := int(h)
+	return sorted[i] + (h-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// MeanDifference is the mean of data1 minus the mean of data2. It is the
+// default statistic of PermutationTest.
+func MeanDifference(data1, data2 Float64Data) (float64, error) {
+	m1, err := Mean(data1)
+	if err != nil {
+		return math.NaN(), err
+	}
+	m2, err := Mean(data2)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return m1 - m2, nil
+}
+
+// PermutationTest tests the null hypothesis that two samples come from
+// the same distribution by randomly reassigning the pooled values to the
+// samples. The statistic defaults to MeanDifference if stat is nil and
+// must be centred at zero under the null hypothesis. The p-value is
+// two-sided and counts the permutations whose statistic is at least as
+// extreme as the observed one, including the observed one itself.
+func PermutationTest(data1, data2 Float64Data, stat TwoSampleStatistic, opts ResampleOptions) (TestResult, error) {
+	if data1.Len() == 0 || data2.Len() == 0 {
+		return nanTestResult, ErrEmptyInput
+	}
+	if stat == nil {
+		stat = MeanDifference
+	}
+
+	observed, err := stat(data1, data2)
+	if err != nil {
+		return nanTestResult, err
+	}
+
+	pooled := append(append(Float64Data{}, data1...), data2...)
+	n1 := data1.Len()
+	extreme := make([]bool, opts.resamples())
+	buffers := sync.Pool{New: func() interface{} { return make(Float64Data, len(pooled)) }}
+	err = runResamples(len(extreme), opts, func(r *rand.Rand, task int) error {
+		buf := buffers.Get().(Float64Data)
+		defer buffers.Put(buf)
+		copy(buf, pooled)
+		r.Shuffle(len(buf), func(i, j int) { buf[i], buf[j] = buf[j], buf[i] })
+		v, err := stat(buf[:n1], buf[n1:])
+		extreme[task] = math.Abs(v) >= math.Abs(observed)*(1-1e-12)
+		return err
+	})
+	if err != nil {
+		return nanTestResult, err
+	}
+
+	count := 1
+	for _, e := range extreme {
+		if e {
+			count++
+		}
+	}
+
+	return TestResult{
+		Statistic: observed,
+		PValue:    float64(count) / float64(len(extreme)+1),
+		DF:        math.NaN(),
+	}, nil
+}
@@ -0,0 +1,161 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+// normalData returns n deterministic values with mean 10 and standard deviation 2
+func normalData(n int) stats.Float64Data {
+	data := make(stats.Float64Data, n)
+	for i := range data {
+		data[i] = stats.NormPpf((float64(i)+0.5)/float64(n), 10, 2)
+	}
+	return data
+}
+
+func TestBootstrap(t *testing.T) {
+	data := normalData(400)
+	opts := stats.ResampleOptions{Resamples: 2000, Seed: 42}
+
+	// The standard error of the mean is 2/sqrt(400)
+	for _, method := range []stats.BootstrapMethod{stats.BootstrapPercentile, stats.BootstrapBasic, stats.BootstrapBCa} {
+		r, err := stats.Bootstrap(data, stats.Mean, 0.95, method, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !tolerance(r.Estimate, 10, 1e-12) {
+			t.Errorf("method %d: Estimate => %v != 10", method, r.Estimate)
+		}
+		if math.Abs(r.StdError-0.1) > 0.01 {
+			t.Errorf("method %d: StdError => %v != 0.1", method, r.StdError)
+		}
+		if math.Abs(r.Lower-(10-0.196)) > 0.03 || math.Abs(r.Upper-(10+0.196)) > 0.03 {
+			t.Errorf("method %d: interval => [%v, %v] != [9.804, 10.196]", method, r.Lower, r.Upper)
+		}
+		if len(r.Replicates) != 2000 {
+			t.Errorf("method %d: %d replicates != 2000", method, len(r.Replicates))
+		}
+	}
+
+	// The basic interval reflects the percentile interval around the estimate
+	p, _ := stats.Bootstrap(data, stats.Median, 0.9, stats.BootstrapPercentile, opts)
+	b, _ := stats.Bootstrap(data, stats.Median, 0.9, stats.BootstrapBasic, opts)
+	if !veryclose(b.Lower, 2*p.Estimate-p.Upper) || !veryclose(b.Upper, 2*p.Estimate-p.Lower) {
+		t.Errorf("basic interval [%v, %v] does not reflect [%v, %v]", b.Lower, b.Upper, p.Lower, p.Upper)
+	}
+}
+
+func TestBootstrapReproducible(t *testing.T) {
+	data := normalData(50)
+
+	r1, _ := stats.Bootstrap(data, stats.Trimean, 0.95, stats.BootstrapBCa, stats.ResampleOptions{Resamples: 1000, Seed: 7, Workers: 1})
+	r8, _ := stats.Bootstrap(data, stats.Trimean, 0.95, stats.BootstrapBCa, stats.ResampleOptions{Resamples: 1000, Seed: 7, Workers: 8})
+	for i := range r1.Replicates {
+		if r1.Replicates[i] != r8.Replicates[i] {
+			t.Fatalf("Replicates[%d] => %v != %v", i, r8.Replicates[i], r1.Replicates[i])
+		}
+	}
+	if r1.Lower != r8.Lower || r1.Upper != r8.Upper {
+		t.Errorf("interval with 8 workers [%v, %v] != [%v, %v]", r8.Lower, r8.Upper, r1.Lower, r1.Upper)
+	}
+
+	r2, _ := stats.Bootstrap(data, stats.Trimean, 0.95, stats.BootstrapBCa, stats.ResampleOptions{Resamples: 1000, Seed: 8})
+	if r2.Lower == r1.Lower && r2.Upper == r1.Upper {
+		t.Errorf("different seeds gave the same interval [%v, %v]", r1.Lower, r1.Upper)
+	}
+}
+
+func TestBootstrapErrors(t *testing.T) {
+	opts := stats.ResampleOptions{Resamples: 100, Seed: 1}
+	if _, err := stats.Bootstrap(stats.Float64Data{}, stats.Mean, 0.95, stats.BootstrapPercentile, opts); err != stats.ErrEmptyInput {
+		t.Errorf("Bootstrap of empty input => %v != %v", err, stats.ErrEmptyInput)
+	}
+	if _, err := stats.Bootstrap(normalData(10), stats.Mean, 1, stats.BootstrapPercentile, opts); err != stats.ErrBounds {
+		t.Errorf("Bootstrap with confidence 1 => %v != %v", err, stats.ErrBounds)
+	}
+
+	// Errors of the statistic are passed on
+	errFail := errors.New("fail")
+	fail := func(input stats.Float64Data) (float64, error) {
+		if input[0] == input[1] {
+			return 0, errFail
+		}
+		return 0, nil
+	}
+	if _, err := stats.Bootstrap(stats.Float64Data{1, 2, 3}, fail, 0.95, stats.BootstrapPercentile, opts); err != errFail {
+		t.Errorf("Bootstrap with failing statistic => %v != %v", err, errFail)
+	}
+}
+
+func TestPermutationTest(t *testing.T) {
+	data := normalData(60)
+	var a, b, shifted stats.Float64Data
+	for i, v := range data {
+		if i%2 == 0 {
+			a = append(a, v)
+			shifted = append(shifted, v+3)
+		} else {
+			b = append(b, v)
+		}
+	}
+	opts := stats.ResampleOptions{Resamples: 999, Seed: 3}
+
+	r, err := stats.PermutationTest(a, b, nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.PValue < 0.5 {
+		t.Errorf("PValue of interleaved samples => %v < 0.5", r.PValue)
+	}
+	if !math.IsNaN(r.DF) {
+		t.Errorf("DF => %v != NaN", r.DF)
+	}
+
+	r, _ = stats.PermutationTest(shifted, b, nil, opts)
+	if r.PValue != 0.001 {
+		t.Errorf("PValue of shifted samples => %v != 0.001", r.PValue)
+	}
+	if want, _ := stats.MeanDifference(shifted, b); r.Statistic != want {
+		t.Errorf("Statistic => %v != %v", r.Statistic, want)
+	}
+
+	// The result does not depend on the number of workers
+	median := func(data1, data2 stats.Float64Data) (float64, error) {
+		m1, _ := stats.Median(data1)
+		m2, _ := stats.Median(data2)
+		return m1 - m2, nil
+	}
+	r1, _ := stats.PermutationTest(a, b, median, stats.ResampleOptions{Resamples: 999, Seed: 9, Workers: 1})
+	r4, _ := stats.PermutationTest(a, b, median, stats.ResampleOptions{Resamples: 999, Seed: 9, Workers: 4})
+	if r1.PValue != r4.PValue {
+		t.Errorf("PValue with 4 workers %v != %v", r4.PValue, r1.PValue)
+	}
+
+	if _, err := stats.PermutationTest(a, nil, nil, opts); err != stats.ErrEmptyInput {
+		t.Errorf("PermutationTest of empty sample => %v != %v", err, stats.ErrEmptyInput)
+	}
+}
@@ -0,0 +1,459 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// Numerical approximations of special functions used by the
+// hypothesis tests and the probability distributions.
+
+const (
+	specialEps     = 1e-15
+	specialFpMin   = 1e-300
+	specialMaxIter = 1000
+)
+
+// normSf is the survival function of the standard normal distribution.
+// Unlike NormSf it keeps its precision far out in the upper tail.
+func normSf(z float64) float64 {
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
+
+// lbeta returns the natural logarithm of the beta function B(a, b).
+func lbeta(a, b float64) float64 {
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	return la + lb - lab
+}
+
+// regIncBeta returns the regularized incomplete beta function I_x(a, b).
+func regIncBeta(a, b, x float64) float64 {
+	switch {
+	case math.IsNaN(x) || a <= 0 || b <= 0:
+		return math.NaN()
+	case x <= 0:
+		return 0
+	case x >= 1:
+		return 1
+	}
+
+	bt := math.Exp(a*math.Log(x) + b*math.Log1p(-x) - lbeta(a, b))
+
+	// The continued fraction converges rapidly for x < (a+1)/(a+b+2),
+	// otherwise the symmetry relation is used.
+	if x < (a+1)/(a+b+2) {
+		return bt * betacf(a, b, x) / a
+	}
+	return 1 - bt*betacf(b, a, 1-x)/b
+}
+
+// betacf evaluates the continued fraction of the incomplete beta function
+// using the modified Lentz's method.
+func betacf(a, b, x float64) float64 {
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < specialFpMin {
+		d = specialFpMin
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= specialMaxIter; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+
+		// Even step of the recurrence
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < specialFpMin {
+			d = specialFpMin
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < specialFpMin {
+			c = specialFpMin
+		}
+		d = 1 / d
+		h *= d * c
+
+		// Odd step of the recurrence
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < specialFpMin {
+			d = specialFpMin
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < specialFpMin {
+			c = specialFpMin
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+
+		if math.Abs(del-1) < specialEps {
+			break
+		}
+	}
+
+	return h
+}
+
+// regIncGammaP returns the regularized lower incomplete gamma function P(a, x).
+func regIncGammaP(a, x float64) float64 {
+	switch {
+	case math.IsNaN(x) || a <= 0:
+		return math.NaN()
+	case x <= 0:
+		return 0
+	case math.IsInf(x, 1):
+		return 1
+	}
+
+	if x < a+1 {
+		return gammaSeries(a, x)
+	}
+	return 1 - gammaCF(a, x)
+}
+
+// regIncGammaQ returns the regularized upper incomplete gamma function Q(a, x) = 1 - P(a, x).
+func regIncGammaQ(a, x float64) float64 {
+	switch {
+	case math.IsNaN(x) || a <= 0:
+		return math.NaN()
+	case x <= 0:
+		return 1
+	case math.IsInf(x, 1):
+		return 0
+	}
+
+	if x < a+1 {
+		return 1 - gammaSeries(a, x)
+	}
+	return gammaCF(a, x)
+}
+
+// gammaMaxIter returns the iteration budget of gammaSeries and gammaCF.
+// Near x = a both need a number of terms that grows like sqrt(a).
+func gammaMaxIter(a float64) int {
+	return specialMaxIter + int(10*math.Sqrt(a))
+}
+
+// gammaPrefix returns x^a e^-x / Γ(a), the factor shared by the series and
+// the continued fraction. For large a it is computed from the deviation of
+// x from a and Stirling's series, since a*log(x) and log Γ(a) would cancel
+// out most of their digits.
+func gammaPrefix(a, x float64) float64 {
+	if a < 10 {
+		lg, _ := math.Lgamma(a)
+		return math.Exp(-x + a*math.Log(x) - lg)
+	}
+	d := x - a
+	stirling := (1 - (1-(1-0.75/(a*a))/(3.5*a*a))/(30*a*a)) / (12 * a)
+	return math.Exp(a*math.Log1p(d/a)-d-stirling) * math.Sqrt(a/(2*math.Pi))
+}
+
+// gammaSeries evaluates P(a, x) by its series representation. It returns
+// NaN if the series does not converge.
+func gammaSeries(a, x float64) float64 {
+	ap := a
+	sum := 1 / a
+	del := sum
+	for n := gammaMaxIter(a); n > 0; n-- {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*specialEps {
+			return sum * gammaPrefix(a, x)
+		}
+	}
+	return math.NaN()
+}
+
+// gammaCF evaluates Q(a, x) by its continued fraction representation
+// using the modified Lentz's method. It returns NaN if the continued
+// fraction does not converge.
+func gammaCF(a, x float64) float64 {
+	b := x + 1 - a
+	c := 1 / specialFpMin
+	d := 1 / b
+	h := d
+	for i, n := 1, gammaMaxIter(a); i <= n; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < specialFpMin {
+			d = specialFpMin
+		}
+		c = b + an/c
+		if math.Abs(c) < specialFpMin {
+			c = specialFpMin
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < specialEps {
+			return gammaPrefix(a, x) * h
+		}
+	}
+	return math.NaN()
+}
+
+// invRegIncGammaP returns x such that P(a, x) = p. An initial guess
+// is refined by Halley's method.
+func invRegIncGammaP(a, p float64) float64 {
+	switch {
+	case math.IsNaN(p) || p < 0 || p > 1 || a <= 0:
+		return math.NaN()
+	case p == 0:
+		return 0
+	case p == 1:
+		return math.Inf(1)
+	}
+
+	gln, _ := math.Lgamma(a)
+	a1 := a - 1
+	var lna1, afac, x float64
+
+	if a > 1 {
+		lna1 = math.Log(a1)
+		afac = math.Exp(a1*(lna1-1) - gln)
+		pp := p
+		if p >= 0.5 {
+			pp = 1 - p
+		}
+		t := math.Sqrt(-2 * math.Log(pp))
+		x = (2.30753+t*0.27061)/(1+t*(0.99229+t*0.04481)) - t
+		if p < 0.5 {
+			x = -x
+		}
+		x = math.Max(1e-3, a*math.Pow(1-1/(9*a)-x/(3*math.Sqrt(a)), 3))
+	} else {
+		t := 1 - a*(0.253+a*0.12)
+		if p < t {
+			x = math.Pow(p/t, 1/a)
+		} else {
+			x = 1 - math.Log(1-(p-t)/(1-t))
+		}
+	}
+
+	for j := 0; j < 100; j++ {
+		if x <= 0 {
+			return 0
+		}
+		err := regIncGammaP(a, x) - p
+		var t float64
+		if a > 1 {
+			t = afac * math.Exp(-(x-a1)+a1*(math.Log(x)-lna1))
+		} else {
+			t = math.Exp(-x + a1*math.Log(x) - gln)
+		}
+		u := err / t
+		t = u / (1 - 0.5*math.Min(1, u*((a-1)/x-1)))
+		x -= t
+		if x <= 0 {
+			x = 0.5 * (x + t)
+		}
+		if math.Abs(t) < 1e-14*x {
+			break
+		}
+	}
+
+	return x
+}
+
+// invRegIncBeta returns x such that I_x(a, b) = p. An initial guess
+// is refined by Halley's method.
+func invRegIncBeta(a, b, p float64) float64 {
+	switch {
+	case math.IsNaN(p) || p < 0 || p > 1 || a <= 0 || b <= 0:
+		return math.NaN()
+	case p == 0:
+		return 0
+	case p == 1:
+		return 1
+	}
+
+	a1 := a - 1
+	b1 := b - 1
+	var x float64
+
+	if a >= 1 && b >= 1 {
+		pp := p
+		if p >= 0.5 {
+			pp = 1 - p
+		}
+		t := math.Sqrt(-2 * math.Log(pp))
+		x = (2.30753+t*0.27061)/(1+t*(0.99229+t*0.04481)) - t
+		if p < 0.5 {
+			x = -x
+		}
+		al := (x*x - 3) / 6
+		h := 2 / (1/(2*a-1) + 1/(2*b-1))
+		w := x*math.Sqrt(al+h)/h - (1/(2*b-1)-1/(2*a-1))*(al+5.0/6-2/(3*h))
+		x = a / (a + b*math.Exp(2*w))
+	} else {
+		lna := math.Log(a / (a + b))
+		lnb := math.Log(b / (a + b))
+		t := math.Exp(a*lna) / a
+		u := math.Exp(b*lnb) / b
+		w := t + u
+		if p < t/w {
+			x = math.Pow(a*w*p, 1/a)
+		} else {
+			x = 1 - math.Pow(b*w*(1-p), 1/b)
+		}
+	}
+
+	afac := -lbeta(a, b)
+	for j := 0; j < 100; j++ {
+		if x == 0 || x == 1 {
+			return x
+		}
+		err := regIncBeta(a, b, x) - p
+		t := math.Exp(a1*math.Log(x) + b1*math.Log1p(-x) + afac)
+		u := err / t
+		t = u / (1 - 0.5*math.Min(1, u*(a1/x-b1/(1-x))))
+		x -= t
+		if x <= 0 {
+			x = 0.5 * (x + t)
+		}
+		if x >= 1 {
+			x = 0.5 * (x + t + 1)
+		}
+		if math.Abs(t) < 1e-14*x {
+			break
+		}
+	}
+
+	return x
+}
+
+// digamma returns the logarithmic derivative of the gamma function.
+func digamma(x float64) float64 {
+	switch {
+	case math.IsNaN(x) || x <= 0 && x == math.Floor(x):
+		return math.NaN()
+	case x < 0:
+		return digamma(1-x) - math.Pi/math.Tan(math.Pi*x)
+	}
+
+	// Shift x by the recurrence relation until the asymptotic expansion is accurate
+	var r float64
+	for x < 10 {
+		r -= 1 / x
+		x++
+	}
+
+	f := 1 / (x * x)
+	return r + math.Log(x) - 0.5/x - f*(1.0/12-f*(1.0/120-f*(1.0/252-f*(1.0/240-f/132))))
+}
+
+// trigamma returns the derivative of the digamma function for x > 0.
+func trigamma(x float64) float64 {
+	if math.IsNaN(x) || x <= 0 {
+		return math.NaN()
+	}
+
+	var r float64
+	for x < 10 {
+		r += 1 / (x * x)
+		x++
+	}
+
+	f := 1 / (x * x)
+	return r + 1/x + f/2 + f/x*(1.0/6-f*(1.0/30-f*(1.0/42-f/30)))
+}
+
+// invDigamma returns x > 0 such that digamma(x) = y using Newton's method.
+func invDigamma(y float64) float64 {
+	if math.IsNaN(y) {
+		return math.NaN()
+	}
+
+	// Initial guess by Minka, "Estimating a Dirichlet distribution"
+	x := math.Exp(y) + 0.5
+	if y < -2.22 {
+		x = -1 / (y - digamma(1))
+	}
+	for i := 0; i < 100; i++ {
+		d := (digamma(x) - y) / trigamma(x)
+		x -= d
+		if math.Abs(d) < 1e-14*x {
+			break
+		}
+	}
+	return x
+}
+
+// studentTSf is the survival function of Student's t distribution with df degrees of freedom.
+func studentTSf(t, df float64) float64 {
+	if math.IsNaN(t) || math.IsNaN(df) {
+		return math.NaN()
+	}
+	if math.IsInf(t, 0) {
+		if t > 0 {
+			return 0
+		}
+		return 1
+	}
+
+	tail := 0.5 * regIncBeta(df/2, 0.5, df/(df+t*t))
+	if t < 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// kolmogorovSf is the survival function of the Kolmogorov distribution,
+// i.e. the limiting distribution of sqrt(n)*D.
+func kolmogorovSf(lambda float64) float64 {
+	if lambda <= 0 {
+		return 1
+	}
+
+	// The series converges too slowly for small values,
+	// where the probability is indistinguishable from one.
+	if lambda < 0.2 {
+		return 1
+	}
+
+	var (
+		sum  float64
+		sign = 1.0
+		prev float64
+	)
+	for j := 1; j <= 100; j++ {
+		fj := float64(j)
+		term := sign * math.Exp(-2*fj*fj*lambda*lambda)
+		sum += term
+		if math.Abs(term) <= 1e-10*math.Abs(prev) || math.Abs(term) <= 1e-16*sum {
+			break
+		}
+		prev = term
+		sign = -sign
+	}
+
+	return math.Max(0, math.Min(1, 2*sum))
+}
@@ -0,0 +1,222 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// StudentTPdf is the probability density function of Student's t distribution
+// with df degrees of freedom.
+func StudentTPdf(x float64, df float64, loc float64, scale float64) float64 {
+	return math.Exp(StudentTLogPdf(x, df, loc, scale))
+}
+
+// StudentTLogPdf is the log of the probability density function.
+func StudentTLogPdf(x float64, df float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	a, _ := math.Lgamma((df + 1) / 2)
+	b, _ := math.Lgamma(df / 2)
+	return a - b - 0.5*math.Log(df*math.Pi) - (df+1)/2*math.Log1p(z*z/df) - math.Log(scale)
+}
+
+// StudentTCdf is the cumulative distribution function.
+func StudentTCdf(x float64, df float64, loc float64, scale float64) float64 {
+	return studentTSf(-(x-loc)/scale, df)
+}
+
+// StudentTLogCdf is the log of the cumulative distribution function.
+func StudentTLogCdf(x float64, df float64, loc float64, scale float64) float64 {
+	return math.Log(StudentTCdf(x, df, loc, scale))
+}
+
+// StudentTSf is the survival function (1 - cdf).
+func StudentTSf(x float64, df float64, loc float64, scale float64) float64 {
+	return studentTSf((x-loc)/scale, df)
+}
+
+// StudentTLogSf is the log of the survival function.
+func StudentTLogSf(x float64, df float64, loc float64, scale float64) float64 {
+	return math.Log(StudentTSf(x, df, loc, scale))
+}
+
+// studentTStdPpf is the point percentile function of the standard t distribution.
+func studentTStdPpf(p float64, df float64) float64 {
+	switch {
+	case math.IsNaN(p) || p < 0 || p > 1 || df <= 0:
+		return math.NaN()
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	case p == 0.5:
+		return 0
+	}
+
+	q := p
+	if p > 0.5 {
+		q = 1 - p
+	}
+
+	// P(|T| > t) = 2q = I_x(df/2, 1/2) with x = df/(df+t^2). Close to the
+	// center the complement is inverted to avoid cancellation.
+	var t float64
+	if q < 0.25 {
+		x := invRegIncBeta(df/2, 0.5, 2*q)
+		t = math.Sqrt(df * (1 - x) / x)
+	} else {
+		y := invRegIncBeta(0.5, df/2, 1-2*q)
+		t = math.Sqrt(df * y / (1 - y))
+	}
+
+	if p < 0.5 {
+		return -t
+	}
+	return t
+}
+
+// StudentTPpf is the point percentile function (inverse of cdf).
+func StudentTPpf(p float64, df float64, loc float64, scale float64) float64 {
+	return loc + scale*studentTStdPpf(p, df)
+}
+
+// StudentTIsf is the inverse survival function (inverse of sf).
+func StudentTIsf(p float64, df float64, loc float64, scale float64) float64 {
+	return loc - scale*studentTStdPpf(p, df)
+}
+
+// StudentTMoment is the non-central (raw) moment of order n.
+// Moments of order n >= df do not exist and are returned as Inf or NaN.
+func StudentTMoment(n int, df float64, loc float64, scale float64) float64 {
+	return rawMoment(n, loc, scale, func(k int) float64 {
+		fk := float64(k)
+		switch {
+		case k%2 == 1 && fk < df:
+			return 0
+		case k%2 == 1:
+			return math.NaN()
+		case fk >= df:
+			return math.Inf(1)
+		}
+		a, _ := math.Lgamma((fk + 1) / 2)
+		b, _ := math.Lgamma((df - fk) / 2)
+		c, _ := math.Lgamma(df / 2)
+		return math.Exp(fk/2*math.Log(df) + a + b - 0.5*math.Log(math.Pi) - c)
+	})
+}
+
+// StudentTStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+// Moments that do not exist for the given df are NaN, or Inf if they diverge.
+func StudentTStats(df float64, loc float64, scale float64, moments string) []float64 {
+	m, v, s, k := math.NaN(), math.NaN(), math.NaN(), math.NaN()
+	if df > 1 {
+		m = 0
+	}
+	if df > 2 {
+		v = df / (df - 2)
+	} else if df > 1 {
+		v = math.Inf(1)
+	}
+	if df > 3 {
+		s = 0
+	}
+	if df > 4 {
+		k = 6 / (df - 4)
+	} else if df > 2 {
+		k = math.Inf(1)
+	}
+	return selectStats(moments, loc, scale, m, v, s, k)
+}
+
+// StudentTEntropy is the differential entropy of the RV.
+func StudentTEntropy(df float64, loc float64, scale float64) float64 {
+	return (df+1)/2*(digamma((df+1)/2)-digamma(df/2)) + 0.5*math.Log(df) + lbeta(df/2, 0.5) + math.Log(scale)
+}
+
+// StudentTFit returns the maximum likelihood estimators for Student's t distribution.
+// Takes array of float64 values.
+// Returns array of the degrees of freedom followed by the location and the scale.
+func StudentTFit(data []float64) [3]float64 {
+	if len(data) < 2 {
+		return [3]float64{math.NaN(), math.NaN(), math.NaN()}
+	}
+
+	loc, _ := Median(data)
+	scale, _ := MedianAbsoluteDeviationPopulation(data)
+	if scale == 0 {
+		scale, _ = StandardDeviationPopulation(data)
+	}
+	if scale == 0 {
+		return [3]float64{math.NaN(), loc, 0}
+	}
+
+	// The degrees of freedom and the scale are optimized on a log scale to keep them positive
+	x := nelderMead(func(p []float64) float64 {
+		var ll float64
+		for _, x := range data {
+			ll += StudentTLogPdf(x, math.Exp(p[0]), p[1], math.Exp(p[2]))
+		}
+		if math.IsNaN(ll) {
+			return math.Inf(1)
+		}
+		return -ll
+	}, []float64{math.Log(5), loc, math.Log(scale * 1.4826)}, 0.5)
+
+	return [3]float64{math.Exp(x[0]), x[1], math.Exp(x[2])}
+}
+
+// StudentTMedian is the median of the distribution.
+func StudentTMedian(df float64, loc float64, scale float64) float64 {
+	return loc
+}
+
+// StudentTMean is the mean/expected value of the distribution.
+func StudentTMean(df float64, loc float64, scale float64) float64 {
+	return StudentTStats(df, loc, scale, "m")[0]
+}
+
+// StudentTVar is the variance of the distribution.
+func StudentTVar(df float64, loc float64, scale float64) float64 {
+	return StudentTStats(df, loc, scale, "v")[0]
+}
+
+// StudentTStd is the standard deviation of the distribution.
+func StudentTStd(df float64, loc float64, scale float64) float64 {
+	return math.Sqrt(StudentTVar(df, loc, scale))
+}
+
+// StudentTInterval finds endpoints of the range that contains alpha percent of the distribution.
+func StudentTInterval(alpha float64, df float64, loc float64, scale float64) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return StudentTPpf(p, df, loc, scale) })
+}
+
+// StudentTRvs generates random variates as the ratio of a standard normal
+// and the square root of a scaled chi-squared variate.
+func StudentTRvs(df float64, loc float64, scale float64, size int) []float64 {
+	r := newRand()
+	toReturn := make([]float64, size)
+	for i := range toReturn {
+		chi2 := 2 * randGamma(r, df/2)
+		toReturn[i] = loc + scale*r.NormFloat64()/math.Sqrt(chi2/df)
+	}
+	return toReturn
+}
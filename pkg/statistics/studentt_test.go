@@ -0,0 +1,82 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+// checkValues compares the results of a distribution function against
+// reference values with a relative tolerance.
+func checkValues(t *testing.T, name string, fn func(float64) float64, cases [][2]float64, e float64) {
+	t.Helper()
+	for _, c := range cases {
+		if got := fn(c[0]); !tolerance(got, c[1], e) {
+			t.Errorf("%s(%v) => %v != %v", name, c[0], got, c[1])
+		}
+	}
+}
+
+func TestStudentT(t *testing.T) {
+	checkValues(t, "StudentTPdf", func(x float64) float64 { return stats.StudentTPdf(x, 5, 0, 1) }, [][2]float64{{1.5, 0.12451734464635517}}, 1e-12)
+	checkValues(t, "StudentTCdf", func(x float64) float64 { return stats.StudentTCdf(x, 5, 0, 1) }, [][2]float64{{1.5, 0.9030481598787633}, {0, 0.5}}, 1e-12)
+	checkValues(t, "StudentTSf", func(x float64) float64 { return stats.StudentTSf(x, 5, 0, 1) }, [][2]float64{{2.5, 0.02724504967118812}}, 1e-12)
+	checkValues(t, "StudentTPpf", func(p float64) float64 { return stats.StudentTPpf(p, 5, 0, 1) }, [][2]float64{{0.975, 2.570581835636315}, {0.5, 0}}, 1e-12)
+	checkValues(t, "StudentTIsf", func(p float64) float64 { return stats.StudentTIsf(p, 5, 0, 1) }, [][2]float64{{0.025, 2.570581835636315}}, 1e-12)
+	checkValues(t, "StudentTLogPdf", func(x float64) float64 { return stats.StudentTLogPdf(x, 5, 2, 3) }, [][2]float64{{6.5, math.Log(0.12451734464635517 / 3)}}, 1e-12)
+
+	if got := stats.StudentTMoment(4, 5, 0, 1); !tolerance(got, 25, 1e-12) {
+		t.Errorf("StudentTMoment(4) => %v != 25", got)
+	}
+	if got := stats.StudentTMoment(5, 5, 0, 1); !math.IsNaN(got) {
+		t.Errorf("StudentTMoment(5) => %v != NaN", got)
+	}
+
+	s := stats.StudentTStats(5, 0, 1, "mvsk")
+	if s[0] != 0 || !tolerance(s[1], 5.0/3, 1e-15) || s[2] != 0 || s[3] != 6 {
+		t.Errorf("StudentTStats(5) => %v", s)
+	}
+	if v := stats.StudentTVar(1.5, 0, 1); !math.IsInf(v, 1) {
+		t.Errorf("StudentTVar(1.5) => %v != Inf", v)
+	}
+	if got := stats.StudentTEntropy(5, 0, 1); !tolerance(got, 1.62750267241437, 1e-12) {
+		t.Errorf("StudentTEntropy(5) => %v", got)
+	}
+	if got := stats.StudentTInterval(0.95, 5, 0, 1); !tolerance(got[1], 2.570581835636315, 1e-12) || got[0] != -got[1] {
+		t.Errorf("StudentTInterval(0.95) => %v", got)
+	}
+
+	fit := stats.StudentTFit([]float64{-1.2, 0.4, 2.2, -0.1, 0.7, 2.9, -4.4, 1.8, 0.3, 0.1})
+	exp := [3]float64{2.61706089454818, 0.532508676378742, 1.2176447836390434}
+	for i := range fit {
+		if !tolerance(fit[i], exp[i], 1e-5) {
+			t.Errorf("StudentTFit => %v != %v", fit, exp)
+			break
+		}
+	}
+
+	if len(stats.StudentTRvs(5, 0, 1, 101)) != 101 {
+		t.Error("Input size=101, Expected 101")
+	}
+}
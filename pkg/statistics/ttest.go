@@ -0,0 +1,147 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// TestResult holds the outcome of a hypothesis test
+type TestResult struct {
+
+	// Statistic is the test statistic.
+	Statistic float64
+
+	// PValue is the two-sided p-value.
+	PValue float64
+
+	// DF is the degrees of freedom of the distribution of the
+	// statistic. It is NaN for tests that have none.
+	DF float64
+}
+
+var nanTestResult = TestResult{math.NaN(), math.NaN(), math.NaN()}
+
+// meanVar returns the mean and the sample variance of the input
+func meanVar(input Float64Data) (float64, float64) {
+	m, _ := Mean(input)
+	v, _ := SampleVariance(input)
+	return m, v
+}
+
+// tTestResult builds the result of a t-test from the statistic t
+func tTestResult(t, df float64) TestResult {
+	return TestResult{
+		Statistic: t,
+		PValue:    2 * studentTSf(math.Abs(t), df),
+		DF:        df,
+	}
+}
+
+// StudentTTest tests the null hypothesis that two independent samples
+// have identical means, assuming that both populations have the same
+// variance. The variance is estimated from the pooled samples.
+func StudentTTest(data1, data2 Float64Data) (TestResult, error) {
+	l1 := data1.Len()
+	l2 := data2.Len()
+
+	if l1 == 0 || l2 == 0 {
+		return nanTestResult, ErrEmptyInput
+	}
+
+	if l1+l2 < 3 {
+		return nanTestResult, ErrBounds
+	}
+
+	n1 := float64(l1)
+	n2 := float64(l2)
+	m1, v1 := meanVar(data1)
+	m2, v2 := meanVar(data2)
+	if l1 == 1 {
+		v1 = 0
+	}
+	if l2 == 1 {
+		v2 = 0
+	}
+
+	df := n1 + n2 - 2
+	pooled := ((n1-1)*v1 + (n2-1)*v2) / df
+	t := (m1 - m2) / math.Sqrt(pooled*(1/n1+1/n2))
+
+	return tTestResult(t, df), nil
+}
+
+// WelchTTest tests the null hypothesis that two independent samples
+// have identical means without assuming equal population variances.
+// The degrees of freedom are given by the Welch-Satterthwaite equation.
+func WelchTTest(data1, data2 Float64Data) (TestResult, error) {
+	l1 := data1.Len()
+	l2 := data2.Len()
+
+	if l1 == 0 || l2 == 0 {
+		return nanTestResult, ErrEmptyInput
+	}
+
+	if l1 < 2 || l2 < 2 {
+		return nanTestResult, ErrBounds
+	}
+
+	n1 := float64(l1)
+	n2 := float64(l2)
+	m1, v1 := meanVar(data1)
+	m2, v2 := meanVar(data2)
+
+	se1 := v1 / n1
+	se2 := v2 / n2
+	t := (m1 - m2) / math.Sqrt(se1+se2)
+	df := (se1 + se2) * (se1 + se2) / (se1*se1/(n1-1) + se2*se2/(n2-1))
+
+	return tTestResult(t, df), nil
+}
+
+// PairedTTest tests the null hypothesis that the mean difference
+// between the paired observations of data1 and data2 is zero.
+// Both sets must be of the same length.
+func PairedTTest(data1, data2 Float64Data) (TestResult, error) {
+	l1 := data1.Len()
+	l2 := data2.Len()
+
+	if l1 == 0 || l2 == 0 {
+		return nanTestResult, ErrEmptyInput
+	}
+
+	if l1 != l2 {
+		return nanTestResult, ErrSize
+	}
+
+	if l1 < 2 {
+		return nanTestResult, ErrBounds
+	}
+
+	diff := make(Float64Data, l1)
+	for i := range diff {
+		diff[i] = data1[i] - data2[i]
+	}
+
+	n := float64(l1)
+	m, v := meanVar(diff)
+	t := m / math.Sqrt(v/n)
+
+	return tTestResult(t, n-1), nil
+}
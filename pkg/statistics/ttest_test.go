@@ -0,0 +1,78 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestTTests(t *testing.T) {
+	s1 := []float64{1, 2, 3, 4, 5}
+	s2 := []float64{6, 7, 8, 9, 10}
+	s3 := []float64{6, 9, 8, 19, 10, 3}
+	s4 := []float64{2, 2, 5, 6, 5.5}
+
+	for _, c := range []struct {
+		name         string
+		fn           func(data1, data2 stats.Float64Data) (stats.TestResult, error)
+		data1, data2 []float64
+		out          stats.TestResult
+	}{
+		{"StudentTTest", stats.StudentTTest, s1, s2, stats.TestResult{Statistic: -5, PValue: 0.0010528257933665396, DF: 8}},
+		{"StudentTTest Unequal Sizes", stats.StudentTTest, s1, s3, stats.TestResult{Statistic: -2.439589899826471, PValue: 0.03738977949850239, DF: 9}},
+		{"WelchTTest", stats.WelchTTest, s1, s2, stats.TestResult{Statistic: -5, PValue: 0.0010528257933665396, DF: 8}},
+		{"WelchTTest Unequal Variances", stats.WelchTTest, s1, s3, stats.TestResult{Statistic: -2.6550769430725407, PValue: 0.03779845245900713, DF: 5.995534703657447}},
+		{"PairedTTest", stats.PairedTTest, s1, s4, stats.TestResult{Statistic: -2.75, PValue: 0.05137443084436806, DF: 4}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := c.fn(c.data1, c.data2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTestResult(t, c.name, got, c.out)
+		})
+	}
+
+	for _, c := range []struct {
+		name         string
+		fn           func(data1, data2 stats.Float64Data) (stats.TestResult, error)
+		data1, data2 []float64
+		err          error
+	}{
+		{"StudentTTest Empty", stats.StudentTTest, s1, []float64{}, stats.ErrEmptyInput},
+		{"StudentTTest Too Small", stats.StudentTTest, []float64{1}, []float64{2}, stats.ErrBounds},
+		{"WelchTTest Too Small", stats.WelchTTest, s1, []float64{2}, stats.ErrBounds},
+		{"PairedTTest Size", stats.PairedTTest, s1, s3, stats.ErrSize},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := c.fn(c.data1, c.data2)
+			if err != c.err {
+				t.Errorf("Should have returned error %s", c.err)
+			}
+			if !math.IsNaN(got.PValue) {
+				t.Errorf("p-value %v should be NaN", got.PValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// UniformPdf is the probability density function of the continuous
+// uniform distribution on [loc, loc+scale].
+func UniformPdf(x float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z < 0 || z > 1 {
+		return 0
+	}
+	return 1 / scale
+}
+
+// UniformLogPdf is the log of the probability density function.
+func UniformLogPdf(x float64, loc float64, scale float64) float64 {
+	return math.Log(UniformPdf(x, loc, scale))
+}
+
+// UniformCdf is the cumulative distribution function.
+func UniformCdf(x float64, loc float64, scale float64) float64 {
+	return math.Max(0, math.Min(1, (x-loc)/scale))
+}
+
+// UniformLogCdf is the log of the cumulative distribution function.
+func UniformLogCdf(x float64, loc float64, scale float64) float64 {
+	return math.Log(UniformCdf(x, loc, scale))
+}
+
+// UniformSf is the survival function (1 - cdf).
+func UniformSf(x float64, loc float64, scale float64) float64 {
+	return math.Max(0, math.Min(1, 1-(x-loc)/scale))
+}
+
+// UniformLogSf is the log of the survival function.
+func UniformLogSf(x float64, loc float64, scale float64) float64 {
+	return math.Log(UniformSf(x, loc, scale))
+}
+
+// UniformPpf is the point percentile function (inverse of cdf).
+func UniformPpf(p float64, loc float64, scale float64) float64 {
+	if p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return loc + scale*p
+}
+
+// UniformIsf is the inverse survival function (inverse of sf).
+func UniformIsf(p float64, loc float64, scale float64) float64 {
+	if p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return loc + scale*(1-p)
+}
+
+// UniformMoment is the non-central (raw) moment of order n.
+func UniformMoment(n int, loc float64, scale float64) float64 {
+	return rawMoment(n, loc, scale, func(k int) float64 {
+		return 1 / float64(k+1)
+	})
+}
+
+// UniformStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func UniformStats(loc float64, scale float64, moments string) []float64 {
+	return selectStats(moments, loc, scale, 0.5, 1.0/12, 0, -1.2)
+}
+
+// UniformEntropy is the differential entropy of the RV.
+func UniformEntropy(loc float64, scale float64) float64 {
+	return math.Log(scale)
+}
+
+// UniformFit returns the maximum likelihood estimators for the uniform distribution.
+// Takes array of float64 values.
+// Returns array of the location (the minimum) followed by the scale (the range).
+func UniformFit(data []float64) [2]float64 {
+	if len(data) == 0 {
+		return [2]float64{math.NaN(), math.NaN()}
+	}
+	min, _ := Min(data)
+	max, _ := Max(data)
+	return [2]float64{min, max - min}
+}
+
+// UniformMedian is the median of the distribution.
+func UniformMedian(loc float64, scale float64) float64 {
+	return loc + scale/2
+}
+
+// UniformMean is the mean/expected value of the distribution.
+func UniformMean(loc float64, scale float64) float64 {
+	return loc + scale/2
+}
+
+// UniformVar is the variance of the distribution.
+func UniformVar(loc float64, scale float64) float64 {
+	return scale * scale / 12
+}
+
+// UniformStd is the standard deviation of the distribution.
+func UniformStd(loc float64, scale float64) float64 {
+	return scale / math.Sqrt(12)
+}
+
+// UniformInterval finds endpoints of the range that contains alpha percent of the distribution.
+func UniformInterval(alpha float64, loc float64, scale float64) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return UniformPpf(p, loc, scale) })
+}
+
+// UniformRvs generates random variates on [loc, loc+scale).
+func UniformRvs(loc float64, scale float64, size int) []float64 {
+	r := newRand()
+	toReturn := make([]float64, size)
+	for i := range toReturn {
+		toReturn[i] = loc + scale*r.Float64()
+	}
+	return toReturn
+}
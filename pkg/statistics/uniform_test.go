@@ -0,0 +1,58 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestUniform(t *testing.T) {
+	checkValues(t, "UniformPdf", func(x float64) float64 { return stats.UniformPdf(x, -1, 4) }, [][2]float64{{0, 0.25}, {4, 0}}, 1e-15)
+	checkValues(t, "UniformCdf", func(x float64) float64 { return stats.UniformCdf(x, -1, 4) }, [][2]float64{{0, 0.25}, {-2, 0}, {5, 1}}, 1e-15)
+	checkValues(t, "UniformSf", func(x float64) float64 { return stats.UniformSf(x, -1, 4) }, [][2]float64{{0, 0.75}}, 1e-15)
+	checkValues(t, "UniformPpf", func(p float64) float64 { return stats.UniformPpf(p, -1, 4) }, [][2]float64{{0.25, 0}}, 1e-15)
+	checkValues(t, "UniformIsf", func(p float64) float64 { return stats.UniformIsf(p, -1, 4) }, [][2]float64{{0.75, 0}}, 1e-15)
+
+	if got := stats.UniformMoment(2, 0, 1); !tolerance(got, 1.0/3, 1e-15) {
+		t.Errorf("UniformMoment(2) => %v", got)
+	}
+	if s := stats.UniformStats(-1, 4, "mvsk"); s[0] != 1 || !tolerance(s[1], 16.0/12, 1e-15) || s[2] != 0 || s[3] != -1.2 {
+		t.Errorf("UniformStats(-1, 4) => %v", s)
+	}
+	if got := stats.UniformEntropy(0, 4); got != math.Log(4) {
+		t.Errorf("UniformEntropy => %v", got)
+	}
+	if got := stats.UniformInterval(0.5, 0, 1); got != [2]float64{0.25, 0.75} {
+		t.Errorf("UniformInterval => %v", got)
+	}
+	if fit := stats.UniformFit([]float64{3, 1, 2, 5}); fit != [2]float64{1, 4} {
+		t.Errorf("UniformFit => %v", fit)
+	}
+
+	for _, x := range stats.UniformRvs(2, 3, 101) {
+		if x < 2 || x >= 5 {
+			t.Errorf("UniformRvs => %v outside [2, 5)", x)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "math"
+
+// eulerGamma is the Euler–Mascheroni constant.
+const eulerGamma = 0.57721566490153286060651209008240243104215933593992
+
+// WeibullPdf is the probability density function of the Weibull
+// distribution (minimum) with shape c.
+func WeibullPdf(x float64, c float64, loc float64, scale float64) float64 {
+	return math.Exp(WeibullLogPdf(x, c, loc, scale))
+}
+
+// WeibullLogPdf is the log of the probability density function.
+func WeibullLogPdf(x float64, c float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	switch {
+	case z < 0:
+		return math.Inf(-1)
+	case z == 0 && c < 1:
+		return math.Inf(1)
+	case z == 0 && c > 1:
+		return math.Inf(-1)
+	case z == 0:
+		return math.Log(c) - math.Log(scale)
+	}
+	return math.Log(c) + (c-1)*math.Log(z) - math.Pow(z, c) - math.Log(scale)
+}
+
+// WeibullCdf is the cumulative distribution function.
+func WeibullCdf(x float64, c float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z <= 0 {
+		return 0
+	}
+	return -math.Expm1(-math.Pow(z, c))
+}
+
+// WeibullLogCdf is the log of the cumulative distribution function.
+func WeibullLogCdf(x float64, c float64, loc float64, scale float64) float64 {
+	return math.Log(WeibullCdf(x, c, loc, scale))
+}
+
+// WeibullSf is the survival function (1 - cdf).
+func WeibullSf(x float64, c float64, loc float64, scale float64) float64 {
+	return math.Exp(WeibullLogSf(x, c, loc, scale))
+}
+
+// WeibullLogSf is the log of the survival function.
+func WeibullLogSf(x float64, c float64, loc float64, scale float64) float64 {
+	z := (x - loc) / scale
+	if z <= 0 {
+		return 0
+	}
+	return -math.Pow(z, c)
+}
+
+// WeibullPpf is the point percentile function (inverse of cdf).
+func WeibullPpf(p float64, c float64, loc float64, scale float64) float64 {
+	if p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return loc + scale*math.Pow(-math.Log1p(-p), 1/c)
+}
+
+// WeibullIsf is the inverse survival function (inverse of sf).
+func WeibullIsf(p float64, c float64, loc float64, scale float64) float64 {
+	if p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return loc + scale*math.Pow(-math.Log(p), 1/c)
+}
+
+// WeibullMoment is the non-central (raw) moment of order n.
+func WeibullMoment(n int, c float64, loc float64, scale float64) float64 {
+	return rawMoment(n, loc, scale, func(k int) float64 {
+		return math.Gamma(1 + float64(k)/c)
+	})
+}
+
+// WeibullStats returns the mean, variance, skew, and/or kurtosis.
+// Mean(‘m’), variance(‘v’), skew(‘s’), and/or kurtosis(‘k’).
+// Takes string containing any of 'mvsk'.
+// Returns array of m v s k in that order.
+func WeibullStats(c float64, loc float64, scale float64, moments string) []float64 {
+	g1 := math.Gamma(1 + 1/c)
+	g2 := math.Gamma(1 + 2/c)
+	g3 := math.Gamma(1 + 3/c)
+	g4 := math.Gamma(1 + 4/c)
+
+	v := g2 - g1*g1
+	s := (g3 - 3*g1*g2 + 2*g1*g1*g1) / math.Pow(v, 1.5)
+	k := (g4-4*g1*g3+6*g1*g1*g2-3*g1*g1*g1*g1)/(v*v) - 3
+	return selectStats(moments, loc, scale, g1, v, s, k)
+}
+
+// WeibullEntropy is the differential entropy of the RV.
+func WeibullEntropy(c float64, loc float64, scale float64) float64 {
+	return eulerGamma*(1-1/c) - math.Log(c) + 1 + math.Log(scale)
+}
+
+// WeibullFit returns the maximum likelihood estimators for the Weibull distribution.
+// The location is fixed at zero, so all values must be positive.
+// Takes array of float64 values.
+// Returns array of the shape followed by the location and the scale.
+func WeibullFit(data []float64) [3]float64 {
+	nan := [3]float64{math.NaN(), 0, math.NaN()}
+	if len(data) < 2 {
+		return nan
+	}
+
+	// The values are normalized by their maximum to avoid overflows of x^c
+	max, _ := Max(data)
+	logs := make([]float64, len(data))
+	for i, x := range data {
+		if x <= 0 {
+			return nan
+		}
+		logs[i] = math.Log(x / max)
+	}
+	meanLog, _ := Mean(logs)
+	sdLog, _ := StandardDeviationPopulation(logs)
+	if sdLog == 0 {
+		return nan
+	}
+
+	// Initial guess from the standard deviation of the logs, which is
+	// pi/(c*sqrt(6)), refined by Newton's method on the profile likelihood
+	c := math.Pi / (math.Sqrt(6) * sdLog)
+	for i := 0; i < 100; i++ {
+		var s0, s1, s2 float64
+		for _, l := range logs {
+			xc := math.Exp(c * l)
+			s0 += xc
+			s1 += xc * l
+			s2 += xc * l * l
+		}
+		f := s1/s0 - 1/c - meanLog
+		df := (s2*s0-s1*s1)/(s0*s0) + 1/(c*c)
+		d := f / df
+		if c-d <= 0 {
+			d = c / 2
+		}
+		c -= d
+		if math.Abs(d) < 1e-14*c {
+			break
+		}
+	}
+
+	var s0 float64
+	for _, l := range logs {
+		s0 += math.Exp(c * l)
+	}
+	scale := max * math.Pow(s0/float64(len(logs)), 1/c)
+
+	return [3]float64{c, 0, scale}
+}
+
+// WeibullMedian is the median of the distribution.
+func WeibullMedian(c float64, loc float64, scale float64) float64 {
+	return loc + scale*math.Pow(math.Ln2, 1/c)
+}
+
+// WeibullMean is the mean/expected value of the distribution.
+func WeibullMean(c float64, loc float64, scale float64) float64 {
+	return loc + scale*math.Gamma(1+1/c)
+}
+
+// WeibullVar is the variance of the distribution.
+func WeibullVar(c float64, loc float64, scale float64) float64 {
+	g1 := math.Gamma(1 + 1/c)
+	return scale * scale * (math.Gamma(1+2/c) - g1*g1)
+}
+
+// WeibullStd is the standard deviation of the distribution.
+func WeibullStd(c float64, loc float64, scale float64) float64 {
+	return math.Sqrt(WeibullVar(c, loc, scale))
+}
+
+// WeibullInterval finds endpoints of the range that contains alpha percent of the distribution.
+func WeibullInterval(alpha float64, c float64, loc float64, scale float64) [2]float64 {
+	return interval(alpha, func(p float64) float64 { return WeibullPpf(p, c, loc, scale) })
+}
+
+// WeibullRvs generates random variates as powers of exponential variates.
+func WeibullRvs(c float64, loc float64, scale float64, size int) []float64 {
+	r := newRand()
+	toReturn := make([]float64, size)
+	for i := range toReturn {
+		toReturn[i] = loc + scale*math.Pow(r.ExpFloat64(), 1/c)
+	}
+	return toReturn
+}
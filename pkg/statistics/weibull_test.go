@@ -0,0 +1,63 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+func TestWeibull(t *testing.T) {
+	checkValues(t, "WeibullPdf", func(x float64) float64 { return stats.WeibullPdf(x, 1.5, 0, 2) }, [][2]float64{{1.3, 0.35803652088564786}, {-1, 0}}, 1e-12)
+	checkValues(t, "WeibullCdf", func(x float64) float64 { return stats.WeibullCdf(x, 1.5, 0, 2) }, [][2]float64{{1.3, 0.4078804687827343}}, 1e-12)
+	checkValues(t, "WeibullSf", func(x float64) float64 { return stats.WeibullSf(x, 1.5, 0, 2) }, [][2]float64{{1.3, 0.5921195312172657}}, 1e-12)
+	checkValues(t, "WeibullPpf", func(p float64) float64 { return stats.WeibullPpf(p, 1.5, 0, 2) }, [][2]float64{{0.9, 3.4874430271928234}}, 1e-12)
+	checkValues(t, "WeibullIsf", func(p float64) float64 { return stats.WeibullIsf(p, 1.5, 0, 2) }, [][2]float64{{0.1, 3.4874430271928234}}, 1e-12)
+
+	if got := stats.WeibullMoment(1, 1.5, 0, 2); !tolerance(got, stats.WeibullMean(1.5, 0, 2), 1e-15) {
+		t.Errorf("WeibullMoment(1) => %v", got)
+	}
+	if got := stats.WeibullEntropy(1.5, 0, 2); !tolerance(got, 1.4800872940856253, 1e-12) {
+		t.Errorf("WeibullEntropy => %v", got)
+	}
+	s := stats.WeibullStats(1, 0, 1, "mvsk")
+	exp := stats.ExponStats(0, 1, "mvsk")
+	for i := range s {
+		if !tolerance(s[i], exp[i], 1e-12) {
+			t.Errorf("WeibullStats(1) => %v != %v", s, exp)
+			break
+		}
+	}
+	if got := stats.WeibullMedian(1, 0, 1); !tolerance(got, math.Ln2, 1e-15) {
+		t.Errorf("WeibullMedian(1) => %v", got)
+	}
+
+	fit := stats.WeibullFit([]float64{1.2, 3.4, 2.2, 5.1, 0.7, 2.9, 4.4, 1.8})
+	if !tolerance(fit[0], 1.9958604479836433, 1e-9) || !tolerance(fit[2], 3.067692874078523, 1e-9) {
+		t.Errorf("WeibullFit => %v", fit)
+	}
+
+	if len(stats.WeibullRvs(1.5, 0, 1, 101)) != 101 {
+		t.Error("Input size=101, Expected 101")
+	}
+}
@@ -0,0 +1,219 @@
+package statistics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"sort"
+)
+
+// checkWeights validates the weights of the input and returns their sum
+func checkWeights(input, weights Float64Data) (float64, error) {
+	if input.Len() == 0 {
+		return math.NaN(), ErrEmptyInput
+	}
+	if weights.Len() != input.Len() {
+		return math.NaN(), ErrSize
+	}
+
+	var sum float64
+	for _, w := range weights {
+		switch {
+		case math.IsNaN(w):
+			return math.NaN(), ErrNaN
+		case math.IsInf(w, 0):
+			return math.NaN(), ErrInfValue
+		case w < 0:
+			return math.NaN(), ErrNegative
+		}
+		sum += w
+	}
+	if sum == 0 {
+		return math.NaN(), ErrZero
+	}
+	return sum, nil
+}
+
+// WeightedMean gets the weighted average of a slice of numbers
+func WeightedMean(input, weights Float64Data) (float64, error) {
+	sum, err := checkWeights(input, weights)
+	if err != nil {
+		return math.NaN(), err
+	}
+
+	var m float64
+	for i, x := range input {
+		m += weights[i] * x
+	}
+	return m / sum, nil
+}
+
+// weightedSquares returns the sum of the weights and of the
+// weighted squared deviations from the weighted mean
+func weightedSquares(input, weights Float64Data) (float64, float64, error) {
+	sum, err := checkWeights(input, weights)
+	if err != nil {
+		return math.NaN(), math.NaN(), err
+	}
+
+	m, _ := WeightedMean(input, weights)
+	var ss float64
+	for i, x := range input {
+		ss += weights[i] * (x - m) * (x - m)
+	}
+	return sum, ss, nil
+}
+
+// WeightedVariance finds the weighted population variance
+func WeightedVariance(input, weights Float64Data) (float64, error) {
+	return WeightedPopulationVariance(input, weights)
+}
+
+// WeightedPopulationVariance finds the weighted population variance,
+// dividing by the sum of the weights
+func WeightedPopulationVariance(input, weights Float64Data) (float64, error) {
+	sum, ss, err := weightedSquares(input, weights)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return ss / sum, nil
+}
+
+// WeightedFrequencyVariance finds the unbiased variance for frequency
+// weights, which count how often each value was observed
+func WeightedFrequencyVariance(input, weights Float64Data) (float64, error) {
+	sum, ss, err := weightedSquares(input, weights)
+	if err != nil {
+		return math.NaN(), err
+	}
+	if sum <= 1 {
+		return math.NaN(), ErrBounds
+	}
+	return ss / (sum - 1), nil
+}
+
+// WeightedReliabilityVariance finds the unbiased variance for reliability
+// weights, which describe the importance or the precision of each value
+func WeightedReliabilityVariance(input, weights Float64Data) (float64, error) {
+	sum, ss, err := weightedSquares(input, weights)
+	if err != nil {
+		return math.NaN(), err
+	}
+
+	var sum2 float64
+	for _, w := range weights {
+		sum2 += w * w
+	}
+	den := sum - sum2/sum
+	if den <= 0 {
+		return math.NaN(), ErrBounds
+	}
+	return ss / den, nil
+}
+
+// WeightedStandardDeviation finds the weighted population standard deviation
+func WeightedStandardDeviation(input, weights Float64Data) (float64, error) {
+	v, err := WeightedPopulationVariance(input, weights)
+	return math.Sqrt(v), err
+}
+
+// WeightedStandardDeviationFrequency finds the standard deviation for frequency weights
+func WeightedStandardDeviationFrequency(input, weights Float64Data) (float64, error) {
+	v, err := WeightedFrequencyVariance(input, weights)
+	return math.Sqrt(v), err
+}
+
+// WeightedStandardDeviationReliability finds the standard deviation for reliability weights
+func WeightedStandardDeviationReliability(input, weights Float64Data) (float64, error) {
+	v, err := WeightedReliabilityVariance(input, weights)
+	return math.Sqrt(v), err
+}
+
+// WeightedPercentile finds the relative standing in a slice of weighted
+// floats. It is the smallest value whose cumulative weight reaches the
+// percent of the total weight. If it is reached exactly, the average
+// with the next value is returned, so equal weights give the same median
+// as Median.
+func WeightedPercentile(input, weights Float64Data, percent float64) (float64, error) {
+	sum, err := checkWeights(input, weights)
+	if err != nil {
+		return math.NaN(), err
+	}
+	if percent <= 0 || percent > 100 {
+		return math.NaN(), ErrBounds
+	}
+
+	// Sort the indices of the values with a positive weight
+	idx := make([]int, 0, input.Len())
+	for i, w := range weights {
+		if w > 0 {
+			idx = append(idx, i)
+		}
+	}
+	sort.Slice(idx, func(a, b int) bool { return input[idx[a]] < input[idx[b]] })
+
+	target := percent / 100 * sum
+	var cum float64
+	for k, i := range idx {
+		cum += weights[i]
+		if cum < target*(1-1e-12) {
+			continue
+		}
+		if cum <= target*(1+1e-12) && k+1 < len(idx) {
+			return (input[i] + input[idx[k+1]]) / 2, nil
+		}
+		return input[i], nil
+	}
+	return input[idx[len(idx)-1]], nil
+}
+
+// WeightedMedian gets the weighted median of a slice of numbers
+func WeightedMedian(input, weights Float64Data) (float64, error) {
+	return WeightedPercentile(input, weights, 50)
+}
+
+// WeightedCorrelation is the weighted Pearson product-moment correlation
+// coefficient between two sets of data
+func WeightedCorrelation(data1, data2, weights Float64Data) (float64, error) {
+	if data1.Len() == 0 || data2.Len() == 0 {
+		return math.NaN(), ErrEmptyInput
+	}
+	if data1.Len() != data2.Len() {
+		return math.NaN(), ErrSize
+	}
+
+	_, ss1, err := weightedSquares(data1, weights)
+	if err != nil {
+		return math.NaN(), err
+	}
+	_, ss2, _ := weightedSquares(data2, weights)
+	if ss1 == 0 || ss2 == 0 {
+		return 0, nil
+	}
+
+	m1, _ := WeightedMean(data1, weights)
+	m2, _ := WeightedMean(data2, weights)
+	var cov float64
+	for i := range data1 {
+		cov += weights[i] * (data1[i] - m1) * (data2[i] - m2)
+	}
+	return cov / math.Sqrt(ss1*ss2), nil
+}
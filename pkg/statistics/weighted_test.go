@@ -0,0 +1,162 @@
+package statistics_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"testing"
+
+	stats "github.com/bhojpur/mathematics/pkg/statistics"
+)
+
+// Frequency weights are equivalent to repeating the values
+var (
+	weightedData    = stats.Float64Data{1, 3, 4, 8, 10}
+	weightedWeights = stats.Float64Data{2, 1, 3, 0, 1}
+	repeatedData    = stats.Float64Data{1, 1, 3, 4, 4, 4, 10}
+)
+
+func TestWeightedMean(t *testing.T) {
+	got, err := stats.WeightedMean(weightedData, weightedWeights)
+	want, _ := stats.Mean(repeatedData)
+	if err != nil || !veryclose(got, want) {
+		t.Errorf("WeightedMean => %v, %v != %v", got, err, want)
+	}
+}
+
+func TestWeightedVariance(t *testing.T) {
+	fns := []struct {
+		name      string
+		weighted  func(input, weights stats.Float64Data) (float64, error)
+		unweighed func(input stats.Float64Data) (float64, error)
+	}{
+		{"WeightedVariance", stats.WeightedVariance, stats.Variance},
+		{"WeightedPopulationVariance", stats.WeightedPopulationVariance, stats.PopulationVariance},
+		{"WeightedFrequencyVariance", stats.WeightedFrequencyVariance, stats.SampleVariance},
+		{"WeightedStandardDeviation", stats.WeightedStandardDeviation, stats.StandardDeviation},
+		{"WeightedStandardDeviationFrequency", stats.WeightedStandardDeviationFrequency, stats.StandardDeviationSample},
+	}
+	for _, f := range fns {
+		got, err := f.weighted(weightedData, weightedWeights)
+		want, _ := f.unweighed(repeatedData)
+		if err != nil || !veryclose(got, want) {
+			t.Errorf("%s => %v, %v != %v", f.name, got, err, want)
+		}
+	}
+
+	// Reliability weights do not depend on the scale of the weights and
+	// equal weights give the sample variance. The weighted sum of squares
+	// is 384/7, the weights sum to 7 and their squares to 15.
+	r1, _ := stats.WeightedReliabilityVariance(weightedData, weightedWeights)
+	r2, _ := stats.WeightedReliabilityVariance(weightedData, stats.Float64Data{20, 10, 30, 0, 10})
+	if !veryclose(r1, r2) {
+		t.Errorf("WeightedReliabilityVariance depends on the scale: %v != %v", r1, r2)
+	}
+	if !veryclose(r1, 384.0/34) {
+		t.Errorf("WeightedReliabilityVariance => %v != %v", r1, 384.0/34)
+	}
+	got, _ := stats.WeightedReliabilityVariance(weightedData, stats.Float64Data{0.5, 0.5, 0.5, 0.5, 0.5})
+	want, _ := stats.SampleVariance(weightedData)
+	if !veryclose(got, want) {
+		t.Errorf("WeightedReliabilityVariance of equal weights => %v != %v", got, want)
+	}
+	sd, _ := stats.WeightedStandardDeviationReliability(weightedData, weightedWeights)
+	if !veryclose(sd, math.Sqrt(r1)) {
+		t.Errorf("WeightedStandardDeviationReliability => %v != %v", sd, math.Sqrt(r1))
+	}
+
+	if _, err := stats.WeightedFrequencyVariance(stats.Float64Data{1, 2}, stats.Float64Data{0.5, 0.5}); err != stats.ErrBounds {
+		t.Errorf("WeightedFrequencyVariance of total weight 1 => %v != %v", err, stats.ErrBounds)
+	}
+	if _, err := stats.WeightedReliabilityVariance(stats.Float64Data{1, 2}, stats.Float64Data{1, 0}); err != stats.ErrBounds {
+		t.Errorf("WeightedReliabilityVariance of a single weight => %v != %v", err, stats.ErrBounds)
+	}
+}
+
+func TestWeightedPercentile(t *testing.T) {
+	for _, p := range []float64{1, 10, 25, 50, 60, 75, 90, 100} {
+		got, err := stats.WeightedPercentile(weightedData, weightedWeights, p)
+		want, _ := stats.PercentileNearestRank(repeatedData, p)
+		if err != nil || got != want {
+			t.Errorf("WeightedPercentile(%v) => %v, %v != %v", p, got, err, want)
+		}
+	}
+
+	// Equal weights give the same median as Median
+	data := stats.Float64Data{4, 1, 3, 2}
+	got, _ := stats.WeightedMedian(data, stats.Float64Data{1, 1, 1, 1})
+	if want, _ := stats.Median(data); got != want {
+		t.Errorf("WeightedMedian of equal weights => %v != %v", got, want)
+	}
+	if got, _ := stats.WeightedMedian(weightedData, weightedWeights); got != 4 {
+		t.Errorf("WeightedMedian => %v != 4", got)
+	}
+
+	if _, err := stats.WeightedPercentile(weightedData, weightedWeights, 0); err != stats.ErrBounds {
+		t.Errorf("WeightedPercentile(0) => %v != %v", err, stats.ErrBounds)
+	}
+}
+
+func TestWeightedCorrelation(t *testing.T) {
+	x := stats.Float64Data{1, 2, 3, 4, 5}
+	y := stats.Float64Data{2, 1, 4, 3, 7}
+
+	got, err := stats.WeightedCorrelation(x, y, stats.Float64Data{1, 1, 1, 1, 1})
+	want, _ := stats.Correlation(x, y)
+	if err != nil || !veryclose(got, want) {
+		t.Errorf("WeightedCorrelation of equal weights => %v, %v != %v", got, err, want)
+	}
+
+	got, _ = stats.WeightedCorrelation(x, y, stats.Float64Data{2, 0, 1, 1, 3})
+	want, _ = stats.Correlation(stats.Float64Data{1, 1, 3, 4, 5, 5, 5}, stats.Float64Data{2, 2, 4, 3, 7, 7, 7})
+	if !veryclose(got, want) {
+		t.Errorf("WeightedCorrelation => %v != %v", got, want)
+	}
+
+	if _, err := stats.WeightedCorrelation(x, y[:4], stats.Float64Data{1, 1, 1, 1, 1}); err != stats.ErrSize {
+		t.Errorf("WeightedCorrelation of different lengths => %v != %v", err, stats.ErrSize)
+	}
+}
+
+func TestWeightedErrors(t *testing.T) {
+	errs := []struct {
+		input, weights stats.Float64Data
+		err            error
+	}{
+		{stats.Float64Data{}, stats.Float64Data{}, stats.ErrEmptyInput},
+		{stats.Float64Data{1, 2}, stats.Float64Data{1}, stats.ErrSize},
+		{stats.Float64Data{1, 2}, stats.Float64Data{1, -1}, stats.ErrNegative},
+		{stats.Float64Data{1, 2}, stats.Float64Data{1, math.NaN()}, stats.ErrNaN},
+		{stats.Float64Data{1, 2}, stats.Float64Data{1, math.Inf(1)}, stats.ErrInfValue},
+		{stats.Float64Data{1, 2}, stats.Float64Data{0, 0}, stats.ErrZero},
+	}
+	for _, e := range errs {
+		if got, err := stats.WeightedMean(e.input, e.weights); err != e.err || !math.IsNaN(got) {
+			t.Errorf("WeightedMean(%v, %v) => %v, %v != %v", e.input, e.weights, got, err, e.err)
+		}
+		if _, err := stats.WeightedMedian(e.input, e.weights); err != e.err {
+			t.Errorf("WeightedMedian(%v, %v) => %v != %v", e.input, e.weights, err, e.err)
+		}
+		if _, err := stats.WeightedVariance(e.input, e.weights); err != e.err {
+			t.Errorf("WeightedVariance(%v, %v) => %v != %v", e.input, e.weights, err, e.err)
+		}
+	}
+}